@@ -0,0 +1,9 @@
+data "confluence_blogposts" "q2" {
+  space = "DEVOPS"
+  from  = "2023-04-01"
+  to    = "2023-06-30"
+}
+
+output "q2_release_notes" {
+  value = [for post in data.confluence_blogposts.q2.blogposts : post.title]
+}
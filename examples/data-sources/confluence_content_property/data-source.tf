@@ -0,0 +1,8 @@
+data "confluence_content_property" "owner" {
+  content_id = "1146920"
+  key        = "owner"
+}
+
+output "owning_team" {
+  value = jsondecode(data.confluence_content_property.owner.value).team
+}
@@ -0,0 +1,7 @@
+data "confluence_content_versions" "runbook" {
+  content_id = "98319"
+}
+
+output "runbook_last_editor" {
+  value = data.confluence_content_versions.runbook.versions[0].author
+}
@@ -0,0 +1,5 @@
+data "confluence_current_user" "me" {}
+
+output "account_id" {
+  value = data.confluence_current_user.me.account_id
+}
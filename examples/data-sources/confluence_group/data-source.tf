@@ -0,0 +1,7 @@
+data "confluence_group" "devops" {
+  name = "devops-team"
+}
+
+output "devops_account_ids" {
+  value = [for member in data.confluence_group.devops.members : member.account_id]
+}
@@ -0,0 +1,16 @@
+data "confluence_spaces" "teams" {
+  type   = "global"
+  status = "current"
+  labels = ["team"]
+}
+
+# Link every team space from an index page
+resource "confluence_content" "index" {
+  type  = "page"
+  space = "DEVOPS"
+  title = "Team spaces"
+  body = join("", [
+    for space in data.confluence_spaces.teams.spaces :
+    "<p><ac:link><ri:space ri:space-key=\"${space.key}\" /><ac:plain-text-link-body><![CDATA[${space.name}]]></ac:plain-text-link-body></ac:link></p>"
+  ])
+}
@@ -0,0 +1,8 @@
+data "confluence_templates" "runbook" {
+  space = "DEVOPS"
+  name  = "Runbook"
+}
+
+output "runbook_template_id" {
+  value = data.confluence_templates.runbook.templates[0].id
+}
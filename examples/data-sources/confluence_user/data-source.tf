@@ -0,0 +1,11 @@
+data "confluence_user" "jane" {
+  display_name = "Jane Doe"
+}
+
+# Mention the user on a page
+resource "confluence_content" "welcome" {
+  type  = "page"
+  space = "DEVOPS"
+  title = "Welcome"
+  body  = "<p>Welcome <ac:link><ri:user ri:account-id=\"${data.confluence_user.jane.account_id}\" /></ac:link></p>"
+}
@@ -14,3 +14,11 @@ provider "confluence" {
   token = "123token"
 
 }
+
+# Confluence Data Center with a personal access token, served under the
+# /confluence context path
+provider "confluence" {
+  host         = "confluence.example.com"
+  context_path = "/confluence"
+  token        = "personal-access-token"
+}
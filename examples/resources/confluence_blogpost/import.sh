@@ -0,0 +1,2 @@
+# Blog posts can be imported by id
+terraform import confluence_blogpost.release 123456
@@ -0,0 +1,6 @@
+resource "confluence_blogpost" "release" {
+  space        = "DEVOPS"
+  title        = "Release 1.0"
+  body         = "<p>Version 1.0 is out.</p>"
+  publish_date = "2023-05-01"
+}
@@ -0,0 +1,2 @@
+# Comments can be imported by id
+terraform import confluence_comment.deployment 123456
@@ -0,0 +1,11 @@
+resource "confluence_comment" "deployment" {
+  content_id = confluence_content.release.id
+  body       = "<p>Deployed to production.</p>"
+}
+
+resource "confluence_comment" "review" {
+  content_id       = confluence_content.release.id
+  body             = "<p>Link the change log here.</p>"
+  location         = "inline"
+  inline_selection = "release notes"
+}
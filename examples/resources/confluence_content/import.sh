@@ -0,0 +1,11 @@
+# Content can be imported by id
+terraform import confluence_content.content 1146920
+
+# by space key and title
+terraform import confluence_content.content "DEVOPS/Example Title"
+
+# by space key, ancestor titles and title
+terraform import confluence_content.content "DEVOPS:Runbooks/Databases/Example Title"
+
+# or by page URL
+terraform import confluence_content.content https://example.atlassian.net/wiki/spaces/DEVOPS/pages/1146920/Example+Title
@@ -13,3 +13,62 @@ resource "confluence_content" "content" {
   type  = "page"
 }
 
+# Purge the page on destroy so its title can be reused
+resource "confluence_content" "content" {
+  body          = "<h1>Contente created from terraform</h1>"
+  space         = "DEVOPS"
+  title         = "Example Title"
+  type          = "page"
+  deletion_mode = "purge"
+}
+
+# Take ownership of a page that already exists in the space
+resource "confluence_content" "content" {
+  body           = "<h1>Contente created from terraform</h1>"
+  space          = "DEVOPS"
+  title          = "Existing Title"
+  type           = "page"
+  adopt_existing = true
+}
+
+
+# Render a confluence page template, page authors take over the body after creation
+resource "confluence_content" "content" {
+  space              = "DEVOPS"
+  title              = "Billing runbook"
+  type               = "page"
+  template_id        = confluence_template.runbook.id
+  template_variables = { service = "billing" }
+  manage_body        = false
+}
+
+# Render a blueprint of the space
+resource "confluence_content" "content" {
+  space                = "DEVOPS"
+  title                = "Weekly meeting"
+  type                 = "page"
+  blueprint_module_key = "meeting-notes-page"
+  template_variables   = { date = "2023-05-01", attendees = "devops" }
+}
+
+# Resolve the relative links of a page written in the repository to the other pages
+resource "confluence_content" "content" {
+  space       = "DEVOPS"
+  title       = "Operations"
+  type        = "page"
+  body        = "<p>See the <a href=\"oncall.md#paging\">paging policy</a> and the <a href=\"topology.png\">topology</a>.</p>"
+  source_path = "docs/ops/index.md"
+  page_links = {
+    "docs/ops/oncall.md" = confluence_content.oncall.id
+  }
+}
+
+# Place the page after its sibling in the page tree
+resource "confluence_content" "content" {
+  body      = "<h1>Rollback</h1>"
+  space     = "DEVOPS"
+  title     = "Rollback runbook"
+  type      = "page"
+  parent_id = confluence_content.runbooks.id
+  after_id  = confluence_content.deploy.id
+}
@@ -0,0 +1,15 @@
+resource "confluence_content" "team" {
+  type  = "page"
+  title = "Team runbooks"
+  space = "TEAM"
+  body  = "<p>Runbooks of the team.</p>"
+}
+
+# Copies the runbooks with their children under the team page.
+resource "confluence_content_copy" "runbooks" {
+  source_id        = "65538"
+  parent_id        = confluence_content.team.id
+  include_children = true
+  title_prefix     = "Team "
+  copy_labels      = false
+}
@@ -0,0 +1,2 @@
+# Content properties can be imported by content id and property key
+terraform import confluence_content_property.owner 1146920/owner
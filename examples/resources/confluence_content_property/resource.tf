@@ -0,0 +1,9 @@
+resource "confluence_content_property" "owner" {
+  content_id = confluence_content.content.id
+  key        = "owner"
+  value = jsonencode({
+    team        = "devops"
+    source      = "docs/runbooks/failover.md"
+    review_date = "2024-06-01"
+  })
+}
@@ -0,0 +1,10 @@
+data "confluence_content_versions" "runbook" {
+  content_id = "98319"
+}
+
+# Roll the runbook back to the version before its last edit.
+resource "confluence_content_restore" "runbook" {
+  content_id = data.confluence_content_versions.runbook.id
+  version    = data.confluence_content_versions.runbook.versions[1].number
+  message    = "Rolled back the automated edit"
+}
@@ -0,0 +1,2 @@
+# Content watchers can be imported by content id
+terraform import confluence_content_watchers.runbook 1146920
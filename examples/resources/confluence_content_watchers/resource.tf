@@ -0,0 +1,9 @@
+data "confluence_user" "owner" {
+  display_name = "Jane Doe"
+}
+
+# The owners of the runbook are notified when someone edits it.
+resource "confluence_content_watchers" "runbook" {
+  content_id  = confluence_content.runbook.id
+  account_ids = [data.confluence_user.owner.account_id]
+}
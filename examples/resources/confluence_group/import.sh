@@ -0,0 +1,2 @@
+# Groups can be imported by name
+terraform import confluence_group.devops devops-team
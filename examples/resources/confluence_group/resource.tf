@@ -0,0 +1,3 @@
+resource "confluence_group" "devops" {
+  name = "devops-team"
+}
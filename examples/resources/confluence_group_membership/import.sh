@@ -0,0 +1,2 @@
+# Group memberships can be imported by group name and username
+terraform import confluence_group_membership.jdoe devops-team/jdoe
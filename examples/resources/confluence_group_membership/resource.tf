@@ -0,0 +1,8 @@
+resource "confluence_group" "devops" {
+  name = "devops-team"
+}
+
+resource "confluence_group_membership" "jdoe" {
+  group_name = confluence_group.devops.name
+  username   = "jdoe"
+}
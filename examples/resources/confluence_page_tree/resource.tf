@@ -0,0 +1,13 @@
+resource "confluence_content" "docs" {
+  type  = "page"
+  space = "DEVOPS"
+  title = "Service documentation"
+  body  = "<p>Published from the service repository.</p>"
+}
+
+resource "confluence_page_tree" "docs" {
+  space      = "DEVOPS"
+  parent_id  = confluence_content.docs.id
+  source_dir = "${path.module}/docs"
+  pattern    = "**/*.md"
+}
@@ -0,0 +1,14 @@
+# Snapshot the space every quarter, changing the quarter exports it again.
+resource "confluence_space_export" "devops" {
+  space_key = "DEVOPS"
+  format    = "xml"
+  path      = "${path.module}/exports/devops-2023-Q2.xml.zip"
+
+  triggers = {
+    quarter = "2023-Q2"
+  }
+}
+
+output "devops_export_checksum" {
+  value = confluence_space_export.devops.checksum
+}
@@ -0,0 +1,2 @@
+# Space properties can be imported by space key and property key
+terraform import confluence_space_property.billing DEVOPS/billing
@@ -0,0 +1,8 @@
+resource "confluence_space_property" "billing" {
+  space_key = "DEVOPS"
+  key       = "billing"
+  value = jsonencode({
+    team        = "devops"
+    cost_centre = "CC-100"
+  })
+}
@@ -0,0 +1,2 @@
+# Space watchers can be imported by space key
+terraform import confluence_space_watchers.devops DEVOPS
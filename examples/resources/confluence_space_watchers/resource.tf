@@ -0,0 +1,8 @@
+data "confluence_user" "owner" {
+  display_name = "Jane Doe"
+}
+
+resource "confluence_space_watchers" "devops" {
+  space_key   = "DEVOPS"
+  account_ids = [data.confluence_user.owner.account_id]
+}
@@ -0,0 +1,2 @@
+# Templates can be imported by id
+terraform import confluence_template.runbook 98305
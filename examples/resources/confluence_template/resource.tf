@@ -0,0 +1,12 @@
+resource "confluence_template" "runbook" {
+  name        = "Runbook"
+  description = "Runbook of a service"
+  body        = file("templates/runbook.xml")
+  labels      = ["runbook"]
+  space       = "DEVOPS"
+}
+
+resource "confluence_template" "adr" {
+  name = "Architecture decision record"
+  body = "<h2>Context</h2><h2>Decision</h2><h2>Consequences</h2>"
+}
@@ -0,0 +1,247 @@
+// Package cassette records HTTP interactions of the confluence client into
+// JSON fixtures and replays them, so client tests run without credentials.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Mode selects whether a Recorder sends requests or replays a cassette.
+type Mode int
+
+const (
+	// ModeReplay answers requests from the cassette, unmatched requests fail.
+	ModeReplay Mode = iota
+	// ModeRecord sends requests to the real transport and stores them.
+	ModeRecord
+)
+
+// redacted replaces secrets in recorded interactions.
+const redacted = "REDACTED"
+
+// sanitisedEmail replaces email addresses in recorded interactions.
+const sanitisedEmail = "user@example.com"
+
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+
+// secretHeaders are not stored, their values are replaced by REDACTED.
+var secretHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// Cassette is the fixture file content.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request, URL is the path and query without the host.
+type Request struct {
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"`
+}
+
+type Response struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"`
+}
+
+// Recorder is an http.RoundTripper that records or replays interactions,
+// set it as the Transport of confluence.API.Client.
+type Recorder struct {
+	mode      Mode
+	path      string
+	transport http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// New returns a Recorder for the cassette file at path. In replay mode the
+// file must exist, in record mode requests are sent with transport, which
+// defaults to http.DefaultTransport.
+func New(path string, mode Mode, transport http.RoundTripper) (*Recorder, error) {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	r := &Recorder{
+		mode:      mode,
+		path:      path,
+		transport: transport,
+	}
+	if mode == ModeReplay {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("cassette.New calls os.ReadFile and returns an error: %w", err)
+		}
+		err = json.Unmarshal(b, &r.cassette)
+		if err != nil {
+			return nil, fmt.Errorf("cassette.New calls json.Unmarshal and returns an error: %w", err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+	return r, nil
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(&req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("cassette RoundTrip reads the request body and returns an error: %w", err)
+	}
+	recorded := Request{
+		Method:  req.Method,
+		URL:     sanitise(req.URL.RequestURI()),
+		Headers: sanitiseHeaders(req.Header, "Content-Type", "Accept", "Authorization"),
+		Body:    sanitise(string(body)),
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("cassette RoundTrip reads the response body and returns an error: %w", err)
+	}
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: recorded,
+		Response: Response{
+			Status:  resp.StatusCode,
+			Headers: sanitiseHeaders(resp.Header, "Content-Type", "Location"),
+			Body:    sanitise(string(respBody)),
+		},
+	})
+	return resp, nil
+}
+
+// replay answers with the first unused interaction matching the request.
+func (r *Recorder) replay(req *http.Request, recorded Request) (*http.Response, error) {
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || !matches(interaction.Request, recorded) {
+			continue
+		}
+		r.used[i] = true
+		header := http.Header{}
+		for k, v := range interaction.Response.Headers {
+			header.Set(k, v)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.Status, http.StatusText(interaction.Response.Status)),
+			StatusCode:    interaction.Response.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("cassette %s has no unused interaction for %s %s", r.path, recorded.Method, recorded.URL)
+}
+
+// Unused returns the interactions of a replayed cassette that were not requested.
+func (r *Recorder) Unused() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	unused := []Interaction{}
+	for i, interaction := range r.cassette.Interactions {
+		if !r.used[i] {
+			unused = append(unused, interaction)
+		}
+	}
+	return unused
+}
+
+// Save writes the recorded interactions to the cassette file, it does
+// nothing in replay mode.
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	b, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cassette Save calls json.MarshalIndent and returns an error: %w", err)
+	}
+	err = os.MkdirAll(filepath.Dir(r.path), 0o755)
+	if err != nil {
+		return fmt.Errorf("cassette Save calls os.MkdirAll and returns an error: %w", err)
+	}
+	return os.WriteFile(r.path, append(b, '\n'), 0o644)
+}
+
+// matches compares method, URL and body, JSON bodies are compared by value.
+func matches(recorded, req Request) bool {
+	if recorded.Method != req.Method || recorded.URL != req.URL {
+		return false
+	}
+	if recorded.Body == req.Body {
+		return true
+	}
+	var a, b any
+	if json.Unmarshal([]byte(recorded.Body), &a) != nil || json.Unmarshal([]byte(req.Body), &b) != nil {
+		return false
+	}
+	ja, _ := json.Marshal(a)
+	jb, _ := json.Marshal(b)
+	return bytes.Equal(ja, jb)
+}
+
+// readBody reads the body and replaces it with a copy so it can be sent.
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	b, err := io.ReadAll(*body)
+	(*body).Close()
+	if err != nil {
+		return nil, err
+	}
+	*body = io.NopCloser(bytes.NewReader(b))
+	return b, nil
+}
+
+// sanitiseHeaders keeps the listed headers, secret headers are redacted.
+func sanitiseHeaders(header http.Header, keep ...string) map[string]string {
+	headers := map[string]string{}
+	for _, k := range keep {
+		v := header.Get(k)
+		if v == "" {
+			continue
+		}
+		headers[k] = sanitise(v)
+		for _, secret := range secretHeaders {
+			if strings.EqualFold(k, secret) {
+				headers[k] = redacted
+			}
+		}
+	}
+	return headers
+}
+
+func sanitise(s string) string {
+	return emailPattern.ReplaceAllString(s, sanitisedEmail)
+}
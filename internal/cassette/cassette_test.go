@@ -0,0 +1,97 @@
+package cassette
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"email":"jane.doe@company.com"}`))
+	}))
+	defer server.Close()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	recorder, err := New(path, ModeRecord, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: recorder}
+	req, _ := http.NewRequest(http.MethodPost, server.URL+"/content?owner=jane.doe@company.com", strings.NewReader(`{"a": 1, "b": 2}`))
+	req.SetBasicAuth("jane.doe@company.com", "secret")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	err = recorder.Save()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replayer, err := New(path, ModeReplay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	interaction := replayer.cassette.Interactions[0]
+	if interaction.Request.Headers["Authorization"] != redacted {
+		t.Errorf("Authorization header, wants %s, but got %s", redacted, interaction.Request.Headers["Authorization"])
+	}
+	if interaction.Request.URL != "/content?owner="+sanitisedEmail {
+		t.Errorf("URL, wants the email sanitised, but got %s", interaction.Request.URL)
+	}
+
+	testCases := []struct {
+		desc    string
+		method  string
+		body    string
+		wantErr bool
+	}{
+		{
+			desc:   "Equal JSON body in another order is matched",
+			method: http.MethodPost,
+			body:   `{"b":2,"a":1}`,
+		},
+		{
+			desc:    "Interactions are used once",
+			method:  http.MethodPost,
+			body:    `{"b":2,"a":1}`,
+			wantErr: true,
+		},
+		{
+			desc:    "Unmatched request fails",
+			method:  http.MethodGet,
+			wantErr: true,
+		},
+	}
+	client = &http.Client{Transport: replayer}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			req, _ := http.NewRequest(tC.method, "https://confluence.example.com/content?owner=jane.doe@company.com", strings.NewReader(tC.body))
+			resp, err := client.Do(req)
+			if tC.wantErr {
+				if err == nil {
+					t.Error("wants an error, but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if string(b) != `{"email":"`+sanitisedEmail+`"}` {
+				t.Errorf("wants the sanitised body, but got %s", b)
+			}
+		})
+	}
+	if len(replayer.Unused()) != 0 {
+		t.Errorf("wants every interaction used, but got %d unused", len(replayer.Unused()))
+	}
+}
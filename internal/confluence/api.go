@@ -2,9 +2,12 @@ package confluence
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 )
 
@@ -13,26 +16,93 @@ type API struct {
 	Endpoint *url.URL
 	user     string
 	token    string
+	// pollInterval is the time to wait between long task status requests.
+	pollInterval time.Duration
 }
 
+// ErrNotFound is wrapped by the errors of the requests confluence answers with
+// 404 Not Found, e.g: a page deleted outside terraform.
+var ErrNotFound = errors.New("not found")
+
+// xsrfNoCheck is the X-Atlassian-Token header value that opts out of the
+// XSRF check confluence runs on uploads and watch changes.
+const xsrfNoCheck = "no-check"
+
+// responseHeaderTimeout bounds the wait for the response of every request, a
+// stalled server fails the request instead of hanging terraform.
+const responseHeaderTimeout = 30 * time.Second
+
+// NewAPI returns a client for the confluence site at host. Every request
+// waits at most responseHeaderTimeout for its response, and is bounded as a
+// whole by the deadline of its context, e.g: the create or update timeout of
+// a resource. The client has no overall timeout so large downloads, e.g: a
+// space export, are only bounded by their context.
+//
+// The REST API is served under the context path of the site: the path of
+// host when it has one, e.g: https://confluence.example.com/confluence,
+// /wiki on confluence cloud and the root path on Data Center otherwise. Use
+// SetContextPath to override it.
 func NewAPI(email, token, host string) (*API, error) {
-	timeout := 30 * time.Second
-	return &API{
+	// A host with scheme, e.g: http://localhost:8090, keeps its scheme.
+	raw := host
+	if !strings.Contains(host, "://") {
+		raw = "https://" + host
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("NewAPI calls to url.Parse method and returns an error: %w", err)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("NewAPI gets an invalid host: %q", host)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = responseHeaderTimeout
+	a := &API{
 		Client: &http.Client{
-			Timeout: timeout,
+			Transport: newLoggingTransport(transport, token),
 		},
 		Endpoint: &url.URL{
-			Host:   host,
-			Path:   "/wiki/rest/api",
-			Scheme: "https",
+			Host:   u.Host,
+			Scheme: u.Scheme,
 		},
-		user:  email,
-		token: token,
-	}, nil
+		user:         email,
+		token:        token,
+		pollInterval: 2 * time.Second,
+	}
+	contextPath := u.Path
+	if contextPath == "" && a.IsCloud() {
+		contextPath = "/wiki"
+	}
+	a.SetContextPath(contextPath)
+	return a, nil
 }
 
-// Build a request and send it to confluence api service.
-func (a *API) requestAPI(method, path string, body []byte) (*http.Response, error) {
+// SetContextPath sets the path the site is served under, the REST API is
+// served from <contextPath>/rest/api. An empty context path is the root path.
+func (a *API) SetContextPath(contextPath string) {
+	contextPath = strings.Trim(contextPath, "/")
+	if contextPath != "" {
+		contextPath = "/" + contextPath
+	}
+	a.Endpoint.Path = contextPath + "/rest/api"
+}
+
+// IsCloud reports whether the API is a confluence cloud site, they are
+// served from atlassian.net. Some endpoints, e.g: the group administration,
+// only exist on confluence Data Center.
+func (a *API) IsCloud() bool {
+	return strings.HasSuffix(a.Endpoint.Hostname(), ".atlassian.net")
+}
+
+// Build a request and send it to confluence api service, the request is
+// cancelled when ctx is done.
+func (a *API) requestAPI(ctx context.Context, method, path string, body []byte) (*http.Response, error) {
+	return a.requestAPIWithHeader(ctx, method, path, body, nil)
+}
+
+// requestAPIWithHeader sends a request like requestAPI, the header replaces
+// the default headers, e.g: the Content-Type of multipart uploads.
+func (a *API) requestAPIWithHeader(ctx context.Context, method, path string, body []byte, header http.Header) (*http.Response, error) {
 	switch method {
 	case "GET":
 		method = http.MethodGet
@@ -45,17 +115,25 @@ func (a *API) requestAPI(method, path string, body []byte) (*http.Response, erro
 	default:
 		method = http.MethodGet
 	}
-	// Add path to URL base path object
-	path, _ = url.JoinPath(a.Endpoint.String(), path)
+	// Add path to URL base path object, the query string is kept as it is
+	ref, err := url.Parse(path)
+	if err != nil {
+		return nil, fmt.Errorf("requestAPI calls to url.Parse method and returns an error: %w", err)
+	}
+	reqURL := a.Endpoint.JoinPath(ref.EscapedPath())
+	reqURL.RawQuery = ref.RawQuery
 	// Create a Request object
 	bodyReader := bytes.NewReader(body)
-	req, err := http.NewRequest(method, path, bodyReader)
+	req, err := http.NewRequestWithContext(ctx, method, reqURL.String(), bodyReader)
 	if err != nil {
-		return nil, fmt.Errorf("requestAPI calls to http.NewRequest method and returns an error: %w", err)
+		return nil, fmt.Errorf("requestAPI calls to http.NewRequestWithContext method and returns an error: %w", err)
 	}
 	// Set Headers
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
+	for key, values := range header {
+		req.Header[key] = values
+	}
 	// Add basic auth to headers
 	a.Auth(req)
 	// Send request
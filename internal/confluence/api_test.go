@@ -1,13 +1,18 @@
 package confluence
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluencefake"
 )
 
 func TestRequestAPI(t *testing.T) {
@@ -53,7 +58,7 @@ func TestRequestAPI(t *testing.T) {
 		token: "123456",
 	}
 
-	resp, err := client.requestAPI(http.MethodPost, "/content", JsonRequest)
+	resp, err := client.requestAPI(context.Background(), http.MethodPost, "/content", JsonRequest)
 	if err != nil {
 		t.Errorf("requestAPI error: %v", err)
 	}
@@ -75,3 +80,202 @@ func TestRequestAPI(t *testing.T) {
 		t.Errorf("Content space key, wants DEVOPS, but got %v", content.Space.Key)
 	}
 }
+
+func TestIsCloud(t *testing.T) {
+	testCases := []struct {
+		desc string
+		host string
+		want bool
+	}{
+		{
+			desc: "cloud site",
+			host: "example.atlassian.net",
+			want: true,
+		},
+		{
+			desc: "data center",
+			host: "confluence.example.com",
+			want: false,
+		},
+		{
+			desc: "data center with scheme and port",
+			host: "http://localhost:8090",
+			want: false,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			api, err := NewAPI("user@email.com", "123456", tC.host)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := api.IsCloud(); got != tC.want {
+				t.Errorf("wants %v, but got %v", tC.want, got)
+			}
+		})
+	}
+}
+
+func TestNewAPIEndpoint(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		host        string
+		contextPath string
+		override    bool
+		want        string
+	}{
+		{
+			desc: "cloud site",
+			host: "example.atlassian.net",
+			want: "https://example.atlassian.net/wiki/rest/api",
+		},
+		{
+			desc: "data center",
+			host: "confluence.example.com",
+			want: "https://confluence.example.com/rest/api",
+		},
+		{
+			desc: "data center with context path in host",
+			host: "http://localhost:8090/confluence/",
+			want: "http://localhost:8090/confluence/rest/api",
+		},
+		{
+			desc:        "data center with context path",
+			host:        "confluence.example.com",
+			contextPath: "confluence",
+			override:    true,
+			want:        "https://confluence.example.com/confluence/rest/api",
+		},
+		{
+			desc:     "root context path",
+			host:     "https://confluence.example.com/wiki",
+			override: true,
+			want:     "https://confluence.example.com/rest/api",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			api, err := NewAPI("user@email.com", "123456", tC.host)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tC.override {
+				api.SetContextPath(tC.contextPath)
+			}
+			if got := api.Endpoint.String(); got != tC.want {
+				t.Errorf("wants %s, but got %s", tC.want, got)
+			}
+		})
+	}
+}
+
+func TestAuth(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		user  string
+		token string
+		want  string
+	}{
+		{
+			desc:  "basic auth",
+			user:  "user@email.com",
+			token: "123456",
+			want:  "Basic dXNlckBlbWFpbC5jb206MTIzNDU2",
+		},
+		{
+			desc:  "personal access token",
+			token: "123456",
+			want:  "Bearer 123456",
+		},
+		{
+			desc: "no credentials",
+			user: "user@email.com",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			api, err := NewAPI(tC.user, tC.token, "confluence.example.com")
+			if err != nil {
+				t.Fatal(err)
+			}
+			req := httptest.NewRequest(http.MethodGet, "https://confluence.example.com/rest/api/space", nil)
+			api.Auth(req)
+			if got := req.Header.Get("Authorization"); got != tC.want {
+				t.Errorf("wants %q, but got %q", tC.want, got)
+			}
+		})
+	}
+}
+
+func TestRequestAPIContextDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+	api, err := NewAPI("user@email.com", "123456", server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if api.Client.Timeout != 0 {
+		t.Errorf("wants no client timeout, requests are bounded by their context, but got %v", api.Client.Timeout)
+	}
+	transport := api.Client.Transport.(*loggingTransport).next.(*http.Transport)
+	if transport.ResponseHeaderTimeout != responseHeaderTimeout {
+		t.Errorf("wants a response header timeout of %v, but got %v", responseHeaderTimeout, transport.ResponseHeaderTimeout)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = api.requestAPI(ctx, http.MethodGet, "/space", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("wants a context deadline error, but got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("wants the request cancelled at the deadline, but it took %v", elapsed)
+	}
+}
+func TestRequestAPIResponseHeaderTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+	api, err := NewAPI("user@email.com", "123456", server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	api.Client.Transport.(*loggingTransport).next.(*http.Transport).ResponseHeaderTimeout = 50 * time.Millisecond
+
+	// A request without deadline, e.g: of a data source, fails when the
+	// server stalls.
+	start := time.Now()
+	_, err = api.requestAPI(context.Background(), http.MethodGet, "/space", nil)
+	if err == nil {
+		t.Error("wants a timeout error, but got none")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("wants the request stopped at the response header timeout, but it took %v", elapsed)
+	}
+}
+
+// fakeAPI returns an API client served by an in-memory confluence server
+// with a DEVOPS space.
+func fakeAPI(t *testing.T) (*API, *confluencefake.Server) {
+	server := confluencefake.NewServer()
+	t.Cleanup(server.Close)
+	server.AddSpace("DEVOPS", "devops")
+	server.AddGroup("confluence-users", confluencefake.CurrentAccountId)
+
+	api, err := NewAPI("user@email.com", "123456", server.SiteURL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	api.pollInterval = time.Millisecond
+	return api, server
+}
@@ -0,0 +1,98 @@
+package confluence
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+)
+
+// UploadAttachment attaches a file to a content, a new version of the
+// attachment is created when the content has a file with the same name.
+func (a *API) UploadAttachment(ctx context.Context, contentId, filename, mediaType string, data []byte) (*Content, error) {
+	body, contentType, err := attachmentBody(filename, mediaType, data)
+	if err != nil {
+		return nil, fmt.Errorf("UploadAttachment calls attachmentBody and returns an error: %w", err)
+	}
+	header := http.Header{}
+	header.Set("Content-Type", contentType)
+	// Confluence rejects multipart requests without the XSRF opt out.
+	header.Set("X-Atlassian-Token", xsrfNoCheck)
+	resp, err := a.requestAPIWithHeader(ctx, http.MethodPut, fmt.Sprintf("/content/%s/child/attachment", url.PathEscape(contentId)), body, header)
+	if err != nil {
+		return nil, fmt.Errorf("UploadAttachment calls a.requestAPIWithHeader and returns an error: %w", err)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("UploadAttachment calls io.ReadAll and returns an error: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var msg string
+		switch resp.StatusCode {
+		case http.StatusBadRequest:
+			msg = "Bad request, the attachment is invalid or exceeds the maximum size"
+		case http.StatusUnauthorized:
+			msg = "Authentication credentials are incorrect or missing from the request"
+		case http.StatusForbidden:
+			msg = "The calling user does not have permission to add attachments to the content"
+		case http.StatusNotFound:
+			msg = "Not Found, there is no content with the given id or the calling user can not view it"
+		default:
+			msg = fmt.Sprintf("Invalid Status Code: %v", resp.StatusCode)
+		}
+		return nil, fmt.Errorf("UploadAttachment gets error: %v, message: %s", msg, string(b))
+	}
+	var attachments ContentArray
+	err = json.Unmarshal(b, &attachments)
+	if err != nil {
+		return nil, fmt.Errorf("UploadAttachment calls json.Unmarshal and returns an error: %w", err)
+	}
+	if len(attachments.Results) == 0 {
+		return nil, fmt.Errorf("UploadAttachment gets error: no attachment returned for %s", filename)
+	}
+	return &attachments.Results[0], nil
+}
+
+// attachmentBody returns the multipart body of an upload and its content
+// type. The boundary is derived from the file, so the same upload always
+// sends the same body.
+func attachmentBody(filename, mediaType string, data []byte) ([]byte, string, error) {
+	sum := sha256.Sum256(append([]byte(filename), data...))
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	err := w.SetBoundary("confluence-" + hex.EncodeToString(sum[:16]))
+	if err != nil {
+		return nil, "", err
+	}
+	if mediaType == "" {
+		mediaType = "application/octet-stream"
+	}
+	h := textproto.MIMEHeader{}
+	h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename=%q`, filename))
+	h.Set("Content-Type", mediaType)
+	part, err := w.CreatePart(h)
+	if err != nil {
+		return nil, "", err
+	}
+	_, err = part.Write(data)
+	if err != nil {
+		return nil, "", err
+	}
+	err = w.WriteField("minorEdit", "true")
+	if err != nil {
+		return nil, "", err
+	}
+	err = w.Close()
+	if err != nil {
+		return nil, "", err
+	}
+	return body.Bytes(), w.FormDataContentType(), nil
+}
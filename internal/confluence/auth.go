@@ -2,9 +2,14 @@ package confluence
 
 import "net/http"
 
-// Auth Adds user and token to request header, implements basic auth.
+// Auth Adds user and token to request header. It implements basic auth with
+// the user and the API token, or bearer auth with a personal access token of
+// confluence Data Center when there is no user.
 func (a *API) Auth(req *http.Request) {
-	if a.user != "" && a.token != "" {
+	switch {
+	case a.user != "" && a.token != "":
 		req.SetBasicAuth(a.user, a.token)
+	case a.token != "":
+		req.Header.Set("Authorization", "Bearer "+a.token)
 	}
 }
@@ -0,0 +1,59 @@
+package confluence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// blogPostExpand adds the history to the expanded properties, it holds the
+// posting date of the blog post.
+const blogPostExpand = contentExpand + ",history"
+
+// GetBlogPostById returns a blog post with its posting date.
+func (a *API) GetBlogPostById(ctx context.Context, id string) (*Content, error) {
+	resp, err := a.requestAPI(ctx, http.MethodGet, fmt.Sprintf("/content/%s?expand=%s", url.PathEscape(id), blogPostExpand), nil)
+	if err != nil {
+		return nil, fmt.Errorf("GetBlogPostById calls a.requestAPI and returns an error: %w", err)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("GetBlogPostById calls io.ReadAll and returns an error: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var msg string
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			msg = "Authentication credentials are incorrect or missing from the request"
+		case http.StatusForbidden:
+			msg = "User does not have correct permission to read this content"
+		case http.StatusNotFound:
+			msg = "Not Found, could be either there is no blog post with the given id or the calling user does not have permission to view it"
+			return nil, fmt.Errorf("GetBlogPostById gets error: %w, message: %s", ErrNotFound, msg)
+		default:
+			msg = fmt.Sprintf("Invalid Status Code: %v", resp.StatusCode)
+		}
+		return nil, fmt.Errorf("GetBlogPostById gets error: %v, message: %s", msg, string(b))
+	}
+	var content Content
+	err = json.Unmarshal(b, &content)
+	if err != nil {
+		return nil, fmt.Errorf("GetBlogPostById calls json.Unmarshal and returns an error: %w", err)
+	}
+	if content.Type != "blogpost" {
+		return nil, fmt.Errorf("GetBlogPostById gets error: content %s is a %s, not a blogpost", id, content.Type)
+	}
+	return &content, nil
+}
+
+// GetBlogPosts returns the blog posts that match the query with their posting
+// date, the type and the expanded properties of the query are overridden.
+func (a *API) GetBlogPosts(ctx context.Context, query ContentQuery) ([]Content, error) {
+	query.Type = "blogpost"
+	query.Expand = []string{"version", "space", "history"}
+	return a.GetContents(ctx, query)
+}
@@ -0,0 +1,51 @@
+package confluence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// commentExpand are the properties expanded on comment requests, the
+// container is the page the comment belongs to.
+const commentExpand = "body.storage,version,container,extensions.inlineProperties"
+
+// GetCommentById returns a comment with its container and location.
+func (a *API) GetCommentById(ctx context.Context, id string) (*Content, error) {
+	resp, err := a.requestAPI(ctx, http.MethodGet, fmt.Sprintf("/content/%s?expand=%s", url.PathEscape(id), commentExpand), nil)
+	if err != nil {
+		return nil, fmt.Errorf("GetCommentById calls a.requestAPI and returns an error: %w", err)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("GetCommentById calls io.ReadAll and returns an error: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var msg string
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			msg = "Authentication credentials are incorrect or missing from the request"
+		case http.StatusForbidden:
+			msg = "User does not have correct permission to read this content"
+		case http.StatusNotFound:
+			msg = "Not Found, could be either there is no comment with the given id or the calling user does not have permission to view it"
+			return nil, fmt.Errorf("GetCommentById gets error: %w, message: %s", ErrNotFound, msg)
+		default:
+			msg = fmt.Sprintf("Invalid Status Code: %v", resp.StatusCode)
+		}
+		return nil, fmt.Errorf("GetCommentById gets error: %v, message: %s", msg, string(b))
+	}
+	var content Content
+	err = json.Unmarshal(b, &content)
+	if err != nil {
+		return nil, fmt.Errorf("GetCommentById calls json.Unmarshal and returns an error: %w", err)
+	}
+	if content.Type != "comment" {
+		return nil, fmt.Errorf("GetCommentById gets error: content %s is a %s, not a comment", id, content.Type)
+	}
+	return &content, nil
+}
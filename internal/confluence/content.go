@@ -1,19 +1,105 @@
 package confluence
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 )
 
-func (a *API) GetContents() ([]Content, error) {
-	return []Content{}, nil
+// contentExpand are the properties expanded on content requests, confluence
+// does not return the body nor the ancestors unless they are expanded.
+const contentExpand = "body.storage,version,space,ancestors"
+
+// contentPageLimit is the number of results requested per page on content listings.
+const contentPageLimit = 50
+
+// ContentQuery filters the contents returned by GetContents, empty fields are not sent.
+type ContentQuery struct {
+	SpaceKey string
+	Title    string
+	Type     string
+	Status   string
+	// PostingDay returns only the blog posts published on the day, yyyy-mm-dd.
+	PostingDay string
+	Expand     []string
 }
 
-func (a *API) GetContentById(id string) (*Content, error) {
+func (q ContentQuery) values() url.Values {
+	params := url.Values{}
+	if q.SpaceKey != "" {
+		params.Set("spaceKey", q.SpaceKey)
+	}
+	if q.Title != "" {
+		params.Set("title", q.Title)
+	}
+	if q.Type != "" {
+		params.Set("type", q.Type)
+	}
+	if q.Status != "" {
+		params.Set("status", q.Status)
+	}
+	if q.PostingDay != "" {
+		params.Set("postingDay", q.PostingDay)
+	}
+	if len(q.Expand) > 0 {
+		params.Set("expand", strings.Join(q.Expand, ","))
+	}
+	return params
+}
+
+// GetContents returns every content that matches the query, it follows the
+// pagination until the last page of results.
+func (a *API) GetContents(ctx context.Context, query ContentQuery) ([]Content, error) {
+	contents := []Content{}
+	params := query.values()
+	start := 0
+	for {
+		params.Set("start", strconv.Itoa(start))
+		params.Set("limit", strconv.Itoa(contentPageLimit))
+		resp, err := a.requestAPI(ctx, http.MethodGet, "/content?"+params.Encode(), []byte(`{}`))
+		if err != nil {
+			return nil, fmt.Errorf("GetContents calls a.requestAPI and returns an error: %w", err)
+		}
+		b, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("GetContents calls io.ReadAll and returns an error: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			var msg string
+			switch resp.StatusCode {
+			case http.StatusBadRequest:
+				msg = "Bad request, could be either an invalid query or sub-expansions limit exceeds"
+			case http.StatusUnauthorized:
+				msg = "Authentication credentials are incorrect or missing from the request"
+			case http.StatusNotFound:
+				msg = "The calling user does not have permission to view the content"
+			default:
+				msg = fmt.Sprintf("Invalid Status Code: %v", resp.StatusCode)
+			}
+			return nil, fmt.Errorf("GetContents gets error: %v, message: %s", msg, string(b))
+		}
+		var page ContentArray
+		err = json.Unmarshal(b, &page)
+		if err != nil {
+			return nil, fmt.Errorf("GetContents calls json.Unmarshal and returns an error: %w", err)
+		}
+		contents = append(contents, page.Results...)
+		if len(page.Results) < contentPageLimit {
+			return contents, nil
+		}
+		start += len(page.Results)
+	}
+}
+
+func (a *API) GetContentById(ctx context.Context, id string) (*Content, error) {
 	var content Content
-	resp, err := a.requestAPI("GET", fmt.Sprintf("/content/%v", id), []byte(`{}`))
+	resp, err := a.requestAPI(ctx, "GET", fmt.Sprintf("/content/%v?expand=%s", id, contentExpand), []byte(`{}`))
 	if err != nil {
 		return nil, fmt.Errorf("GetContentById calls to a.requestAPI and returns an error: %w", err)
 	}
@@ -29,6 +115,7 @@ func (a *API) GetContentById(id string) (*Content, error) {
 			msg = "User does not have correct permission to read this content"
 		case http.StatusNotFound:
 			msg = "User does not have permission to view the requested content"
+			return nil, fmt.Errorf("GetContentById gets error: %w, message: %s", ErrNotFound, msg)
 		default:
 			msg = fmt.Sprintf("Invalid Status Code: %v", resp.StatusCode)
 		}
@@ -45,13 +132,13 @@ func (a *API) GetContentById(id string) (*Content, error) {
 	return &content, nil
 }
 
-func (a *API) CreateContent(c *Content) error {
+func (a *API) CreateContent(ctx context.Context, c *Content) error {
 	body, err := json.Marshal(c)
 
 	if err != nil {
 		return err
 	}
-	resp, err := a.requestAPI("POST", "/content", body)
+	resp, err := a.requestAPI(ctx, "POST", "/content", body)
 	if err != nil {
 		return err
 	}
@@ -84,8 +171,8 @@ func (a *API) CreateContent(c *Content) error {
 	return nil
 }
 
-func (a *API) UpdateContent(c *Content) error {
-	content, err := a.GetContentById(c.Id)
+func (a *API) UpdateContent(ctx context.Context, c *Content) error {
+	content, err := a.GetContentById(ctx, c.Id)
 	if err != nil {
 		return fmt.Errorf("UpdateContent calls a.GetContentById and returns an error: %w", err)
 	}
@@ -96,7 +183,7 @@ func (a *API) UpdateContent(c *Content) error {
 	if err != nil {
 		return fmt.Errorf("UpdateContent calls json.Marshal and returns an error: %w", err)
 	}
-	resp, err := a.requestAPI("PUT", fmt.Sprintf("/content/%s", c.Id), body)
+	resp, err := a.requestAPI(ctx, "PUT", fmt.Sprintf("/content/%s", c.Id), body)
 	if err != nil {
 		return fmt.Errorf("UpdateContent calls a.requestAPI and returns an error: %w", err)
 	}
@@ -131,12 +218,12 @@ func (a *API) UpdateContent(c *Content) error {
 	return nil
 }
 
-func (a *API) DeleteContent(id string) error {
-	_, err := a.GetContentById(id)
+func (a *API) DeleteContent(ctx context.Context, id string) error {
+	_, err := a.GetContentById(ctx, id)
 	if err != nil {
 		return fmt.Errorf("DeleteContent calls a.GetContentById and returns an error: %w", err)
 	}
-	resp, err := a.requestAPI("DELETE", fmt.Sprintf("content/%s", id), []byte(``))
+	resp, err := a.requestAPI(ctx, "DELETE", fmt.Sprintf("content/%s", id), []byte(``))
 	if err != nil {
 		return fmt.Errorf("DeleteContent calls a.requestAPI and returns an error: %w", err)
 	}
@@ -163,3 +250,135 @@ func (a *API) DeleteContent(id string) error {
 	}
 	return nil
 }
+
+// PurgeContent removes a trashed content permanently, the content must be
+// in the trash before it can be purged.
+func (a *API) PurgeContent(ctx context.Context, id string) error {
+	resp, err := a.requestAPI(ctx, http.MethodDelete, fmt.Sprintf("/content/%s?status=trashed", id), []byte(``))
+	if err != nil {
+		return fmt.Errorf("PurgeContent calls a.requestAPI and returns an error: %w", err)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("PurgeContent calls io.ReadAll and returns an error: %w", err)
+	}
+	if resp.StatusCode != http.StatusNoContent {
+		var msg string
+		switch resp.StatusCode {
+		case http.StatusBadRequest:
+			msg = "The content id is invalid or the content is not in the trash"
+		case http.StatusUnauthorized:
+			msg = "Authentication credentials are incorrect or missing from the request"
+		case http.StatusForbidden:
+			msg = "The calling user can not purge the content with specified id"
+		case http.StatusNotFound:
+			msg = "Not Found could be either there is no trashed content with the given ID or the requesting user does not have permission to purge the content"
+		default:
+			msg = fmt.Sprintf("PurgeContent invalid status code: %v", resp.StatusCode)
+		}
+		return fmt.Errorf("PurgeContent gets error:, %v, message: %s", msg, string(b))
+	}
+	return nil
+}
+
+// ArchiveContent archives a page, confluence archives pages in a long task
+// so it waits until the task finishes or ctx is done.
+func (a *API) ArchiveContent(ctx context.Context, id string) error {
+	body, err := json.Marshal(ArchiveRequest{
+		Pages: []Content{{Id: id}},
+	})
+	if err != nil {
+		return fmt.Errorf("ArchiveContent calls json.Marshal and returns an error: %w", err)
+	}
+	resp, err := a.requestAPI(ctx, http.MethodPost, "/content/archive", body)
+	if err != nil {
+		return fmt.Errorf("ArchiveContent calls a.requestAPI and returns an error: %w", err)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("ArchiveContent calls io.ReadAll and returns an error: %w", err)
+	}
+	if resp.StatusCode != http.StatusAccepted {
+		var msg string
+		switch resp.StatusCode {
+		case http.StatusBadRequest:
+			msg = "Bad request could be either the page is not current or it is already being archived"
+		case http.StatusUnauthorized:
+			msg = "Authentication credentials are incorrect or missing from the request"
+		case http.StatusForbidden:
+			msg = "The calling user can not archive the content with specified id"
+		default:
+			msg = fmt.Sprintf("ArchiveContent invalid status code: %v", resp.StatusCode)
+		}
+		return fmt.Errorf("ArchiveContent gets error:, %v, message: %s", msg, string(b))
+	}
+	_, err = a.waitForLongTaskResponse(ctx, b)
+	if err != nil {
+		return fmt.Errorf("ArchiveContent calls a.waitForLongTaskResponse and returns an error: %w", err)
+	}
+	return nil
+}
+
+// RestoreContent moves a trashed content back to current, the content is
+// updated with the values of c at the same time.
+func (a *API) RestoreContent(ctx context.Context, c *Content) error {
+	resp, err := a.requestAPI(ctx, http.MethodGet, fmt.Sprintf("/content/%s?status=trashed", c.Id), []byte(`{}`))
+	if err != nil {
+		return fmt.Errorf("RestoreContent calls a.requestAPI and returns an error: %w", err)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("RestoreContent calls io.ReadAll and returns an error: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("RestoreContent gets error: trashed content %s not found, status code: %v, message: %s", c.Id, resp.StatusCode, string(b))
+	}
+	var trashed Content
+	err = json.Unmarshal(b, &trashed)
+	if err != nil {
+		return fmt.Errorf("RestoreContent calls json.Unmarshal and returns an error: %w", err)
+	}
+	if trashed.Version == nil {
+		return fmt.Errorf("RestoreContent gets a trashed content without version: %s", string(b))
+	}
+
+	c.Status = "current"
+	c.Version = &Version{
+		Number: trashed.Version.Number + 1,
+	}
+	body, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("RestoreContent calls json.Marshal and returns an error: %w", err)
+	}
+	resp, err = a.requestAPI(ctx, http.MethodPut, fmt.Sprintf("/content/%s", c.Id), body)
+	if err != nil {
+		return fmt.Errorf("RestoreContent calls a.requestAPI and returns an error: %w", err)
+	}
+	defer resp.Body.Close()
+	b, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("RestoreContent calls io.ReadAll and returns an error: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var msg string
+		switch resp.StatusCode {
+		case http.StatusBadRequest:
+			msg = "Bad request could be either request body is missing required parameters (version, type, title) or type property has been set incorrectly"
+		case http.StatusUnauthorized:
+			msg = "Authentication credentials are incorrect or missing from the request"
+		case http.StatusForbidden:
+			msg = "The calling user can not restore the content with specified id"
+		case http.StatusConflict:
+			msg = "Conflict, the version property has not been set correctly for the content"
+		default:
+			msg = fmt.Sprintf("RestoreContent invalid status code: %v", resp.StatusCode)
+		}
+		return fmt.Errorf("RestoreContent gets error:, %v, message: %s", msg, string(b))
+	}
+	json.Unmarshal(b, c)
+
+	return nil
+}
@@ -1,13 +1,234 @@
 package confluence
 
-//func TestGetContentById(t *testing.T) {
-//	clientAPI, err := NewAPI("renemontilva@gmail.com", "NtZzjgQCOAQGBRtmuU5gF6F8", "renemontilva.atlassian.net")
-//	if err != nil {
-//		t.Errorf("NewAPI error: %v", err)
-//	}
-//	_, err = clientAPI.GetContentById("1146920")
-//	if err != nil {
-//		t.Errorf("GetContentById error: %v", err)
-//	}
-//}
-//
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluencefake"
+)
+
+func TestContentLifecycle(t *testing.T) {
+	api, _ := fakeAPI(t)
+	ctx := context.Background()
+
+	content := Content{
+		Type:  "page",
+		Title: "Terraform Test",
+		Space: &Space{Key: "DEVOPS"},
+		Body:  Body{Storage: Storage{Value: "<p>create</p>", Representation: "storage"}},
+	}
+	err := api.CreateContent(ctx, &content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content.Id == "" {
+		t.Fatal("CreateContent does not set the content id")
+	}
+
+	content.Body.Storage.Value = "<p>update</p>"
+	err = api.UpdateContent(ctx, &content)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := api.GetContentById(ctx, content.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Body.Storage.Value != "<p>update</p>" {
+		t.Errorf("wants body <p>update</p>, but got %v", got.Body.Storage.Value)
+	}
+	if got.Version.Number != 2 {
+		t.Errorf("wants version 2, but got %v", got.Version.Number)
+	}
+
+	err = api.DeleteContent(ctx, content.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = api.GetContentById(ctx, content.Id)
+	if err == nil {
+		t.Error("wants an error reading a trashed content, but got nil")
+	}
+	err = api.PurgeContent(ctx, content.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUpdateContentConflict(t *testing.T) {
+	api, server := fakeAPI(t)
+	id := server.AddContent("DEVOPS", "page", "Terraform Test", "<p>body</p>", "")
+	server.AddFault(confluencefake.Fault{Method: http.MethodPut, Path: "/content/" + id, Status: http.StatusConflict, Times: 1})
+
+	err := api.UpdateContent(context.Background(), &Content{Id: id, Type: "page", Title: "Terraform Test"})
+	if err == nil {
+		t.Error("wants a conflict error, but got nil")
+	}
+}
+
+func TestGetContents(t *testing.T) {
+	api, server := fakeAPI(t)
+	ctx := context.Background()
+	id := server.AddContent("DEVOPS", "page", "Terraform Test", "<p>body</p>", "")
+	err := api.DeleteContent(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	contents, err := api.GetContents(ctx, ContentQuery{
+		SpaceKey: "DEVOPS",
+		Title:    "Terraform Test",
+		Status:   "trashed",
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	if len(contents) != 1 || contents[0].Id != id {
+		t.Errorf("wants a content with id %s, but got %v", id, contents)
+	}
+	requests := server.Requests()
+	u, err := url.Parse(strings.TrimPrefix(requests[len(requests)-1], "GET "))
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := u.Query()
+	if query.Get("spaceKey") != "DEVOPS" || query.Get("title") != "Terraform Test" || query.Get("status") != "trashed" {
+		t.Errorf("wants spaceKey, title and status query parameters, but got %v", query)
+	}
+}
+
+func TestDeleteContentModes(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		delete  func(a *API, id string) error
+		request string
+		status  string
+	}{
+		{
+			desc: "Purge trashed content",
+			delete: func(a *API, id string) error {
+				if err := a.DeleteContent(context.Background(), id); err != nil {
+					return err
+				}
+				return a.PurgeContent(context.Background(), id)
+			},
+			request: "DELETE /content/%s?status=trashed",
+		},
+		{
+			desc:    "Archive content",
+			delete:  func(a *API, id string) error { return a.ArchiveContent(context.Background(), id) },
+			request: "POST /content/archive",
+			status:  "archived",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			api, server := fakeAPI(t)
+			id := server.AddContent("DEVOPS", "page", "Terraform Test", "<p>body</p>", "")
+
+			err := tC.delete(api, id)
+			if err != nil {
+				t.Error(err)
+			}
+			request := strings.ReplaceAll(tC.request, "%s", id)
+			if !containsRequest(server.Requests(), request) {
+				t.Errorf("wants request %q, but got %v", request, server.Requests())
+			}
+			contents, err := api.GetContents(context.Background(), ContentQuery{SpaceKey: "DEVOPS", Title: "Terraform Test", Status: "any"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tC.status == "" && len(contents) != 0 {
+				t.Errorf("wants the content purged, but got %v", contents)
+			}
+			if tC.status != "" && (len(contents) != 1 || contents[0].Status != tC.status) {
+				t.Errorf("wants the content %s, but got %v", tC.status, contents)
+			}
+		})
+	}
+}
+
+func TestRestoreContent(t *testing.T) {
+	api, server := fakeAPI(t)
+	ctx := context.Background()
+	id := server.AddContent("DEVOPS", "page", "Terraform Test", "<p>body</p>", "")
+	err := api.DeleteContent(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := Content{Id: id, Type: "page", Title: "Terraform Test", Body: Body{Storage: Storage{Value: "<p>body</p>", Representation: "storage"}}}
+	err = api.RestoreContent(ctx, &content)
+	if err != nil {
+		t.Error(err)
+	}
+	restored, err := api.GetContentById(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.Status != "current" {
+		t.Errorf("wants status current, but got %v", restored.Status)
+	}
+	if restored.Version == nil || restored.Version.Number != 2 {
+		t.Errorf("wants version 2, but got %v", restored.Version)
+	}
+}
+
+func containsRequest(requests []string, request string) bool {
+	for _, r := range requests {
+		if r == request {
+			return true
+		}
+	}
+	return false
+}
+
+func TestContentExtensionsPosition(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		json         string
+		want         string
+		wantLocation string
+	}{
+		{
+			desc: "position",
+			json: `{"extensions":{"position":2}}`,
+			want: "2",
+		},
+		{
+			desc: "never ordered",
+			json: `{"extensions":{"position":"none"}}`,
+			want: "<nil>",
+		},
+		{
+			desc:         "comment location",
+			json:         `{"extensions":{"location":"footer"}}`,
+			want:         "<nil>",
+			wantLocation: "footer",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			var content Content
+			if err := json.Unmarshal([]byte(tC.json), &content); err != nil {
+				t.Fatal(err)
+			}
+			got := "<nil>"
+			if content.Extensions.Position != nil {
+				got = strconv.Itoa(*content.Extensions.Position)
+			}
+			if got != tC.want {
+				t.Errorf("wants position %s, but got %s", tC.want, got)
+			}
+			if content.Extensions.Location != tC.wantLocation {
+				t.Errorf("wants location %q, but got %q", tC.wantLocation, content.Extensions.Location)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package confluence
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Space export formats, the export types of the exportSpace method of the
+// remote API.
+const (
+	SpaceExportXML  = "TYPE_XML"
+	SpaceExportHTML = "TYPE_HTML"
+	SpaceExportPDF  = "TYPE_PDF"
+)
+
+// spaceExportPath is the JSON-RPC exportSpace method of the remote API of
+// confluence Data Center, relative to the context path. The REST API has no
+// space export endpoint, neither on cloud nor on Data Center. The remote API
+// is disabled by default on recent Data Center versions and was removed in
+// Confluence 9.
+const spaceExportPath = "/rpc/json-rpc/confluenceservice-v2/exportSpace"
+
+// ErrSpaceExportUnsupported is returned by ExportSpace on confluence cloud
+// and on Data Center sites without the JSON-RPC remote API, they have no API
+// to export a space.
+var ErrSpaceExportUnsupported = errors.New("the site has no API to export a space, spaces are exported from the space settings")
+
+// SpaceExport is a finished space export, the archive is downloaded from
+// DownloadLink with DownloadSpaceExport.
+type SpaceExport struct {
+	DownloadLink string
+}
+
+// ExportSpace exports a space to an archive of the export type, XML, HTML
+// or PDF, with the exportSpace method of the JSON-RPC remote API of
+// confluence Data Center. The method is synchronous, confluence builds the
+// archive before it answers with its download link, there is no long task to
+// poll. It returns ErrSpaceExportUnsupported on confluence cloud and when
+// the remote API is not available, e.g: on Confluence 9.
+func (a *API) ExportSpace(ctx context.Context, key, exportType string) (*SpaceExport, error) {
+	if a.IsCloud() {
+		return nil, fmt.Errorf("ExportSpace gets error: %w", ErrSpaceExportUnsupported)
+	}
+	body, err := json.Marshal([]string{key, exportType})
+	if err != nil {
+		return nil, fmt.Errorf("ExportSpace calls json.Marshal and returns an error: %w", err)
+	}
+	reqURL, err := a.siteURL(spaceExportPath)
+	if err != nil {
+		return nil, fmt.Errorf("ExportSpace calls a.siteURL and returns an error: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("ExportSpace calls http.NewRequestWithContext and returns an error: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	a.Auth(req)
+	resp, err := a.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ExportSpace calls a.Client.Do and returns an error: %w", err)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("ExportSpace calls io.ReadAll and returns an error: %w", err)
+	}
+	// The method answers the URL of the archive, or a JSON-RPC error.
+	var link string
+	if resp.StatusCode == http.StatusOK && json.Unmarshal(b, &link) == nil && link != "" {
+		return &SpaceExport{DownloadLink: link}, nil
+	}
+	var msg string
+	switch resp.StatusCode {
+	case http.StatusOK:
+		msg = "The remote API did not answer a download link"
+	case http.StatusUnauthorized:
+		msg = "Authentication credentials are incorrect or missing from the request"
+	case http.StatusForbidden:
+		msg = "The calling user does not have permission to export the space, or the remote API is disabled"
+	case http.StatusNotFound:
+		msg = "Not found, the JSON-RPC remote API is disabled or was removed from the site"
+		return nil, fmt.Errorf("ExportSpace gets error: %w, message: %s", ErrSpaceExportUnsupported, msg)
+	default:
+		msg = fmt.Sprintf("Invalid Status Code: %v", resp.StatusCode)
+	}
+	return nil, fmt.Errorf("ExportSpace gets error: %v, message: %s", msg, string(b))
+}
+
+// DownloadSpaceExport writes the archive of a space export to w and returns
+// the number of bytes written.
+func (a *API) DownloadSpaceExport(ctx context.Context, link string, w io.Writer) (int64, error) {
+	reqURL, err := a.siteURL(link)
+	if err != nil {
+		return 0, fmt.Errorf("DownloadSpaceExport calls a.siteURL and returns an error: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
+	if err != nil {
+		return 0, fmt.Errorf("DownloadSpaceExport calls http.NewRequestWithContext and returns an error: %w", err)
+	}
+	a.Auth(req)
+	resp, err := a.Client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("DownloadSpaceExport calls a.Client.Do and returns an error: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		var msg string
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			msg = "Authentication credentials are incorrect or missing from the request"
+		case http.StatusNotFound:
+			msg = "Not found, the export archive expired or was never created"
+		default:
+			msg = fmt.Sprintf("Invalid Status Code: %v", resp.StatusCode)
+		}
+		return 0, fmt.Errorf("DownloadSpaceExport gets error: %v, message: %s", msg, string(b))
+	}
+	n, err := io.Copy(w, resp.Body)
+	if err != nil {
+		return n, fmt.Errorf("DownloadSpaceExport calls io.Copy and returns an error: %w", err)
+	}
+	return n, nil
+}
+
+// siteURL resolves a link of the site, e.g: /wiki/download/temp/export.zip
+// or /download/temp/export.zip, against the context path of the API
+// endpoint. Only the path and query of the link are kept so credentials are
+// never sent to another host.
+func (a *API) siteURL(link string) (*url.URL, error) {
+	ref, err := url.Parse(link)
+	if err != nil {
+		return nil, err
+	}
+	base := strings.TrimSuffix(a.Endpoint.Path, "/rest/api")
+	u := *a.Endpoint
+	u.Path = ref.Path
+	if !strings.HasPrefix(ref.Path, base+"/") {
+		u.Path = base + ref.Path
+	}
+	u.RawPath = ""
+	u.RawQuery = ref.RawQuery
+	return &u, nil
+}
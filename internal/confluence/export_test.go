@@ -0,0 +1,80 @@
+package confluence
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluencefake"
+)
+
+func TestExportSpaceDataCenter(t *testing.T) {
+	api, server := fakeAPI(t)
+	server.AddContent("DEVOPS", "page", "Runbooks", "<p>body</p>", "")
+
+	ctx := context.Background()
+	export, err := api.ExportSpace(ctx, "DEVOPS", SpaceExportXML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !containsRequest(server.Requests(), "POST /wiki/rpc/json-rpc/confluenceservice-v2/exportSpace") {
+		t.Errorf("wants the exportSpace remote API method called, but got %v", server.Requests())
+	}
+	var archive bytes.Buffer
+	n, err := api.DownloadSpaceExport(ctx, export.DownloadLink, &archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n == 0 || int64(archive.Len()) != n {
+		t.Errorf("wants the archive of %s, but got %d bytes", export.DownloadLink, n)
+	}
+
+	if _, err := api.ExportSpace(ctx, "MISSING", SpaceExportXML); err == nil {
+		t.Error("wants an error exporting a missing space")
+	}
+}
+
+func TestExportSpaceRemoteAPIRemoved(t *testing.T) {
+	api, server := fakeAPI(t)
+	server.AddFault(confluencefake.Fault{Method: http.MethodPost, Path: confluencefake.ExportSpacePath, Status: http.StatusNotFound, Body: "<html>Page Not Found</html>", Times: 1})
+
+	_, err := api.ExportSpace(context.Background(), "DEVOPS", SpaceExportXML)
+	if !errors.Is(err, ErrSpaceExportUnsupported) {
+		t.Errorf("wants ErrSpaceExportUnsupported, but got %v", err)
+	}
+}
+
+func TestExportSpaceCloud(t *testing.T) {
+	api, err := NewAPI("user@email.com", "123456", "https://example.atlassian.net")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = api.ExportSpace(context.Background(), "DEVOPS", SpaceExportXML)
+	if !errors.Is(err, ErrSpaceExportUnsupported) {
+		t.Errorf("wants ErrSpaceExportUnsupported, but got %v", err)
+	}
+}
+
+func TestSiteURL(t *testing.T) {
+	api := &API{Endpoint: &url.URL{Scheme: "https", Host: "example.atlassian.net", Path: "/wiki/rest/api"}}
+	testCases := []struct {
+		link string
+		want string
+	}{
+		{link: "/wiki/download/temp/export.zip", want: "https://example.atlassian.net/wiki/download/temp/export.zip"},
+		{link: "/download/temp/export.zip?contentType=zip", want: "https://example.atlassian.net/wiki/download/temp/export.zip?contentType=zip"},
+		{link: "https://attacker.example/wiki/download/temp/export.zip", want: "https://example.atlassian.net/wiki/download/temp/export.zip"},
+	}
+	for _, tC := range testCases {
+		got, err := api.siteURL(tC.link)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != tC.want {
+			t.Errorf("siteURL(%s), wants %s, but got %s", tC.link, tC.want, got)
+		}
+	}
+}
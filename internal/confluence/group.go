@@ -0,0 +1,114 @@
+package confluence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// The group administration endpoints only exist on confluence Data Center,
+// cloud groups are managed in the Atlassian administration.
+
+// CreateGroup creates a local group on confluence Data Center.
+func (a *API) CreateGroup(ctx context.Context, name string) error {
+	body, err := json.Marshal(Group{Type: "group", Name: name})
+	if err != nil {
+		return fmt.Errorf("CreateGroup calls json.Marshal and returns an error: %w", err)
+	}
+	resp, err := a.requestAPI(ctx, http.MethodPost, "/admin/group", body)
+	if err != nil {
+		return fmt.Errorf("CreateGroup calls a.requestAPI and returns an error: %w", err)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("CreateGroup calls io.ReadAll and returns an error: %w", err)
+	}
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		var msg string
+		switch resp.StatusCode {
+		case http.StatusBadRequest:
+			msg = "Bad request, the group name is invalid or the group already exists"
+		case http.StatusUnauthorized:
+			msg = "Authentication credentials are incorrect or missing from the request"
+		case http.StatusForbidden:
+			msg = "The calling user is not a confluence administrator"
+		case http.StatusNotFound:
+			msg = "Not Found, the group administration is only available on confluence Data Center"
+		default:
+			msg = fmt.Sprintf("Invalid Status Code: %v", resp.StatusCode)
+		}
+		return fmt.Errorf("CreateGroup gets error: %v, message: %s", msg, string(b))
+	}
+	return nil
+}
+
+// DeleteGroup deletes a local group on confluence Data Center.
+func (a *API) DeleteGroup(ctx context.Context, name string) error {
+	resp, err := a.requestAPI(ctx, http.MethodDelete, fmt.Sprintf("/admin/group/%s", url.PathEscape(name)), nil)
+	if err != nil {
+		return fmt.Errorf("DeleteGroup calls a.requestAPI and returns an error: %w", err)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("DeleteGroup calls io.ReadAll and returns an error: %w", err)
+	}
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		var msg string
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			msg = "Authentication credentials are incorrect or missing from the request"
+		case http.StatusForbidden:
+			msg = "The calling user is not a confluence administrator"
+		case http.StatusNotFound:
+			msg = "Not Found, there is no group with the given name"
+		default:
+			msg = fmt.Sprintf("Invalid Status Code: %v", resp.StatusCode)
+		}
+		return fmt.Errorf("DeleteGroup gets error: %v, message: %s", msg, string(b))
+	}
+	return nil
+}
+
+// AddGroupMember adds the user with the username to a group on confluence
+// Data Center.
+func (a *API) AddGroupMember(ctx context.Context, name, username string) error {
+	return a.groupMember(ctx, "AddGroupMember", http.MethodPut, name, username)
+}
+
+// RemoveGroupMember removes the user with the username from a group on
+// confluence Data Center.
+func (a *API) RemoveGroupMember(ctx context.Context, name, username string) error {
+	return a.groupMember(ctx, "RemoveGroupMember", http.MethodDelete, name, username)
+}
+
+func (a *API) groupMember(ctx context.Context, caller, method, name, username string) error {
+	resp, err := a.requestAPI(ctx, method, fmt.Sprintf("/user/%s/group/%s", url.PathEscape(username), url.PathEscape(name)), nil)
+	if err != nil {
+		return fmt.Errorf("%s calls a.requestAPI and returns an error: %w", caller, err)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("%s calls io.ReadAll and returns an error: %w", caller, err)
+	}
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		var msg string
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			msg = "Authentication credentials are incorrect or missing from the request"
+		case http.StatusForbidden:
+			msg = "The calling user is not a confluence administrator"
+		case http.StatusNotFound:
+			msg = "Not Found, there is no user or group with the given name"
+		default:
+			msg = fmt.Sprintf("Invalid Status Code: %v", resp.StatusCode)
+		}
+		return fmt.Errorf("%s gets error: %v, message: %s", caller, msg, string(b))
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package confluence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Move positions of MoveContent, append places the page under the target
+// as its last child, before and after place it next to the target.
+const (
+	MovePositionAppend = "append"
+	MovePositionBefore = "before"
+	MovePositionAfter  = "after"
+)
+
+// CopyOptions are the parts of a page copied with it, the page body and
+// title are always copied.
+type CopyOptions struct {
+	CopyAttachments    bool `json:"copyAttachments"`
+	CopyPermissions    bool `json:"copyPermissions"`
+	CopyProperties     bool `json:"copyProperties"`
+	CopyLabels         bool `json:"copyLabels"`
+	CopyCustomContents bool `json:"copyCustomContents"`
+}
+
+// CopyRequest copies a single page.
+type CopyRequest struct {
+	CopyOptions
+	Destination CopyDestination `json:"destination"`
+	// PageTitle is the title of the copy, the title of the page when empty.
+	PageTitle string `json:"pageTitle,omitempty"`
+}
+
+// CopyDestination is where a page is copied to, e.g: parent_page with the id
+// of the parent of the copy.
+type CopyDestination struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// CopyPageHierarchyRequest copies a page with all its descendants under the
+// destination page.
+type CopyPageHierarchyRequest struct {
+	CopyOptions
+	DestinationPageId string            `json:"destinationPageId"`
+	TitleOptions      *CopyTitleOptions `json:"titleOptions,omitempty"`
+}
+
+// CopyTitleOptions change the titles of the copied pages, they must be unique
+// in the destination space.
+type CopyTitleOptions struct {
+	Prefix  string `json:"prefix,omitempty"`
+	Replace string `json:"replace,omitempty"`
+	Search  string `json:"search,omitempty"`
+}
+
+// MoveContent moves a page to a position relative to the target page, the
+// target may be in another space. The page keeps its descendants.
+func (a *API) MoveContent(ctx context.Context, id, position, targetId string) error {
+	resp, err := a.requestAPI(ctx, http.MethodPut, fmt.Sprintf("/content/%s/move/%s/%s", url.PathEscape(id), position, url.PathEscape(targetId)), []byte(`{}`))
+	if err != nil {
+		return fmt.Errorf("MoveContent calls a.requestAPI and returns an error: %w", err)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("MoveContent calls io.ReadAll and returns an error: %w", err)
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusAccepted:
+		// Moves between spaces may run as a long task.
+		_, err = a.waitForLongTaskResponse(ctx, b)
+		if err != nil {
+			return fmt.Errorf("MoveContent calls a.waitForLongTaskResponse and returns an error: %w", err)
+		}
+		return nil
+	}
+	var msg string
+	switch resp.StatusCode {
+	case http.StatusBadRequest:
+		msg = "Bad request, could be either an invalid position or the target is a descendant of the page"
+	case http.StatusUnauthorized:
+		msg = "Authentication credentials are incorrect or missing from the request"
+	case http.StatusForbidden:
+		msg = "The calling user can not move the page or add pages to the target"
+	case http.StatusNotFound:
+		msg = "Not found, could be either the page or the target does not exist"
+	default:
+		msg = fmt.Sprintf("Invalid Status Code: %v", resp.StatusCode)
+	}
+	return fmt.Errorf("MoveContent gets error: %v, message: %s", msg, string(b))
+}
+
+// CopyContent copies a single page and returns the copy.
+func (a *API) CopyContent(ctx context.Context, id string, req CopyRequest) (*Content, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("CopyContent calls json.Marshal and returns an error: %w", err)
+	}
+	resp, err := a.requestAPI(ctx, http.MethodPost, fmt.Sprintf("/content/%s/copy?expand=%s", url.PathEscape(id), contentExpand), body)
+	if err != nil {
+		return nil, fmt.Errorf("CopyContent calls a.requestAPI and returns an error: %w", err)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("CopyContent calls io.ReadAll and returns an error: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var msg string
+		switch resp.StatusCode {
+		case http.StatusBadRequest:
+			msg = "Bad request, could be either an invalid destination or a page with the same title exists in the destination space"
+		case http.StatusUnauthorized:
+			msg = "Authentication credentials are incorrect or missing from the request"
+		case http.StatusForbidden:
+			msg = "The calling user can not copy the page or add pages to the destination"
+		case http.StatusNotFound:
+			msg = "Not found, could be either the page or the destination does not exist"
+		default:
+			msg = fmt.Sprintf("Invalid Status Code: %v", resp.StatusCode)
+		}
+		return nil, fmt.Errorf("CopyContent gets error: %v, message: %s", msg, string(b))
+	}
+	var content Content
+	err = json.Unmarshal(b, &content)
+	if err != nil {
+		return nil, fmt.Errorf("CopyContent calls json.Unmarshal and returns an error: %w", err)
+	}
+	return &content, nil
+}
+
+// CopyPageHierarchy copies a page with its descendants and waits until the
+// long task copying them finishes.
+func (a *API) CopyPageHierarchy(ctx context.Context, id string, req CopyPageHierarchyRequest) (*LongTask, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("CopyPageHierarchy calls json.Marshal and returns an error: %w", err)
+	}
+	resp, err := a.requestAPI(ctx, http.MethodPost, fmt.Sprintf("/content/%s/pagehierarchy/copy", url.PathEscape(id)), body)
+	if err != nil {
+		return nil, fmt.Errorf("CopyPageHierarchy calls a.requestAPI and returns an error: %w", err)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("CopyPageHierarchy calls io.ReadAll and returns an error: %w", err)
+	}
+	if resp.StatusCode != http.StatusAccepted {
+		var msg string
+		switch resp.StatusCode {
+		case http.StatusBadRequest:
+			msg = "Bad request, could be either an invalid destination or the copied titles exist in the destination space"
+		case http.StatusUnauthorized:
+			msg = "Authentication credentials are incorrect or missing from the request"
+		case http.StatusForbidden:
+			msg = "The calling user can not copy the pages or add pages to the destination"
+		case http.StatusNotFound:
+			msg = "Not found, could be either the page or the destination does not exist"
+		default:
+			msg = fmt.Sprintf("Invalid Status Code: %v", resp.StatusCode)
+		}
+		return nil, fmt.Errorf("CopyPageHierarchy gets error: %v, message: %s", msg, string(b))
+	}
+	task, err := a.waitForLongTaskResponse(ctx, b)
+	if err != nil {
+		return nil, fmt.Errorf("CopyPageHierarchy calls a.waitForLongTaskResponse and returns an error: %w", err)
+	}
+	return task, nil
+}
+
+// GetChildPages returns the current child pages of a page.
+func (a *API) GetChildPages(ctx context.Context, id string) ([]Content, error) {
+	children := []Content{}
+	start := 0
+	for {
+		params := url.Values{}
+		params.Set("start", strconv.Itoa(start))
+		params.Set("limit", strconv.Itoa(contentPageLimit))
+		resp, err := a.requestAPI(ctx, http.MethodGet, fmt.Sprintf("/content/%s/child/page?%s", url.PathEscape(id), params.Encode()), []byte(`{}`))
+		if err != nil {
+			return nil, fmt.Errorf("GetChildPages calls a.requestAPI and returns an error: %w", err)
+		}
+		b, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("GetChildPages calls io.ReadAll and returns an error: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			var msg string
+			switch resp.StatusCode {
+			case http.StatusUnauthorized:
+				msg = "Authentication credentials are incorrect or missing from the request"
+			case http.StatusNotFound:
+				msg = "Not found, could be either the page does not exist or the calling user can not view it"
+			default:
+				msg = fmt.Sprintf("Invalid Status Code: %v", resp.StatusCode)
+			}
+			return nil, fmt.Errorf("GetChildPages gets error: %v, message: %s", msg, string(b))
+		}
+		var page ContentArray
+		err = json.Unmarshal(b, &page)
+		if err != nil {
+			return nil, fmt.Errorf("GetChildPages calls json.Unmarshal and returns an error: %w", err)
+		}
+		children = append(children, page.Results...)
+		if len(page.Results) < contentPageLimit {
+			return children, nil
+		}
+		start += len(page.Results)
+	}
+}
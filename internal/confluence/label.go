@@ -0,0 +1,106 @@
+package confluence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+func (a *API) GetLabels(ctx context.Context, id string) ([]Label, error) {
+	resp, err := a.requestAPI(ctx, http.MethodGet, fmt.Sprintf("/content/%s/label?limit=200", id), []byte(`{}`))
+	if err != nil {
+		return nil, fmt.Errorf("GetLabels calls a.requestAPI and returns an error: %w", err)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("GetLabels calls io.ReadAll and returns an error: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var msg string
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			msg = "Authentication credentials are incorrect or missing from the request"
+		case http.StatusNotFound:
+			msg = "The calling user does not have permission to view the content"
+		default:
+			msg = fmt.Sprintf("Invalid Status Code: %v", resp.StatusCode)
+		}
+		return nil, fmt.Errorf("GetLabels gets error: %v, message: %s", msg, string(b))
+	}
+	var labels LabelArray
+	err = json.Unmarshal(b, &labels)
+	if err != nil {
+		return nil, fmt.Errorf("GetLabels calls json.Unmarshal and returns an error: %w", err)
+	}
+	return labels.Results, nil
+}
+
+// AddLabels adds labels to a content, labels without prefix are added as global labels.
+func (a *API) AddLabels(ctx context.Context, id string, labels []Label) error {
+	for i := range labels {
+		if labels[i].Prefix == "" {
+			labels[i].Prefix = "global"
+		}
+	}
+	body, err := json.Marshal(labels)
+	if err != nil {
+		return fmt.Errorf("AddLabels calls json.Marshal and returns an error: %w", err)
+	}
+	resp, err := a.requestAPI(ctx, http.MethodPost, fmt.Sprintf("/content/%s/label", id), body)
+	if err != nil {
+		return fmt.Errorf("AddLabels calls a.requestAPI and returns an error: %w", err)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("AddLabels calls io.ReadAll and returns an error: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var msg string
+		switch resp.StatusCode {
+		case http.StatusBadRequest:
+			msg = "Bad request, could be either the label name or prefix is invalid"
+		case http.StatusUnauthorized:
+			msg = "Authentication credentials are incorrect or missing from the request"
+		case http.StatusForbidden:
+			msg = "The calling user does not have permission to edit labels on the content"
+		case http.StatusNotFound:
+			msg = "The calling user does not have permission to view the content"
+		default:
+			msg = fmt.Sprintf("Invalid Status Code: %v", resp.StatusCode)
+		}
+		return fmt.Errorf("AddLabels gets error: %v, message: %s", msg, string(b))
+	}
+	return nil
+}
+
+func (a *API) DeleteLabel(ctx context.Context, id, name string) error {
+	resp, err := a.requestAPI(ctx, http.MethodDelete, fmt.Sprintf("/content/%s/label?name=%s", id, url.QueryEscape(name)), []byte(``))
+	if err != nil {
+		return fmt.Errorf("DeleteLabel calls a.requestAPI and returns an error: %w", err)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("DeleteLabel calls io.ReadAll and returns an error: %w", err)
+	}
+	if resp.StatusCode != http.StatusNoContent {
+		var msg string
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			msg = "Authentication credentials are incorrect or missing from the request"
+		case http.StatusForbidden:
+			msg = "The calling user does not have permission to edit labels on the content"
+		case http.StatusNotFound:
+			msg = "Not found, could be either the content or the label does not exist"
+		default:
+			msg = fmt.Sprintf("Invalid Status Code: %v", resp.StatusCode)
+		}
+		return fmt.Errorf("DeleteLabel gets error: %v, message: %s", msg, string(b))
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package confluence
+
+import (
+	"context"
+	"testing"
+)
+
+func TestLabels(t *testing.T) {
+	api, server := fakeAPI(t)
+	ctx := context.Background()
+	id := server.AddContent("DEVOPS", "page", "Terraform Test", "<p>body</p>", "")
+
+	err := api.AddLabels(ctx, id, []Label{{Name: "runbook"}, {Name: "terraform"}})
+	if err != nil {
+		t.Error(err)
+	}
+	labels, err := api.GetLabels(ctx, id)
+	if err != nil {
+		t.Error(err)
+	}
+	if len(labels) != 2 || labels[0].Name != "runbook" || labels[0].Prefix != "global" {
+		t.Errorf("wants global labels runbook and terraform, but got %v", labels)
+	}
+
+	err = api.DeleteLabel(ctx, id, "runbook")
+	if err != nil {
+		t.Error(err)
+	}
+	labels, err = api.GetLabels(ctx, id)
+	if err != nil {
+		t.Error(err)
+	}
+	if len(labels) != 1 || labels[0].Name != "terraform" {
+		t.Errorf("wants label runbook deleted, but got %v", labels)
+	}
+}
@@ -0,0 +1,162 @@
+package confluence
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// maxLoggedBody is the number of body bytes written to the trace logs.
+const maxLoggedBody = 4096
+
+// redactedValue replaces secrets in the logs.
+const redactedValue = "REDACTED"
+
+// rateLimitHeaders are logged with every response, confluence cloud sends
+// them when a request is close to or over the rate limit.
+var rateLimitHeaders = []string{
+	"Retry-After",
+	"X-RateLimit-Limit",
+	"X-RateLimit-Remaining",
+	"X-RateLimit-Reset",
+	"X-RateLimit-NearLimit",
+}
+
+// redactedHeaders values are never logged.
+var redactedHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// loggingTransport logs every request and response with tflog, the log sink
+// and level come from the request context, so TF_LOG_PROVIDER controls the
+// verbosity. Method, URL, status, latency and rate limit headers are logged
+// at debug level, headers and truncated JSON bodies at trace level. Other
+// bodies, e.g: attachments and space exports, are neither read nor logged.
+type loggingTransport struct {
+	next http.RoundTripper
+	// secrets are masked in every logged field, e.g: the api token.
+	secrets []string
+}
+
+func newLoggingTransport(next http.RoundTripper, secrets ...string) *loggingTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	nonEmpty := []string{}
+	for _, s := range secrets {
+		if s != "" {
+			nonEmpty = append(nonEmpty, s)
+		}
+	}
+	return &loggingTransport{next: next, secrets: nonEmpty}
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := tflog.MaskAllFieldValuesStrings(req.Context(), t.secrets...)
+	fields := map[string]any{
+		"http_method": req.Method,
+		"http_url":    req.URL.String(),
+	}
+	tflog.Trace(ctx, "Sending confluence API request", mergeFields(fields, map[string]any{
+		"http_request_headers": logHeaders(req.Header),
+		"http_request_body":    requestBody(req),
+	}))
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	fields["http_duration_ms"] = time.Since(start).Milliseconds()
+	if err != nil {
+		tflog.Debug(ctx, "Confluence API request failed", mergeFields(fields, map[string]any{"error": err.Error()}))
+		return nil, err
+	}
+
+	fields["http_status"] = resp.StatusCode
+	for _, h := range rateLimitHeaders {
+		if v := resp.Header.Get(h); v != "" {
+			fields["http_"+strings.ToLower(strings.ReplaceAll(h, "-", "_"))] = v
+		}
+	}
+	tflog.Debug(ctx, "Received confluence API response", fields)
+
+	trace := map[string]any{"http_response_headers": logHeaders(resp.Header)}
+	if jsonBody(resp.Header) {
+		var body []byte
+		body, resp.Body = peekBody(resp.Body)
+		trace["http_response_body"] = truncate(body)
+	}
+	tflog.Trace(ctx, "Received confluence API response body", mergeFields(fields, trace))
+	return resp, nil
+}
+
+// requestBody returns the truncated request body without consuming it, the
+// body is empty unless it is JSON.
+func requestBody(req *http.Request) string {
+	if req.GetBody == nil || !jsonBody(req.Header) {
+		return ""
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+	b, _ := io.ReadAll(io.LimitReader(body, maxLoggedBody+1))
+	return truncate(b)
+}
+
+// jsonBody reports whether the Content-Type of the headers is JSON, only JSON
+// bodies are logged.
+func jsonBody(header http.Header) bool {
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// peekBody reads the start of body and returns it with a body that still
+// yields every byte.
+func peekBody(body io.ReadCloser) ([]byte, io.ReadCloser) {
+	if body == nil || body == http.NoBody {
+		return nil, body
+	}
+	b, _ := io.ReadAll(io.LimitReader(body, maxLoggedBody+1))
+	return b, struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(b), body), body}
+}
+
+func truncate(b []byte) string {
+	if len(b) > maxLoggedBody {
+		return string(b[:maxLoggedBody]) + "...(truncated)"
+	}
+	return string(b)
+}
+
+// logHeaders flattens the headers, the redacted headers values are replaced.
+func logHeaders(header http.Header) map[string]string {
+	headers := map[string]string{}
+	for k, v := range header {
+		headers[k] = strings.Join(v, ", ")
+		for _, h := range redactedHeaders {
+			if strings.EqualFold(k, h) {
+				headers[k] = redactedValue
+			}
+		}
+	}
+	return headers
+}
+
+func mergeFields(fields, extra map[string]any) map[string]any {
+	merged := make(map[string]any, len(fields)+len(extra))
+	for k, v := range fields {
+		merged[k] = v
+	}
+	for k, v := range extra {
+		merged[k] = v
+	}
+	return merged
+}
@@ -0,0 +1,131 @@
+package confluence
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestLoggingTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-RateLimit-Remaining", "9")
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"message":"` + strings.Repeat("a", maxLoggedBody) + `"}`))
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	api, err := NewAPI("user@email.com", "secret-token", server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := api.requestAPI(ctx, http.MethodPost, "/content?token=secret-token", []byte(`{"title":"secret-token"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b) != maxLoggedBody+len(`{"message":""}`) {
+		t.Errorf("wants the whole response body after logging, but got %d bytes", len(b))
+	}
+
+	if strings.Contains(output.String(), "secret-token") {
+		t.Error("wants the token masked in the logs, but it was logged")
+	}
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var response map[string]any
+	for _, entry := range entries {
+		if entry["@message"] == "Received confluence API response" {
+			response = entry
+		}
+	}
+	if response == nil {
+		t.Fatalf("wants a response log entry, but got %v", entries)
+	}
+	testCases := []struct {
+		field string
+		want  any
+	}{
+		{field: "@level", want: "debug"},
+		{field: "http_method", want: http.MethodPost},
+		{field: "http_status", want: float64(http.StatusTooManyRequests)},
+		{field: "http_x_ratelimit_remaining", want: "9"},
+		{field: "http_retry_after", want: "30"},
+	}
+	for _, tC := range testCases {
+		if response[tC.field] != tC.want {
+			t.Errorf("%s, wants %v, but got %v", tC.field, tC.want, response[tC.field])
+		}
+	}
+	for _, entry := range entries {
+		headers, ok := entry["http_request_headers"].(map[string]any)
+		if ok && headers["Authorization"] != redactedValue {
+			t.Errorf("Authorization header, wants %s, but got %v", redactedValue, headers["Authorization"])
+		}
+		body, ok := entry["http_response_body"].(string)
+		if ok && !strings.HasSuffix(body, "...(truncated)") {
+			t.Errorf("wants the response body truncated, but got %d bytes", len(body))
+		}
+	}
+}
+
+// roundTripperFunc adapts a function to an http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestLoggingTransportBinaryBody(t *testing.T) {
+	export := bytes.NewReader(bytes.Repeat([]byte{0x50, 0x4b}, maxLoggedBody))
+	transport := newLoggingTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/zip"}},
+			Body:       io.NopCloser(export),
+		}, nil
+	}))
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, "https://example.atlassian.net/wiki/rest/api/content/1001/child/attachment", strings.NewReader("--boundary\r\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "multipart/form-data; boundary=boundary")
+	_, err = transport.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if export.Len() != 2*maxLoggedBody {
+		t.Errorf("wants the binary body unread, but %d bytes were read", 2*maxLoggedBody-export.Len())
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if body, ok := entry["http_request_body"]; ok && body != "" {
+			t.Errorf("wants the multipart request body not logged, but got %v", body)
+		}
+		if body, ok := entry["http_response_body"]; ok {
+			t.Errorf("wants the binary response body not logged, but got %v", body)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package confluence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// defaultPollInterval is used when the API struct was built without NewAPI.
+const defaultPollInterval = 2 * time.Second
+
+func (a *API) GetLongTask(ctx context.Context, id string) (*LongTask, error) {
+	var task LongTask
+	resp, err := a.requestAPI(ctx, http.MethodGet, fmt.Sprintf("/longtask/%s", id), []byte(`{}`))
+	if err != nil {
+		return nil, fmt.Errorf("GetLongTask calls a.requestAPI and returns an error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("GetLongTask calls io.ReadAll and returns an error: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var msg string
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			msg = "Authentication credentials are incorrect or missing from the request"
+		case http.StatusForbidden:
+			msg = "The calling user does not have permission to view the task"
+		case http.StatusNotFound:
+			msg = "Not found, could be either there is no task with the given id or the calling user does not have permission to view it"
+		default:
+			msg = fmt.Sprintf("Invalid Status Code: %v", resp.StatusCode)
+		}
+		return nil, fmt.Errorf("GetLongTask gets error: %v, message: %s", msg, string(b))
+	}
+
+	err = json.Unmarshal(b, &task)
+	if err != nil {
+		return nil, fmt.Errorf("GetLongTask calls json.Unmarshal and returns an error: %w", err)
+	}
+	return &task, nil
+}
+
+// WaitForLongTask polls the long task until it finishes or ctx is done, the
+// progress percentage and messages are written to the provider logs on every poll.
+func (a *API) WaitForLongTask(ctx context.Context, id string) (*LongTask, error) {
+	interval := a.pollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	for {
+		task, err := a.GetLongTask(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("WaitForLongTask calls a.GetLongTask and returns an error: %w", err)
+		}
+		tflog.Debug(ctx, "Confluence long task progress", map[string]any{
+			"long_task_id":        id,
+			"percentage_complete": task.PercentageComplete,
+			"messages":            longTaskTranslations(task.Messages),
+		})
+		if task.Finished {
+			if !task.Successful {
+				return task, fmt.Errorf("WaitForLongTask task %s finished unsuccessfully: %s", id, strings.Join(longTaskTranslations(task.Errors), "; "))
+			}
+			return task, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return task, fmt.Errorf("WaitForLongTask task %s is %d%% complete, stop waiting: %w", id, task.PercentageComplete, ctx.Err())
+		case <-time.After(interval):
+		}
+	}
+}
+
+// waitForLongTaskResponse reads a long task reference from an accepted
+// response body and waits until the referenced task finishes.
+func (a *API) waitForLongTaskResponse(ctx context.Context, body []byte) (*LongTask, error) {
+	var ref LongTaskRef
+	err := json.Unmarshal(body, &ref)
+	if err != nil {
+		return nil, fmt.Errorf("waitForLongTaskResponse calls json.Unmarshal and returns an error: %w", err)
+	}
+	id := ref.Id
+	if id == "" && ref.Links != nil {
+		id = ref.Links.Status[strings.LastIndex(ref.Links.Status, "/")+1:]
+	}
+	if id == "" {
+		return nil, fmt.Errorf("waitForLongTaskResponse gets a response without long task id: %s", string(body))
+	}
+	return a.WaitForLongTask(ctx, id)
+}
+
+func longTaskTranslations(messages []LongTaskMessage) []string {
+	translations := make([]string, 0, len(messages))
+	for _, m := range messages {
+		translations = append(translations, m.Translation)
+	}
+	return translations
+}
@@ -0,0 +1,87 @@
+package confluence
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluencefake"
+)
+
+func TestWaitForLongTask(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		responses []string
+		wantErr   bool
+	}{
+		{
+			desc: "Task finishes successfully",
+			responses: []string{
+				`{"id":"1","percentageComplete":50,"finished":false,"messages":[{"translation":"Deleting space"}]}`,
+				`{"id":"1","percentageComplete":100,"finished":true,"successful":true}`,
+			},
+		},
+		{
+			desc: "Task finishes unsuccessfully",
+			responses: []string{
+				`{"id":"1","percentageComplete":100,"finished":true,"successful":false,"errors":[{"translation":"Space is locked"}]}`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			api, server := fakeAPI(t)
+			for _, response := range tC.responses {
+				server.AddFault(confluencefake.Fault{Method: http.MethodGet, Path: "/longtask/1", Status: http.StatusOK, Body: response, Times: 1})
+			}
+
+			task, err := api.WaitForLongTask(context.Background(), "1")
+			if tC.wantErr {
+				if err == nil {
+					t.Error("wants an error, but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Error(err)
+			}
+			if calls := len(server.Requests()); calls != len(tC.responses) {
+				t.Errorf("wants %v long task requests, but got %v", len(tC.responses), calls)
+			}
+			if task.PercentageComplete != 100 {
+				t.Errorf("wants task.percentageComplete: %v, but got %v", 100, task.PercentageComplete)
+			}
+		})
+	}
+}
+
+func TestWaitForLongTaskContextDone(t *testing.T) {
+	api, server := fakeAPI(t)
+	server.AddFault(confluencefake.Fault{Method: http.MethodGet, Path: "/longtask/1", Status: http.StatusOK, Body: `{"id":"1","percentageComplete":10,"finished":false}`})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := api.WaitForLongTask(ctx, "1")
+	if err == nil {
+		t.Error("wants a context deadline error, but got nil")
+	}
+}
+
+func TestDeleteSpace(t *testing.T) {
+	api, server := fakeAPI(t)
+
+	err := api.DeleteSpace(context.Background(), "DEVOPS")
+	if err != nil {
+		t.Error(err)
+	}
+	requests := server.Requests()
+	if !strings.HasPrefix(requests[len(requests)-1], "GET /longtask/") {
+		t.Errorf("DeleteSpace returns before the long task finished, requests: %v", requests)
+	}
+	if _, err := api.GetSpace(context.Background(), "DEVOPS"); err == nil {
+		t.Error("wants the space deleted, but it is still served")
+	}
+}
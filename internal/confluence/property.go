@@ -0,0 +1,197 @@
+package confluence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// GetContentProperty returns the property of a content by key.
+func (a *API) GetContentProperty(ctx context.Context, contentId, key string) (*Property, error) {
+	return a.getProperty(ctx, "GetContentProperty", contentPropertiesPath(contentId), key)
+}
+
+// CreateContentProperty adds a property to a content, the key must not exist.
+func (a *API) CreateContentProperty(ctx context.Context, contentId string, p *Property) error {
+	return a.createProperty(ctx, "CreateContentProperty", contentPropertiesPath(contentId), p)
+}
+
+// UpdateContentProperty replaces the value of a content property, the
+// version is read and incremented before the update.
+func (a *API) UpdateContentProperty(ctx context.Context, contentId string, p *Property) error {
+	return a.updateProperty(ctx, "UpdateContentProperty", contentPropertiesPath(contentId), p)
+}
+
+func (a *API) DeleteContentProperty(ctx context.Context, contentId, key string) error {
+	return a.deleteProperty(ctx, "DeleteContentProperty", contentPropertiesPath(contentId), key)
+}
+
+func contentPropertiesPath(contentId string) string {
+	return fmt.Sprintf("/content/%s/property", url.PathEscape(contentId))
+}
+
+// GetSpaceProperty returns the property of a space by key.
+func (a *API) GetSpaceProperty(ctx context.Context, spaceKey, key string) (*Property, error) {
+	return a.getProperty(ctx, "GetSpaceProperty", spacePropertiesPath(spaceKey), key)
+}
+
+// CreateSpaceProperty adds a property to a space, the key must not exist.
+func (a *API) CreateSpaceProperty(ctx context.Context, spaceKey string, p *Property) error {
+	return a.createProperty(ctx, "CreateSpaceProperty", spacePropertiesPath(spaceKey), p)
+}
+
+// UpdateSpaceProperty replaces the value of a space property, the version
+// is read and incremented before the update.
+func (a *API) UpdateSpaceProperty(ctx context.Context, spaceKey string, p *Property) error {
+	return a.updateProperty(ctx, "UpdateSpaceProperty", spacePropertiesPath(spaceKey), p)
+}
+
+func (a *API) DeleteSpaceProperty(ctx context.Context, spaceKey, key string) error {
+	return a.deleteProperty(ctx, "DeleteSpaceProperty", spacePropertiesPath(spaceKey), key)
+}
+
+func spacePropertiesPath(spaceKey string) string {
+	return fmt.Sprintf("/space/%s/property", url.PathEscape(spaceKey))
+}
+
+// The property endpoints of contents and spaces are the same, the helpers
+// below take the properties path and the caller name for the error messages.
+
+func (a *API) getProperty(ctx context.Context, caller, path, key string) (*Property, error) {
+	resp, err := a.requestAPI(ctx, http.MethodGet, fmt.Sprintf("%s/%s?expand=version", path, url.PathEscape(key)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s calls a.requestAPI and returns an error: %w", caller, err)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%s calls io.ReadAll and returns an error: %w", caller, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var msg string
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			msg = "Authentication credentials are incorrect or missing from the request"
+		case http.StatusNotFound:
+			msg = "Not Found, could be either there is no property with the given key or the calling user does not have permission to view it"
+			return nil, fmt.Errorf("%s gets error: %w, message: %s", caller, ErrNotFound, msg)
+		default:
+			msg = fmt.Sprintf("Invalid Status Code: %v", resp.StatusCode)
+		}
+		return nil, fmt.Errorf("%s gets error: %v, message: %s", caller, msg, string(b))
+	}
+	var property Property
+	err = json.Unmarshal(b, &property)
+	if err != nil {
+		return nil, fmt.Errorf("%s calls json.Unmarshal and returns an error: %w", caller, err)
+	}
+	return &property, nil
+}
+
+func (a *API) createProperty(ctx context.Context, caller, path string, p *Property) error {
+	body, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("%s calls json.Marshal and returns an error: %w", caller, err)
+	}
+	resp, err := a.requestAPI(ctx, http.MethodPost, path, body)
+	if err != nil {
+		return fmt.Errorf("%s calls a.requestAPI and returns an error: %w", caller, err)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("%s calls io.ReadAll and returns an error: %w", caller, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var msg string
+		switch resp.StatusCode {
+		case http.StatusBadRequest:
+			msg = "Bad request, the property key or value is invalid"
+		case http.StatusUnauthorized:
+			msg = "Authentication credentials are incorrect or missing from the request"
+		case http.StatusForbidden:
+			msg = "The calling user does not have permission to edit the owner of the property"
+		case http.StatusNotFound:
+			msg = "The owner of the property does not exist or the calling user can not view it"
+		case http.StatusConflict:
+			msg = "A property with the given key already exists"
+		default:
+			msg = fmt.Sprintf("Invalid Status Code: %v", resp.StatusCode)
+		}
+		return fmt.Errorf("%s gets error: %v, message: %s", caller, msg, string(b))
+	}
+	return json.Unmarshal(b, p)
+}
+
+func (a *API) updateProperty(ctx context.Context, caller, path string, p *Property) error {
+	current, err := a.getProperty(ctx, caller, path, p.Key)
+	if err != nil {
+		return err
+	}
+	p.Version = &Version{Number: 1}
+	if current.Version != nil {
+		p.Version.Number = current.Version.Number + 1
+	}
+	body, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("%s calls json.Marshal and returns an error: %w", caller, err)
+	}
+	resp, err := a.requestAPI(ctx, http.MethodPut, fmt.Sprintf("%s/%s", path, url.PathEscape(p.Key)), body)
+	if err != nil {
+		return fmt.Errorf("%s calls a.requestAPI and returns an error: %w", caller, err)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("%s calls io.ReadAll and returns an error: %w", caller, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var msg string
+		switch resp.StatusCode {
+		case http.StatusBadRequest:
+			msg = "Bad request, the property value is invalid"
+		case http.StatusUnauthorized:
+			msg = "Authentication credentials are incorrect or missing from the request"
+		case http.StatusForbidden:
+			msg = "The calling user does not have permission to edit the owner of the property"
+		case http.StatusNotFound:
+			msg = "The owner of the property does not exist or the calling user can not view it"
+		case http.StatusConflict:
+			msg = "The property version has not been incremented, it was updated concurrently"
+		default:
+			msg = fmt.Sprintf("Invalid Status Code: %v", resp.StatusCode)
+		}
+		return fmt.Errorf("%s gets error: %v, message: %s", caller, msg, string(b))
+	}
+	return json.Unmarshal(b, p)
+}
+
+func (a *API) deleteProperty(ctx context.Context, caller, path, key string) error {
+	resp, err := a.requestAPI(ctx, http.MethodDelete, fmt.Sprintf("%s/%s", path, url.PathEscape(key)), nil)
+	if err != nil {
+		return fmt.Errorf("%s calls a.requestAPI and returns an error: %w", caller, err)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("%s calls io.ReadAll and returns an error: %w", caller, err)
+	}
+	if resp.StatusCode != http.StatusNoContent {
+		var msg string
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			msg = "Authentication credentials are incorrect or missing from the request"
+		case http.StatusForbidden:
+			msg = "The calling user does not have permission to edit the owner of the property"
+		case http.StatusNotFound:
+			msg = "There is no property with the given key or the calling user can not view it"
+		default:
+			msg = fmt.Sprintf("Invalid Status Code: %v", resp.StatusCode)
+		}
+		return fmt.Errorf("%s gets error: %v, message: %s", caller, msg, string(b))
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package confluence
+
+import (
+	"context"
+	"io"
+)
+
+// ContentService manages pages and blog posts.
+type ContentService interface {
+	GetContents(ctx context.Context, query ContentQuery) ([]Content, error)
+	GetContentById(ctx context.Context, id string) (*Content, error)
+	CreateContent(ctx context.Context, c *Content) error
+	UpdateContent(ctx context.Context, c *Content) error
+	DeleteContent(ctx context.Context, id string) error
+	PurgeContent(ctx context.Context, id string) error
+	ArchiveContent(ctx context.Context, id string) error
+	RestoreContent(ctx context.Context, c *Content) error
+}
+
+// PageHierarchyService moves and copies pages with their descendants.
+type PageHierarchyService interface {
+	GetChildPages(ctx context.Context, id string) ([]Content, error)
+	MoveContent(ctx context.Context, id, position, targetId string) error
+	CopyContent(ctx context.Context, id string, req CopyRequest) (*Content, error)
+	CopyPageHierarchy(ctx context.Context, id string, req CopyPageHierarchyRequest) (*LongTask, error)
+}
+
+// VersionService reads the history of a content and restores its previous
+// versions.
+type VersionService interface {
+	GetContentVersions(ctx context.Context, id string) ([]Version, error)
+	GetContentVersion(ctx context.Context, id string, number int) (*Version, error)
+	RestoreContentVersion(ctx context.Context, id string, number int, message string, restoreTitle bool) (*Version, error)
+}
+
+// BlogPostService reads blog posts with their posting date, they are
+// created, updated and deleted with the ContentService.
+type BlogPostService interface {
+	GetBlogPostById(ctx context.Context, id string) (*Content, error)
+	GetBlogPosts(ctx context.Context, query ContentQuery) ([]Content, error)
+}
+
+// CommentService reads comments with their container and location, they
+// are created, updated and deleted with the ContentService.
+type CommentService interface {
+	GetCommentById(ctx context.Context, id string) (*Content, error)
+}
+
+// AttachmentService uploads the files of a content.
+type AttachmentService interface {
+	UploadAttachment(ctx context.Context, contentId, filename, mediaType string, data []byte) (*Content, error)
+}
+
+// LabelService manages the labels of a content.
+type LabelService interface {
+	GetLabels(ctx context.Context, id string) ([]Label, error)
+	AddLabels(ctx context.Context, id string, labels []Label) error
+	DeleteLabel(ctx context.Context, id, name string) error
+}
+
+// SpaceService manages spaces.
+type SpaceService interface {
+	ListSpaces(ctx context.Context, query SpaceQuery) ([]Space, error)
+	GetSpace(ctx context.Context, key string) (*Space, error)
+	CreateSpace(ctx context.Context, space *Space) error
+	UpdateSpace(ctx context.Context, s *Space) error
+	DeleteSpace(ctx context.Context, key string) error
+}
+
+// SpaceExportService exports spaces to archives.
+type SpaceExportService interface {
+	ExportSpace(ctx context.Context, key, exportType string) (*SpaceExport, error)
+	DownloadSpaceExport(ctx context.Context, link string, w io.Writer) (int64, error)
+}
+
+// ContentPropertyService manages the properties of a content.
+type ContentPropertyService interface {
+	GetContentProperty(ctx context.Context, contentId, key string) (*Property, error)
+	CreateContentProperty(ctx context.Context, contentId string, p *Property) error
+	UpdateContentProperty(ctx context.Context, contentId string, p *Property) error
+	DeleteContentProperty(ctx context.Context, contentId, key string) error
+}
+
+// SpacePropertyService manages the properties of a space.
+type SpacePropertyService interface {
+	GetSpaceProperty(ctx context.Context, spaceKey, key string) (*Property, error)
+	CreateSpaceProperty(ctx context.Context, spaceKey string, p *Property) error
+	UpdateSpaceProperty(ctx context.Context, spaceKey string, p *Property) error
+	DeleteSpaceProperty(ctx context.Context, spaceKey, key string) error
+}
+
+// UserService looks up users.
+type UserService interface {
+	GetUser(ctx context.Context, accountId string) (*User, error)
+	GetCurrentUser(ctx context.Context) (*User, error)
+	SearchUsers(ctx context.Context, cql string) ([]User, error)
+}
+
+// WatcherService manages the users watching contents and spaces.
+type WatcherService interface {
+	GetContentWatchers(ctx context.Context, id string) ([]User, error)
+	WatchContent(ctx context.Context, id, accountId string) error
+	UnwatchContent(ctx context.Context, id, accountId string) error
+	GetSpaceWatchers(ctx context.Context, key string) ([]User, error)
+	WatchSpace(ctx context.Context, key, accountId string) error
+	UnwatchSpace(ctx context.Context, key, accountId string) error
+}
+
+// GroupService looks up groups and their members, the groups can only be
+// managed on confluence Data Center.
+type GroupService interface {
+	GetGroup(ctx context.Context, name string) (*Group, error)
+	GetGroupMembers(ctx context.Context, name string) ([]User, error)
+	CreateGroup(ctx context.Context, name string) error
+	DeleteGroup(ctx context.Context, name string) error
+	AddGroupMember(ctx context.Context, name, username string) error
+	RemoveGroupMember(ctx context.Context, name, username string) error
+}
+
+// TemplateService manages the page templates of spaces and the global
+// templates, and lists the blueprints.
+type TemplateService interface {
+	GetTemplate(ctx context.Context, id string) (*Template, error)
+	CreateTemplate(ctx context.Context, t *Template) error
+	UpdateTemplate(ctx context.Context, t *Template) error
+	DeleteTemplate(ctx context.Context, id string) error
+	ListTemplates(ctx context.Context, query TemplateQuery) ([]Template, error)
+}
+
+// Ensure API implements every service.
+var (
+	_ ContentService         = &API{}
+	_ PageHierarchyService   = &API{}
+	_ VersionService         = &API{}
+	_ BlogPostService        = &API{}
+	_ CommentService         = &API{}
+	_ AttachmentService      = &API{}
+	_ LabelService           = &API{}
+	_ SpaceService           = &API{}
+	_ SpaceExportService     = &API{}
+	_ ContentPropertyService = &API{}
+	_ SpacePropertyService   = &API{}
+	_ UserService            = &API{}
+	_ WatcherService         = &API{}
+	_ GroupService           = &API{}
+	_ TemplateService        = &API{}
+)
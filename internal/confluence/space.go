@@ -1,15 +1,95 @@
 package confluence
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 )
 
-func (a *API) GetSpace(key string) (*Space, error) {
+// spacePageLimit is the number of results requested per page on space listings.
+const spacePageLimit = 50
+
+// SpaceQuery filters the spaces returned by ListSpaces, empty fields are not sent.
+type SpaceQuery struct {
+	Keys   []string
+	Type   string
+	Status string
+	Labels []string
+	// Favourite returns only the spaces the calling user marked as favourite.
+	Favourite bool
+}
+
+func (q SpaceQuery) values() url.Values {
+	params := url.Values{}
+	for _, key := range q.Keys {
+		params.Add("spaceKey", key)
+	}
+	if q.Type != "" {
+		params.Set("type", q.Type)
+	}
+	if q.Status != "" {
+		params.Set("status", q.Status)
+	}
+	for _, label := range q.Labels {
+		params.Add("label", label)
+	}
+	if q.Favourite {
+		params.Set("favourite", "true")
+	}
+	params.Set("expand", "homepage")
+	return params
+}
+
+// ListSpaces returns every space that matches the query with its homepage,
+// it follows the pagination until the last page of results.
+func (a *API) ListSpaces(ctx context.Context, query SpaceQuery) ([]Space, error) {
+	spaces := []Space{}
+	params := query.values()
+	start := 0
+	for {
+		params.Set("start", strconv.Itoa(start))
+		params.Set("limit", strconv.Itoa(spacePageLimit))
+		resp, err := a.requestAPI(ctx, http.MethodGet, "/space?"+params.Encode(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("ListSpaces calls a.requestAPI and returns an error: %w", err)
+		}
+		b, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("ListSpaces calls io.ReadAll and returns an error: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			var msg string
+			switch resp.StatusCode {
+			case http.StatusBadRequest:
+				msg = "Bad request, one of the filters is invalid"
+			case http.StatusUnauthorized:
+				msg = "Authentication credentials are incorrect or missing from the request"
+			default:
+				msg = fmt.Sprintf("Invalid Status Code: %v", resp.StatusCode)
+			}
+			return nil, fmt.Errorf("ListSpaces gets error: %v, message: %s", msg, string(b))
+		}
+		var page SpaceArray
+		err = json.Unmarshal(b, &page)
+		if err != nil {
+			return nil, fmt.Errorf("ListSpaces calls json.Unmarshal and returns an error: %w", err)
+		}
+		spaces = append(spaces, page.Results...)
+		if len(page.Results) < spacePageLimit {
+			return spaces, nil
+		}
+		start += len(page.Results)
+	}
+}
+
+func (a *API) GetSpace(ctx context.Context, key string) (*Space, error) {
 	var space Space
-	resp, err := a.requestAPI(http.MethodGet, fmt.Sprintf("/space/%v", key), []byte(`{}`))
+	resp, err := a.requestAPI(ctx, http.MethodGet, fmt.Sprintf("/space/%v", key), []byte(`{}`))
 	if err != nil {
 		return nil, err
 	}
@@ -40,13 +120,13 @@ func (a *API) GetSpace(key string) (*Space, error) {
 	return &space, nil
 }
 
-func (a *API) CreateSpace(space *Space) error {
+func (a *API) CreateSpace(ctx context.Context, space *Space) error {
 	body, err := json.Marshal(space)
 	if err != nil {
 		return fmt.Errorf("CreateSpace calls json.Marshal and returns an error: %w", err)
 	}
 
-	resp, err := a.requestAPI(http.MethodPost, "/space", body)
+	resp, err := a.requestAPI(ctx, http.MethodPost, "/space", body)
 	if err != nil {
 		return fmt.Errorf("CreateSpace calls a.requestAPI and returns an error: %w", err)
 	}
@@ -79,8 +159,8 @@ func (a *API) CreateSpace(space *Space) error {
 	return nil
 }
 
-func (a *API) UpdateSpace(s *Space) error {
-	space, err := a.GetSpace(s.Key)
+func (a *API) UpdateSpace(ctx context.Context, s *Space) error {
+	space, err := a.GetSpace(ctx, s.Key)
 	if err != nil {
 		return fmt.Errorf("UpdateSpace calls a.GetSpace and returns an error %w", err)
 	}
@@ -91,7 +171,7 @@ func (a *API) UpdateSpace(s *Space) error {
 		return fmt.Errorf("UpdateSpace calls json.Marshal and returns an error %w", err)
 	}
 
-	resp, err := a.requestAPI(http.MethodPut, fmt.Sprintf("/space/%s", s.Key), body)
+	resp, err := a.requestAPI(ctx, http.MethodPut, fmt.Sprintf("/space/%s", s.Key), body)
 	if err != nil {
 		return fmt.Errorf("UpdateSpace calls a.requestAPI and returns an error %w", err)
 	}
@@ -118,8 +198,10 @@ func (a *API) UpdateSpace(s *Space) error {
 	return nil
 }
 
-func (a *API) DeleteSpace(key string) error {
-	space, err := a.GetSpace(key)
+// DeleteSpace deletes a space, confluence removes the space in a long task
+// so it waits until the task finishes or ctx is done.
+func (a *API) DeleteSpace(ctx context.Context, key string) error {
+	space, err := a.GetSpace(ctx, key)
 	if err != nil {
 		return fmt.Errorf("DeleteSpace calls a.GetSpace and returns an error %w", err)
 	}
@@ -127,7 +209,7 @@ func (a *API) DeleteSpace(key string) error {
 		return fmt.Errorf("DeleteSpace calls a.GetSpace and returns an space empty object")
 	}
 
-	resp, err := a.requestAPI(http.MethodDelete, fmt.Sprintf("/space/%s", key), []byte{})
+	resp, err := a.requestAPI(ctx, http.MethodDelete, fmt.Sprintf("/space/%s", key), []byte{})
 	if err != nil {
 		return fmt.Errorf("DeleteSpace calls a.requestAPI and returns an error %w", err)
 	}
@@ -151,5 +233,10 @@ func (a *API) DeleteSpace(key string) error {
 		return fmt.Errorf("DeleteSpace gets error: %v, message: %v", msg, string(b))
 	}
 
+	_, err = a.waitForLongTaskResponse(ctx, b)
+	if err != nil {
+		return fmt.Errorf("DeleteSpace calls a.waitForLongTaskResponse and returns an error %w", err)
+	}
+
 	return nil
 }
@@ -1,10 +1,8 @@
 package confluence
 
 import (
-	"net/http"
-	"net/http/httptest"
-	"net/url"
-	"strings"
+	"context"
+	"fmt"
 	"testing"
 )
 
@@ -20,29 +18,17 @@ func TestGetSpace(t *testing.T) {
 	}
 	for _, tC := range testCases {
 		t.Run(tC.desc, func(t *testing.T) {
-			server := httpServer([]byte(`{
-				"id":65541,"key":"DEVOPS","name":"devops","type":"global","status":"current"
-			}`))
-			defer server.Close()
-			serverURL := strings.Split(server.URL, "://")
+			api, _ := fakeAPI(t)
 
-			api := &API{
-				Client: server.Client(),
-				Endpoint: &url.URL{
-					Scheme: serverURL[0],
-					Host:   serverURL[1],
-				},
-			}
-
-			space, err := api.GetSpace("devops")
+			space, err := api.GetSpace(context.Background(), "devops")
 			if err != nil {
 				t.Error(err)
 			}
 			if space == nil {
 				t.Error("Space struct got empty")
 			}
-			if space.Id != 65541 {
-				t.Errorf("wants space.id: %v, but got %v", 65541, space.Id)
+			if space.Name != "devops" {
+				t.Errorf("wants space.name: %v, but got %v", "devops", space.Name)
 			}
 			if space.Key != "DEVOPS" {
 				t.Errorf("wants space.key: %v, but got %v", "DEVOPS", space.Key)
@@ -51,11 +37,62 @@ func TestGetSpace(t *testing.T) {
 	}
 }
 
-func httpServer(resp []byte) *httptest.Server {
-	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Add("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		w.Write(resp)
-	}))
-	return server
+func TestListSpaces(t *testing.T) {
+	api, server := fakeAPI(t)
+	server.AddSpace("TEAMA", "team a")
+	server.AddSpace("TEAMB", "team b")
+	server.SetSpaceLabels("TEAMA", "team")
+	server.SetSpaceLabels("TEAMB", "team")
+	server.SetSpaceFavourite("TEAMB", true)
+	for i := 0; i < spacePageLimit; i++ {
+		server.AddSpace(fmt.Sprintf("ARCHIVE%d", i), fmt.Sprintf("archive %d", i))
+	}
+
+	testCases := []struct {
+		desc  string
+		query SpaceQuery
+		want  []string
+	}{
+		{
+			desc:  "Keys",
+			query: SpaceQuery{Keys: []string{"DEVOPS", "TEAMA"}},
+			want:  []string{"DEVOPS", "TEAMA"},
+		},
+		{
+			desc:  "Label",
+			query: SpaceQuery{Labels: []string{"team"}},
+			want:  []string{"TEAMA", "TEAMB"},
+		},
+		{
+			desc:  "Favourite",
+			query: SpaceQuery{Favourite: true},
+			want:  []string{"TEAMB"},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			spaces, err := api.ListSpaces(context.Background(), tC.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := []string{}
+			for _, s := range spaces {
+				got = append(got, s.Key)
+				if s.Homepage == nil || s.Homepage.Id == "" {
+					t.Errorf("space %s, wants the homepage expanded, but got %v", s.Key, s.Homepage)
+				}
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tC.want) {
+				t.Errorf("wants %v, but got %v", tC.want, got)
+			}
+		})
+	}
+
+	spaces, err := api.ListSpaces(context.Background(), SpaceQuery{Type: "global", Status: "current"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(spaces) != spacePageLimit+3 {
+		t.Errorf("wants every page of spaces, %d spaces, but got %d", spacePageLimit+3, len(spaces))
+	}
 }
@@ -0,0 +1,186 @@
+package confluence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// templatePageLimit is the number of results requested per page on template listings.
+const templatePageLimit = 50
+
+// TemplateQuery filters the templates returned by ListTemplates.
+type TemplateQuery struct {
+	// SpaceKey lists the templates of the space, empty lists the global templates.
+	SpaceKey string
+	// Blueprints lists the blueprint templates instead of the page templates.
+	Blueprints bool
+}
+
+// GetTemplate returns the template with the given id and its body.
+func (a *API) GetTemplate(ctx context.Context, id string) (*Template, error) {
+	resp, err := a.requestAPI(ctx, http.MethodGet, fmt.Sprintf("/template/%s?expand=body", url.PathEscape(id)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("GetTemplate calls a.requestAPI and returns an error: %w", err)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("GetTemplate calls io.ReadAll and returns an error: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var msg string
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			msg = "Authentication credentials are incorrect or missing from the request"
+		case http.StatusForbidden:
+			msg = "The calling user does not have permission to view the template"
+		case http.StatusNotFound:
+			msg = "Not Found, there is no template with the given id"
+			return nil, fmt.Errorf("GetTemplate gets error: %w, message: %s", ErrNotFound, msg)
+		default:
+			msg = fmt.Sprintf("Invalid Status Code: %v", resp.StatusCode)
+		}
+		return nil, fmt.Errorf("GetTemplate gets error: %v, message: %s", msg, string(b))
+	}
+	var template Template
+	err = json.Unmarshal(b, &template)
+	if err != nil {
+		return nil, fmt.Errorf("GetTemplate calls json.Unmarshal and returns an error: %w", err)
+	}
+	return &template, nil
+}
+
+// CreateTemplate creates a page template, in the space when t.Space is set
+// or a global template otherwise. The template is updated with the response.
+func (a *API) CreateTemplate(ctx context.Context, t *Template) error {
+	return a.saveTemplate(ctx, "CreateTemplate", http.MethodPost, t)
+}
+
+// UpdateTemplate replaces the name, description, body and labels of a template.
+func (a *API) UpdateTemplate(ctx context.Context, t *Template) error {
+	return a.saveTemplate(ctx, "UpdateTemplate", http.MethodPut, t)
+}
+
+func (a *API) saveTemplate(ctx context.Context, caller, method string, t *Template) error {
+	if t.TemplateType == "" {
+		t.TemplateType = "page"
+	}
+	body, err := json.Marshal(t)
+	if err != nil {
+		return fmt.Errorf("%s calls json.Marshal and returns an error: %w", caller, err)
+	}
+	resp, err := a.requestAPI(ctx, method, "/template", body)
+	if err != nil {
+		return fmt.Errorf("%s calls a.requestAPI and returns an error: %w", caller, err)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("%s calls io.ReadAll and returns an error: %w", caller, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var msg string
+		switch resp.StatusCode {
+		case http.StatusBadRequest:
+			msg = "Bad request, the template is invalid or its name is already used"
+		case http.StatusUnauthorized:
+			msg = "Authentication credentials are incorrect or missing from the request"
+		case http.StatusForbidden:
+			msg = "The calling user does not have permission to administer the space or the global templates"
+		case http.StatusNotFound:
+			msg = "Not Found, the space or the template does not exist"
+		default:
+			msg = fmt.Sprintf("Invalid Status Code: %v", resp.StatusCode)
+		}
+		return fmt.Errorf("%s gets error: %v, message: %s", caller, msg, string(b))
+	}
+	return json.Unmarshal(b, t)
+}
+
+// DeleteTemplate deletes a page template, blueprint templates can not be deleted.
+func (a *API) DeleteTemplate(ctx context.Context, id string) error {
+	resp, err := a.requestAPI(ctx, http.MethodDelete, fmt.Sprintf("/template/%s", url.PathEscape(id)), nil)
+	if err != nil {
+		return fmt.Errorf("DeleteTemplate calls a.requestAPI and returns an error: %w", err)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("DeleteTemplate calls io.ReadAll and returns an error: %w", err)
+	}
+	if resp.StatusCode != http.StatusNoContent {
+		var msg string
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			msg = "Authentication credentials are incorrect or missing from the request"
+		case http.StatusForbidden:
+			msg = "The calling user does not have permission to delete the template"
+		case http.StatusNotFound:
+			msg = "Not Found, there is no template with the given id"
+		default:
+			msg = fmt.Sprintf("Invalid Status Code: %v", resp.StatusCode)
+		}
+		return fmt.Errorf("DeleteTemplate gets error: %v, message: %s", msg, string(b))
+	}
+	return nil
+}
+
+// ListTemplates returns the page or blueprint templates of a space or the
+// global ones, it follows the pagination until the last page. Page templates
+// are returned with their body.
+func (a *API) ListTemplates(ctx context.Context, query TemplateQuery) ([]Template, error) {
+	templates := []Template{}
+	path := "/template/page"
+	params := url.Values{}
+	if query.Blueprints {
+		path = "/template/blueprint"
+	} else {
+		params.Set("expand", "body")
+	}
+	if query.SpaceKey != "" {
+		params.Set("spaceKey", query.SpaceKey)
+	}
+	start := 0
+	for {
+		params.Set("start", strconv.Itoa(start))
+		params.Set("limit", strconv.Itoa(templatePageLimit))
+		resp, err := a.requestAPI(ctx, http.MethodGet, path+"?"+params.Encode(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("ListTemplates calls a.requestAPI and returns an error: %w", err)
+		}
+		b, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("ListTemplates calls io.ReadAll and returns an error: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			var msg string
+			switch resp.StatusCode {
+			case http.StatusUnauthorized:
+				msg = "Authentication credentials are incorrect or missing from the request"
+			case http.StatusForbidden:
+				msg = "The calling user does not have permission to view the space"
+			case http.StatusNotFound:
+				msg = "Not Found, there is no space with the given key"
+			default:
+				msg = fmt.Sprintf("Invalid Status Code: %v", resp.StatusCode)
+			}
+			return nil, fmt.Errorf("ListTemplates gets error: %v, message: %s", msg, string(b))
+		}
+		var page TemplateArray
+		err = json.Unmarshal(b, &page)
+		if err != nil {
+			return nil, fmt.Errorf("ListTemplates calls json.Unmarshal and returns an error: %w", err)
+		}
+		templates = append(templates, page.Results...)
+		if len(page.Results) < templatePageLimit {
+			return templates, nil
+		}
+		start += len(page.Results)
+	}
+}
@@ -1,5 +1,7 @@
 package confluence
 
+import "encoding/json"
+
 type Content struct {
 	Id        string    `json:"id,omitempty"`
 	Type      string    `json:"type,omitempty"`
@@ -9,6 +11,75 @@ type Content struct {
 	Ancestors []Content `json:"ancestors,omitempty"`
 	Body      Body      `json:"body,omitempty"`
 	Version   *Version  `json:"version,omitempty"`
+	History   *History  `json:"history,omitempty"`
+	// Container is the page or blog post a comment belongs to.
+	Container  *Content           `json:"container,omitempty"`
+	Extensions *ContentExtensions `json:"extensions,omitempty"`
+}
+
+// ContentExtensions holds the type specific properties of a content, e.g: the
+// location of a comment.
+type ContentExtensions struct {
+	// Location of a comment, footer or inline.
+	Location         string            `json:"location,omitempty"`
+	InlineProperties *InlineProperties `json:"inlineProperties,omitempty"`
+	// Position orders a page among the children of its parent, it is nil
+	// for pages that were never ordered, confluence answers "none" for them.
+	Position *int `json:"position,omitempty"`
+}
+
+// UnmarshalJSON decodes the extensions, a position that is not a number,
+// e.g: "none", is decoded as nil.
+func (e *ContentExtensions) UnmarshalJSON(b []byte) error {
+	type extensions ContentExtensions
+	var v struct {
+		extensions
+		Position json.RawMessage `json:"position"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*e = ContentExtensions(v.extensions)
+	var position int
+	if json.Unmarshal(v.Position, &position) == nil {
+		e.Position = &position
+	}
+	return nil
+}
+
+// InlineProperties anchors an inline comment to a text of the page.
+type InlineProperties struct {
+	OriginalSelection string `json:"originalSelection,omitempty"`
+	MarkerRef         string `json:"markerRef,omitempty"`
+}
+
+// History holds the creation date of a content, it is the posting date of
+// blog posts.
+type History struct {
+	CreatedDate string `json:"createdDate,omitempty"`
+}
+
+// ContentArray is a page of results returned by content listings.
+type ContentArray struct {
+	Results []Content `json:"results"`
+	Start   int       `json:"start,omitempty"`
+	Limit   int       `json:"limit,omitempty"`
+	Size    int       `json:"size,omitempty"`
+}
+
+type ArchiveRequest struct {
+	Pages []Content `json:"pages"`
+}
+
+type Label struct {
+	Id     string `json:"id,omitempty"`
+	Prefix string `json:"prefix,omitempty"`
+	Name   string `json:"name,omitempty"`
+}
+
+type LabelArray struct {
+	Results []Label `json:"results"`
+	Size    int     `json:"size,omitempty"`
 }
 
 type Space struct {
@@ -18,6 +89,23 @@ type Space struct {
 	Type        string            `json:"type,omitempty"`
 	Status      string            `json:"status,omitempty"`
 	Description *SpaceDescription `json:"description,omitempty"`
+	Homepage    *Content          `json:"homepage,omitempty"`
+}
+
+// MarshalJSON leaves the homepage out, it is read-only and is not sent on
+// create and update requests.
+func (s Space) MarshalJSON() ([]byte, error) {
+	type space Space
+	v := space(s)
+	v.Homepage = nil
+	return json.Marshal(v)
+}
+
+type SpaceArray struct {
+	Results []Space `json:"results"`
+	Start   int     `json:"start,omitempty"`
+	Limit   int     `json:"limit,omitempty"`
+	Size    int     `json:"size,omitempty"`
 }
 
 type Storage struct {
@@ -29,8 +117,22 @@ type Body struct {
 	Storage Storage `json:"storage,omitempty"`
 }
 
+// Version is a version of a content, the history returns who made it, when
+// and its message. Content is only returned when it is expanded.
 type Version struct {
-	Number int `json:"number,omitempty"`
+	Number    int      `json:"number,omitempty"`
+	By        *User    `json:"by,omitempty"`
+	When      string   `json:"when,omitempty"`
+	Message   string   `json:"message,omitempty"`
+	MinorEdit bool     `json:"minorEdit,omitempty"`
+	Content   *Content `json:"content,omitempty"`
+}
+
+type VersionArray struct {
+	Results []Version `json:"results"`
+	Start   int       `json:"start,omitempty"`
+	Limit   int       `json:"limit,omitempty"`
+	Size    int       `json:"size,omitempty"`
 }
 
 type SpaceDescription struct {
@@ -41,3 +143,126 @@ type Plain struct {
 	Value          string `json:"value,omitempty"`
 	Representation string `json:"representation,omitempty"`
 }
+
+// LongTaskRef is returned by operations that run asynchronously on the
+// confluence side, e.g: space deletion, the status link points to the long task.
+type LongTaskRef struct {
+	Id    string        `json:"id,omitempty"`
+	Links *LongTaskLink `json:"links,omitempty"`
+}
+
+type LongTaskLink struct {
+	Status string `json:"status,omitempty"`
+}
+
+type LongTask struct {
+	Id                 string            `json:"id,omitempty"`
+	Name               *LongTaskName     `json:"name,omitempty"`
+	ElapsedTime        int64             `json:"elapsedTime,omitempty"`
+	PercentageComplete int               `json:"percentageComplete,omitempty"`
+	Successful         bool              `json:"successful,omitempty"`
+	Finished           bool              `json:"finished,omitempty"`
+	Messages           []LongTaskMessage `json:"messages,omitempty"`
+	Errors             []LongTaskMessage `json:"errors,omitempty"`
+	Status             string            `json:"status,omitempty"`
+	AdditionalDetails  map[string]any    `json:"additionalDetails,omitempty"`
+}
+
+type LongTaskName struct {
+	Key string `json:"key,omitempty"`
+}
+
+type LongTaskMessage struct {
+	Translation string `json:"translation,omitempty"`
+	Args        []any  `json:"args,omitempty"`
+}
+
+// Property is a key with a JSON value stored on a content or a space.
+type Property struct {
+	Id      string          `json:"id,omitempty"`
+	Key     string          `json:"key"`
+	Value   json.RawMessage `json:"value"`
+	Version *Version        `json:"version,omitempty"`
+}
+
+// User is an Atlassian account, the email is only returned when the account
+// privacy settings allow it. Data Center identifies users by username
+// instead of account id.
+type User struct {
+	Type        string `json:"type,omitempty"`
+	AccountId   string `json:"accountId,omitempty"`
+	Username    string `json:"username,omitempty"`
+	AccountType string `json:"accountType,omitempty"`
+	Email       string `json:"email,omitempty"`
+	PublicName  string `json:"publicName,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+}
+
+type UserArray struct {
+	Results []User `json:"results"`
+	Start   int    `json:"start,omitempty"`
+	Limit   int    `json:"limit,omitempty"`
+	Size    int    `json:"size,omitempty"`
+}
+
+// UserSearchArray is the page returned by the user search, every result
+// wraps the user.
+type UserSearchArray struct {
+	Results []struct {
+		User User `json:"user"`
+	} `json:"results"`
+	Start int `json:"start,omitempty"`
+	Limit int `json:"limit,omitempty"`
+	Size  int `json:"size,omitempty"`
+}
+
+// Watch is a user watching a content or a space, one of ContentId or
+// SpaceKey is set.
+type Watch struct {
+	Type      string `json:"type,omitempty"`
+	Watcher   User   `json:"watcher"`
+	ContentId string `json:"contentId,omitempty"`
+	SpaceKey  string `json:"spaceKey,omitempty"`
+}
+
+type WatchArray struct {
+	Results []Watch `json:"results"`
+	Start   int     `json:"start,omitempty"`
+	Limit   int     `json:"limit,omitempty"`
+	Size    int     `json:"size,omitempty"`
+}
+
+type Group struct {
+	Type string `json:"type,omitempty"`
+	Name string `json:"name"`
+	Id   string `json:"id,omitempty"`
+}
+
+// Template is a page template of a space, or a global template when it has
+// no space. Blueprint templates are provided by plugins and reference their
+// module in OriginalTemplate, they can not be created nor deleted.
+type Template struct {
+	TemplateId   string `json:"templateId,omitempty"`
+	Name         string `json:"name"`
+	TemplateType string `json:"templateType,omitempty"`
+	// Description is always sent, an empty description clears it on update.
+	Description          string            `json:"description"`
+	Body                 *Body             `json:"body,omitempty"`
+	Labels               []Label           `json:"labels,omitempty"`
+	Space                *Space            `json:"space,omitempty"`
+	OriginalTemplate     *OriginalTemplate `json:"originalTemplate,omitempty"`
+	ReferencingBlueprint string            `json:"referencingBlueprint,omitempty"`
+}
+
+// OriginalTemplate identifies the plugin module a blueprint template comes from.
+type OriginalTemplate struct {
+	PluginKey string `json:"pluginKey,omitempty"`
+	ModuleKey string `json:"moduleKey,omitempty"`
+}
+
+type TemplateArray struct {
+	Results []Template `json:"results"`
+	Start   int        `json:"start,omitempty"`
+	Limit   int        `json:"limit,omitempty"`
+	Size    int        `json:"size,omitempty"`
+}
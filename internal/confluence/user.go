@@ -0,0 +1,185 @@
+package confluence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// userPageLimit is the number of results requested per page on user listings.
+const userPageLimit = 50
+
+// GetUser returns the user with the given account id.
+func (a *API) GetUser(ctx context.Context, accountId string) (*User, error) {
+	params := url.Values{}
+	params.Set("accountId", accountId)
+	return a.getUser(ctx, "GetUser", "/user?"+params.Encode())
+}
+
+// GetCurrentUser returns the user the provider is authenticated as.
+func (a *API) GetCurrentUser(ctx context.Context) (*User, error) {
+	return a.getUser(ctx, "GetCurrentUser", "/user/current")
+}
+
+func (a *API) getUser(ctx context.Context, caller, path string) (*User, error) {
+	resp, err := a.requestAPI(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s calls a.requestAPI and returns an error: %w", caller, err)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%s calls io.ReadAll and returns an error: %w", caller, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var msg string
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			msg = "Authentication credentials are incorrect or missing from the request"
+		case http.StatusForbidden:
+			msg = "The calling user does not have permission to view users"
+		case http.StatusNotFound:
+			msg = "Not Found, there is no user with the given account id"
+		default:
+			msg = fmt.Sprintf("Invalid Status Code: %v", resp.StatusCode)
+		}
+		return nil, fmt.Errorf("%s gets error: %v, message: %s", caller, msg, string(b))
+	}
+	var user User
+	err = json.Unmarshal(b, &user)
+	if err != nil {
+		return nil, fmt.Errorf("%s calls json.Unmarshal and returns an error: %w", caller, err)
+	}
+	return &user, nil
+}
+
+// SearchUsers returns every user that matches the CQL query, e.g:
+// user.fullname~"Jane", it follows the pagination until the last page.
+func (a *API) SearchUsers(ctx context.Context, cql string) ([]User, error) {
+	users := []User{}
+	params := url.Values{}
+	params.Set("cql", cql)
+	start := 0
+	for {
+		params.Set("start", strconv.Itoa(start))
+		params.Set("limit", strconv.Itoa(userPageLimit))
+		resp, err := a.requestAPI(ctx, http.MethodGet, "/search/user?"+params.Encode(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("SearchUsers calls a.requestAPI and returns an error: %w", err)
+		}
+		b, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("SearchUsers calls io.ReadAll and returns an error: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			var msg string
+			switch resp.StatusCode {
+			case http.StatusBadRequest:
+				msg = "Bad request, the CQL query is invalid"
+			case http.StatusUnauthorized:
+				msg = "Authentication credentials are incorrect or missing from the request"
+			case http.StatusForbidden:
+				msg = "The calling user does not have permission to search users"
+			default:
+				msg = fmt.Sprintf("Invalid Status Code: %v", resp.StatusCode)
+			}
+			return nil, fmt.Errorf("SearchUsers gets error: %v, message: %s", msg, string(b))
+		}
+		var page UserSearchArray
+		err = json.Unmarshal(b, &page)
+		if err != nil {
+			return nil, fmt.Errorf("SearchUsers calls json.Unmarshal and returns an error: %w", err)
+		}
+		for _, result := range page.Results {
+			users = append(users, result.User)
+		}
+		if len(page.Results) < userPageLimit {
+			return users, nil
+		}
+		start += len(page.Results)
+	}
+}
+
+// GetGroup returns the group with the given name.
+func (a *API) GetGroup(ctx context.Context, name string) (*Group, error) {
+	resp, err := a.requestAPI(ctx, http.MethodGet, fmt.Sprintf("/group/%s", url.PathEscape(name)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("GetGroup calls a.requestAPI and returns an error: %w", err)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("GetGroup calls io.ReadAll and returns an error: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var msg string
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			msg = "Authentication credentials are incorrect or missing from the request"
+		case http.StatusForbidden:
+			msg = "The calling user does not have permission to view groups"
+		case http.StatusNotFound:
+			msg = "Not Found, there is no group with the given name"
+			return nil, fmt.Errorf("GetGroup gets error: %w, message: %s", ErrNotFound, msg)
+		default:
+			msg = fmt.Sprintf("Invalid Status Code: %v", resp.StatusCode)
+		}
+		return nil, fmt.Errorf("GetGroup gets error: %v, message: %s", msg, string(b))
+	}
+	var group Group
+	err = json.Unmarshal(b, &group)
+	if err != nil {
+		return nil, fmt.Errorf("GetGroup calls json.Unmarshal and returns an error: %w", err)
+	}
+	return &group, nil
+}
+
+// GetGroupMembers returns every member of a group, it follows the pagination
+// until the last page.
+func (a *API) GetGroupMembers(ctx context.Context, name string) ([]User, error) {
+	users := []User{}
+	params := url.Values{}
+	start := 0
+	for {
+		params.Set("start", strconv.Itoa(start))
+		params.Set("limit", strconv.Itoa(userPageLimit))
+		resp, err := a.requestAPI(ctx, http.MethodGet, fmt.Sprintf("/group/%s/member?%s", url.PathEscape(name), params.Encode()), nil)
+		if err != nil {
+			return nil, fmt.Errorf("GetGroupMembers calls a.requestAPI and returns an error: %w", err)
+		}
+		b, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("GetGroupMembers calls io.ReadAll and returns an error: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			var msg string
+			switch resp.StatusCode {
+			case http.StatusUnauthorized:
+				msg = "Authentication credentials are incorrect or missing from the request"
+			case http.StatusForbidden:
+				msg = "The calling user does not have permission to view groups"
+			case http.StatusNotFound:
+				msg = "Not Found, there is no group with the given name"
+			default:
+				msg = fmt.Sprintf("Invalid Status Code: %v", resp.StatusCode)
+			}
+			return nil, fmt.Errorf("GetGroupMembers gets error: %v, message: %s", msg, string(b))
+		}
+		var page UserArray
+		err = json.Unmarshal(b, &page)
+		if err != nil {
+			return nil, fmt.Errorf("GetGroupMembers calls json.Unmarshal and returns an error: %w", err)
+		}
+		users = append(users, page.Results...)
+		if len(page.Results) < userPageLimit {
+			return users, nil
+		}
+		start += len(page.Results)
+	}
+}
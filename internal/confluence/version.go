@@ -0,0 +1,147 @@
+package confluence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// versionRestoreRequest restores a previous version of a content as its new
+// version.
+type versionRestoreRequest struct {
+	OperationKey string `json:"operationKey"`
+	Params       struct {
+		VersionNumber int    `json:"versionNumber"`
+		Message       string `json:"message"`
+		RestoreTitle  bool   `json:"restoreTitle"`
+	} `json:"params"`
+}
+
+// GetContentVersions returns the history of a content, newest first, it
+// follows the pagination until the last page.
+func (a *API) GetContentVersions(ctx context.Context, id string) ([]Version, error) {
+	versions := []Version{}
+	params := url.Values{}
+	start := 0
+	for {
+		params.Set("start", strconv.Itoa(start))
+		params.Set("limit", strconv.Itoa(contentPageLimit))
+		resp, err := a.requestAPI(ctx, http.MethodGet, fmt.Sprintf("/content/%s/version?%s", id, params.Encode()), nil)
+		if err != nil {
+			return nil, fmt.Errorf("GetContentVersions calls a.requestAPI and returns an error: %w", err)
+		}
+		b, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("GetContentVersions calls io.ReadAll and returns an error: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			var msg string
+			switch resp.StatusCode {
+			case http.StatusUnauthorized:
+				msg = "Authentication credentials are incorrect or missing from the request"
+			case http.StatusForbidden:
+				msg = "The calling user does not have permission to view the content"
+			case http.StatusNotFound:
+				msg = "Not Found, there is no content with the given id"
+			default:
+				msg = fmt.Sprintf("Invalid Status Code: %v", resp.StatusCode)
+			}
+			return nil, fmt.Errorf("GetContentVersions gets error: %v, message: %s", msg, string(b))
+		}
+		var page VersionArray
+		err = json.Unmarshal(b, &page)
+		if err != nil {
+			return nil, fmt.Errorf("GetContentVersions calls json.Unmarshal and returns an error: %w", err)
+		}
+		versions = append(versions, page.Results...)
+		if len(page.Results) < contentPageLimit {
+			return versions, nil
+		}
+		start += len(page.Results)
+	}
+}
+
+// GetContentVersion returns a version of a content with the content title
+// and body of that version.
+func (a *API) GetContentVersion(ctx context.Context, id string, number int) (*Version, error) {
+	resp, err := a.requestAPI(ctx, http.MethodGet, fmt.Sprintf("/content/%s/version/%d?expand=content.body.storage", id, number), nil)
+	if err != nil {
+		return nil, fmt.Errorf("GetContentVersion calls a.requestAPI and returns an error: %w", err)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("GetContentVersion calls io.ReadAll and returns an error: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var msg string
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			msg = "Authentication credentials are incorrect or missing from the request"
+		case http.StatusForbidden:
+			msg = "The calling user does not have permission to view the content"
+		case http.StatusNotFound:
+			msg = "Not Found, there is no content with the given id or no version with the given number"
+		default:
+			msg = fmt.Sprintf("Invalid Status Code: %v", resp.StatusCode)
+		}
+		return nil, fmt.Errorf("GetContentVersion gets error: %v, message: %s", msg, string(b))
+	}
+	var version Version
+	err = json.Unmarshal(b, &version)
+	if err != nil {
+		return nil, fmt.Errorf("GetContentVersion calls json.Unmarshal and returns an error: %w", err)
+	}
+	return &version, nil
+}
+
+// RestoreContentVersion restores the body, and the title when restoreTitle
+// is set, of a previous version of a content. It returns the new version.
+func (a *API) RestoreContentVersion(ctx context.Context, id string, number int, message string, restoreTitle bool) (*Version, error) {
+	req := versionRestoreRequest{OperationKey: "restore"}
+	req.Params.VersionNumber = number
+	req.Params.Message = message
+	req.Params.RestoreTitle = restoreTitle
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("RestoreContentVersion calls json.Marshal and returns an error: %w", err)
+	}
+	resp, err := a.requestAPI(ctx, http.MethodPost, fmt.Sprintf("/content/%s/version", id), body)
+	if err != nil {
+		return nil, fmt.Errorf("RestoreContentVersion calls a.requestAPI and returns an error: %w", err)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("RestoreContentVersion calls io.ReadAll and returns an error: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var msg string
+		switch resp.StatusCode {
+		case http.StatusBadRequest:
+			msg = "Bad request, the version number is invalid"
+		case http.StatusUnauthorized:
+			msg = "Authentication credentials are incorrect or missing from the request"
+		case http.StatusForbidden:
+			msg = "The calling user does not have permission to edit the content"
+		case http.StatusNotFound:
+			msg = "Not Found, there is no content with the given id or no version with the given number"
+		case http.StatusConflict:
+			msg = "Conflict, the content was updated while it was restored"
+		default:
+			msg = fmt.Sprintf("Invalid Status Code: %v", resp.StatusCode)
+		}
+		return nil, fmt.Errorf("RestoreContentVersion gets error: %v, message: %s", msg, string(b))
+	}
+	var version Version
+	err = json.Unmarshal(b, &version)
+	if err != nil {
+		return nil, fmt.Errorf("RestoreContentVersion calls json.Unmarshal and returns an error: %w", err)
+	}
+	return &version, nil
+}
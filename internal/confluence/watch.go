@@ -0,0 +1,121 @@
+package confluence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// GetContentWatchers returns the users watching a content, it follows the
+// pagination until the last page.
+func (a *API) GetContentWatchers(ctx context.Context, id string) ([]User, error) {
+	return a.getWatchers(ctx, "GetContentWatchers", fmt.Sprintf("/content/%s/notification/created", url.PathEscape(id)))
+}
+
+// GetSpaceWatchers returns the users watching a space, it follows the
+// pagination until the last page.
+func (a *API) GetSpaceWatchers(ctx context.Context, key string) ([]User, error) {
+	return a.getWatchers(ctx, "GetSpaceWatchers", fmt.Sprintf("/space/%s/watch", url.PathEscape(key)))
+}
+
+// WatchContent makes the user with the account id watch a content.
+func (a *API) WatchContent(ctx context.Context, id, accountId string) error {
+	return a.watch(ctx, "WatchContent", http.MethodPost, fmt.Sprintf("/user/watch/content/%s", url.PathEscape(id)), accountId)
+}
+
+// UnwatchContent stops the user with the account id watching a content.
+func (a *API) UnwatchContent(ctx context.Context, id, accountId string) error {
+	return a.watch(ctx, "UnwatchContent", http.MethodDelete, fmt.Sprintf("/user/watch/content/%s", url.PathEscape(id)), accountId)
+}
+
+// WatchSpace makes the user with the account id watch a space.
+func (a *API) WatchSpace(ctx context.Context, key, accountId string) error {
+	return a.watch(ctx, "WatchSpace", http.MethodPost, fmt.Sprintf("/user/watch/space/%s", url.PathEscape(key)), accountId)
+}
+
+// UnwatchSpace stops the user with the account id watching a space.
+func (a *API) UnwatchSpace(ctx context.Context, key, accountId string) error {
+	return a.watch(ctx, "UnwatchSpace", http.MethodDelete, fmt.Sprintf("/user/watch/space/%s", url.PathEscape(key)), accountId)
+}
+
+func (a *API) getWatchers(ctx context.Context, caller, path string) ([]User, error) {
+	users := []User{}
+	params := url.Values{}
+	start := 0
+	for {
+		params.Set("start", strconv.Itoa(start))
+		params.Set("limit", strconv.Itoa(userPageLimit))
+		resp, err := a.requestAPI(ctx, http.MethodGet, path+"?"+params.Encode(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("%s calls a.requestAPI and returns an error: %w", caller, err)
+		}
+		b, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s calls io.ReadAll and returns an error: %w", caller, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			var msg string
+			switch resp.StatusCode {
+			case http.StatusUnauthorized:
+				msg = "Authentication credentials are incorrect or missing from the request"
+			case http.StatusForbidden:
+				msg = "The calling user does not have permission to view the watchers"
+			case http.StatusNotFound:
+				msg = "Not Found, there is no content or space with the given id or key"
+				return nil, fmt.Errorf("%s gets error: %w, message: %s", caller, ErrNotFound, msg)
+			default:
+				msg = fmt.Sprintf("Invalid Status Code: %v", resp.StatusCode)
+			}
+			return nil, fmt.Errorf("%s gets error: %v, message: %s", caller, msg, string(b))
+		}
+		var page WatchArray
+		err = json.Unmarshal(b, &page)
+		if err != nil {
+			return nil, fmt.Errorf("%s calls json.Unmarshal and returns an error: %w", caller, err)
+		}
+		for _, result := range page.Results {
+			users = append(users, result.Watcher)
+		}
+		if len(page.Results) < userPageLimit {
+			return users, nil
+		}
+		start += len(page.Results)
+	}
+}
+
+func (a *API) watch(ctx context.Context, caller, method, path, accountId string) error {
+	params := url.Values{}
+	params.Set("accountId", accountId)
+	header := http.Header{}
+	// Confluence rejects watch changes without the XSRF opt out.
+	header.Set("X-Atlassian-Token", xsrfNoCheck)
+	resp, err := a.requestAPIWithHeader(ctx, method, path+"?"+params.Encode(), nil, header)
+	if err != nil {
+		return fmt.Errorf("%s calls a.requestAPIWithHeader and returns an error: %w", caller, err)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("%s calls io.ReadAll and returns an error: %w", caller, err)
+	}
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		var msg string
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			msg = "Authentication credentials are incorrect or missing from the request"
+		case http.StatusForbidden:
+			msg = "The calling user does not have permission to manage the watchers of other users"
+		case http.StatusNotFound:
+			msg = "Not Found, there is no user, content or space with the given id"
+		default:
+			msg = fmt.Sprintf("Invalid Status Code: %v", resp.StatusCode)
+		}
+		return fmt.Errorf("%s gets error: %v, message: %s", caller, msg, string(b))
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package confluencefake
+
+import (
+	"io"
+	"net/http"
+	"strings"
+)
+
+// serveAttachments lists and uploads attachments, POST fails when a file
+// with the same name exists while PUT creates a new version of it.
+func (s *Server) serveAttachments(w http.ResponseWriter, r *http.Request, containerId string) {
+	container, ok := s.contents[containerId]
+	if !ok || container.Status == "trashed" {
+		writeError(w, http.StatusNotFound, "no content with id "+containerId)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		results := []map[string]any{}
+		for _, id := range sortedKeys(s.contents) {
+			c := s.contents[id]
+			if c.Type == "attachment" && c.ContainerId == containerId {
+				if name := r.URL.Query().Get("filename"); name != "" && c.Title != name {
+					continue
+				}
+				results = append(results, s.contentJSON(c))
+			}
+		}
+		page(w, r, results)
+	case http.MethodPost, http.MethodPut:
+		if r.Header.Get("X-Atlassian-Token") != xsrfNoCheck {
+			writeError(w, http.StatusForbidden, "XSRF check failed")
+			return
+		}
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "a multipart file is required: "+err.Error())
+			return
+		}
+		defer file.Close()
+		data, err := io.ReadAll(file)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		attachment := s.attachment(containerId, header.Filename)
+		switch {
+		case attachment != nil && r.Method == http.MethodPost:
+			writeError(w, http.StatusBadRequest, "Cannot add a new attachment with same file name as an existing attachment: "+header.Filename)
+			return
+		case attachment != nil:
+			attachment.Version++
+		default:
+			attachment = &content{
+				Id:          s.newId(),
+				Type:        "attachment",
+				Title:       header.Filename,
+				Status:      "current",
+				SpaceKey:    container.SpaceKey,
+				ContainerId: containerId,
+				Version:     1,
+			}
+			s.contents[attachment.Id] = attachment
+		}
+		attachment.MediaType = header.Header.Get("Content-Type")
+		s.attachments[attachment.Id] = data
+		page(w, r, []map[string]any{s.contentJSON(attachment)})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (s *Server) attachment(containerId, filename string) *content {
+	for _, c := range s.contents {
+		if c.Type == "attachment" && c.ContainerId == containerId && c.Title == filename {
+			return c
+		}
+	}
+	return nil
+}
+
+// downloadAttachment serves /wiki/download/attachments/{containerId}/{filename}.
+func (s *Server) downloadAttachment(w http.ResponseWriter, r *http.Request) {
+	segments := strings.Split(strings.TrimPrefix(r.URL.Path, "/wiki/download/attachments/"), "/")
+	if len(segments) != 2 {
+		writeError(w, http.StatusNotFound, "unknown attachment path")
+		return
+	}
+	attachment := s.attachment(segments[0], segments[1])
+	if attachment == nil {
+		writeError(w, http.StatusNotFound, "no attachment "+segments[1])
+		return
+	}
+	w.Header().Set("Content-Type", attachment.MediaType)
+	w.WriteHeader(http.StatusOK)
+	w.Write(s.attachments[attachment.Id])
+}
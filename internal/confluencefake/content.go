@@ -0,0 +1,489 @@
+package confluencefake
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+func (s *Server) serveContent(w http.ResponseWriter, r *http.Request, segments []string) {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		s.listContents(w, r)
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		s.createContent(w, r)
+	case len(segments) == 1 && segments[0] == "archive" && r.Method == http.MethodPost:
+		s.archiveContents(w, r)
+	case len(segments) == 1:
+		s.serveContentById(w, r, segments[0])
+	case len(segments) >= 2 && segments[1] == "label":
+		s.serveLabels(w, r, segments[0], segments[2:])
+	case len(segments) >= 2 && segments[1] == "property":
+		s.serveProperties(w, r, s.contentProperties(segments[0]), segments[2:])
+	case len(segments) == 3 && segments[1] == "notification" && segments[2] == "created" && r.Method == http.MethodGet:
+		s.listWatchers(w, r, "content", segments[0])
+	case len(segments) >= 2 && segments[1] == "version":
+		s.serveVersions(w, r, segments[0], segments[2:])
+	case len(segments) == 2 && segments[1] == "restriction":
+		s.serveRestrictions(w, r, segments[0])
+	case len(segments) == 3 && segments[1] == "child" && segments[2] == "attachment":
+		s.serveAttachments(w, r, segments[0])
+	case len(segments) == 3 && segments[1] == "child" && r.Method == http.MethodGet:
+		s.listChildren(w, r, segments[0], segments[2])
+	case len(segments) == 4 && segments[1] == "move" && r.Method == http.MethodPut:
+		s.moveContent(w, segments[0], segments[2], segments[3])
+	case len(segments) == 2 && segments[1] == "copy" && r.Method == http.MethodPost:
+		s.copyContent(w, r, segments[0])
+	case len(segments) == 3 && segments[1] == "pagehierarchy" && segments[2] == "copy" && r.Method == http.MethodPost:
+		s.copyPageHierarchy(w, r, segments[0])
+	default:
+		writeError(w, http.StatusNotFound, "unknown content path "+r.URL.Path)
+	}
+}
+
+func (s *Server) listContents(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	status := q.Get("status")
+	if status == "" {
+		status = "current"
+	}
+	results := []map[string]any{}
+	for _, id := range sortedKeys(s.contents) {
+		c := s.contents[id]
+		if c.Type == "attachment" || c.Type == "comment" {
+			continue
+		}
+		if q.Get("spaceKey") != "" && !strings.EqualFold(c.SpaceKey, q.Get("spaceKey")) {
+			continue
+		}
+		if q.Get("title") != "" && c.Title != q.Get("title") {
+			continue
+		}
+		if q.Get("type") != "" && c.Type != q.Get("type") {
+			continue
+		}
+		if status != "any" && c.Status != status {
+			continue
+		}
+		if q.Get("postingDay") != "" && (c.Type != "blogpost" || !strings.HasPrefix(c.Created, q.Get("postingDay"))) {
+			continue
+		}
+		results = append(results, s.contentJSON(c))
+	}
+	page(w, r, results)
+}
+
+func (s *Server) createContent(w http.ResponseWriter, r *http.Request) {
+	var req contentRequest
+	if err := decode(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if req.Type == "" || (req.Title == "" && req.Type != "comment") {
+		writeError(w, http.StatusBadRequest, "type and title are required")
+		return
+	}
+	c := &content{
+		Id:      s.newId(),
+		Type:    req.Type,
+		Title:   req.Title,
+		Status:  "current",
+		Body:    req.Body.Storage.Value,
+		Version: 1,
+		Created: time.Now().UTC().Format(createdLayout),
+	}
+	if req.History != nil && req.History.CreatedDate != "" {
+		c.Created = req.History.CreatedDate
+	}
+	if req.Container != nil {
+		container, ok := s.contents[req.Container.Id]
+		if !ok {
+			writeError(w, http.StatusNotFound, "no container with id "+req.Container.Id)
+			return
+		}
+		c.ContainerId = container.Id
+		c.SpaceKey = container.SpaceKey
+		if c.Type == "comment" && c.Title == "" {
+			c.Title = "Re: " + container.Title
+		}
+	}
+	if c.Type == "comment" {
+		if c.ContainerId == "" {
+			writeError(w, http.StatusBadRequest, "comments require a container")
+			return
+		}
+		c.Location = "footer"
+		if req.Extensions != nil && req.Extensions.Location != "" {
+			c.Location = req.Extensions.Location
+		}
+		if c.Location == "inline" {
+			if req.Extensions.InlineProperties == nil || req.Extensions.InlineProperties.OriginalSelection == "" {
+				writeError(w, http.StatusBadRequest, "inline comments require an originalSelection")
+				return
+			}
+			c.Selection = req.Extensions.InlineProperties.OriginalSelection
+			if !strings.Contains(s.contents[c.ContainerId].Body, c.Selection) {
+				writeError(w, http.StatusBadRequest, "Cannot find the selection in the content: "+c.Selection)
+				return
+			}
+		}
+	}
+	if req.Space != nil {
+		c.SpaceKey = req.Space.Key
+	}
+	sp, ok := s.space(c.SpaceKey)
+	if !ok {
+		writeError(w, http.StatusForbidden, "no space with key "+c.SpaceKey)
+		return
+	}
+	c.SpaceKey = sp.Key
+	if len(req.Ancestors) > 0 {
+		parentId := req.Ancestors[len(req.Ancestors)-1].Id
+		if _, ok := s.contents[parentId]; !ok {
+			writeError(w, http.StatusNotFound, "no parent content with id "+parentId)
+			return
+		}
+		c.ParentId = parentId
+	}
+	if c.Type == "page" {
+		c.Position = s.appendPosition(c.ParentId)
+	}
+	if c.Type == "page" && s.titleTaken(c.SpaceKey, c.Title, "") {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("A page with this title already exists: A page already exists with the title %s in this space", c.Title))
+		return
+	}
+	if c.Type == "blogpost" && s.blogPostTitleTaken(c.SpaceKey, c.Title, c.Created, "") {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("A blog post with this title already exists: A blog post already exists with the title %s on this day", c.Title))
+		return
+	}
+	s.contents[c.Id] = c
+	s.recordVersion(c, "")
+	writeJSON(w, http.StatusOK, s.contentJSON(c))
+}
+
+// createdLayout is the format of the creation dates.
+const createdLayout = "2006-01-02T15:04:05.000Z"
+
+// blogPostTitleTaken reports whether a blog post other than id uses the title
+// in the space on the same posting day, confluence keys blog posts by day.
+func (s *Server) blogPostTitleTaken(spaceKey, title, created, id string) bool {
+	for _, c := range s.contents {
+		if c.Id != id && c.Type == "blogpost" && strings.EqualFold(c.SpaceKey, spaceKey) && c.Title == title &&
+			c.Status != "archived" && len(c.Created) >= 10 && len(created) >= 10 && c.Created[:10] == created[:10] {
+			return true
+		}
+	}
+	return false
+}
+
+// titleTaken reports whether a current or trashed page other than id uses
+// the title in the space, confluence keeps titles of trashed pages.
+func (s *Server) titleTaken(spaceKey, title, id string) bool {
+	for _, c := range s.contents {
+		if c.Id != id && c.Type == "page" && strings.EqualFold(c.SpaceKey, spaceKey) && c.Title == title && c.Status != "archived" {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) serveContentById(w http.ResponseWriter, r *http.Request, id string) {
+	c, ok := s.contents[id]
+	status := r.URL.Query().Get("status")
+	if !ok || (c.Status == "trashed" && status != "trashed" && r.Method != http.MethodPut) {
+		writeError(w, http.StatusNotFound, "no content with id "+id)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, s.contentJSON(c))
+	case http.MethodPut:
+		s.updateContent(w, r, c)
+	case http.MethodDelete:
+		switch {
+		case status == "trashed" && c.Status != "trashed":
+			writeError(w, http.StatusNotFound, "content "+id+" is not in the trash")
+		case status == "trashed" || c.Type == "attachment" || c.Type == "comment":
+			s.purgeContent(id)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			s.trashContent(c)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (s *Server) updateContent(w http.ResponseWriter, r *http.Request, c *content) {
+	var req contentRequest
+	if err := decode(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if req.Version == nil || req.Type == "" || (req.Title == "" && c.Type != "comment") {
+		writeError(w, http.StatusBadRequest, "version, type and title are required")
+		return
+	}
+	if req.Title == "" {
+		req.Title = c.Title
+	}
+	if req.Version.Number != c.Version+1 {
+		writeError(w, http.StatusConflict, fmt.Sprintf("Version must be incremented on update. Current version is: %d", c.Version))
+		return
+	}
+	if c.Status == "trashed" && req.Status != "current" {
+		writeError(w, http.StatusNotFound, "no content with id "+c.Id)
+		return
+	}
+	spaceKey := c.SpaceKey
+	if req.Space != nil && req.Space.Key != "" {
+		sp, ok := s.space(req.Space.Key)
+		if !ok {
+			writeError(w, http.StatusForbidden, "no space with key "+req.Space.Key)
+			return
+		}
+		spaceKey = sp.Key
+	}
+	if c.Type == "page" && s.titleTaken(spaceKey, req.Title, c.Id) {
+		writeError(w, http.StatusBadRequest, "A page with this title already exists in this space")
+		return
+	}
+	if c.Type == "blogpost" && s.blogPostTitleTaken(spaceKey, req.Title, c.Created, c.Id) {
+		writeError(w, http.StatusBadRequest, "A blog post with this title already exists on this day")
+		return
+	}
+	if len(req.Ancestors) > 0 {
+		parentId := req.Ancestors[len(req.Ancestors)-1].Id
+		if _, ok := s.contents[parentId]; !ok || parentId == c.Id {
+			writeError(w, http.StatusBadRequest, "invalid parent content "+parentId)
+			return
+		}
+		if parentId != c.ParentId {
+			c.Position = s.appendPosition(parentId)
+		}
+		c.ParentId = parentId
+	}
+	c.SpaceKey = spaceKey
+	c.Title = req.Title
+	c.Type = req.Type
+	c.Status = "current"
+	if req.Body.Storage.Value != "" || c.Type != "attachment" {
+		c.Body = req.Body.Storage.Value
+	}
+	c.Version = req.Version.Number
+	s.recordVersion(c, req.Version.Message)
+	writeJSON(w, http.StatusOK, s.contentJSON(c))
+}
+
+// trashContent moves the content to the trash, like confluence the child
+// pages are moved to the parent of the trashed page.
+func (s *Server) trashContent(c *content) {
+	c.Status = "trashed"
+	for _, child := range s.children(c.Id) {
+		child.Position = s.appendPosition(c.ParentId)
+		child.ParentId = c.ParentId
+	}
+}
+
+// purgeContent removes the content with its attachments, comments, labels,
+// properties and restrictions. Children are moved to the purged content parent.
+func (s *Server) purgeContent(id string) {
+	parentId := s.contents[id].ParentId
+	for _, c := range s.contents {
+		if c.ContainerId == id {
+			s.purgeContent(c.Id)
+		}
+		if c.ParentId == id {
+			c.ParentId = parentId
+		}
+	}
+	delete(s.contents, id)
+	delete(s.labels, id)
+	delete(s.attachments, id)
+	delete(s.properties, id)
+	delete(s.restrictions, id)
+	delete(s.versions, id)
+}
+
+func (s *Server) archiveContents(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Pages []struct {
+			Id string `json:"id"`
+		} `json:"pages"`
+	}
+	if err := decode(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	for _, p := range req.Pages {
+		c, ok := s.contents[p.Id]
+		if !ok || c.Status != "current" {
+			writeError(w, http.StatusBadRequest, "page "+p.Id+" is not current")
+			return
+		}
+	}
+	for _, p := range req.Pages {
+		s.contents[p.Id].Status = "archived"
+	}
+	s.newLongTask(w, "archive")
+}
+
+func (s *Server) listChildren(w http.ResponseWriter, r *http.Request, id, childType string) {
+	if _, ok := s.contents[id]; !ok {
+		writeError(w, http.StatusNotFound, "no content with id "+id)
+		return
+	}
+	results := []map[string]any{}
+	if childType == "page" {
+		for _, c := range s.children(id) {
+			results = append(results, s.contentJSON(c))
+		}
+	}
+	for _, key := range sortedKeys(s.contents) {
+		c := s.contents[key]
+		if c.Status == "current" && childType == "comment" && c.ContainerId == id && c.Type == "comment" {
+			results = append(results, s.contentJSON(c))
+		}
+	}
+	page(w, r, results)
+}
+
+func (s *Server) serveLabels(w http.ResponseWriter, r *http.Request, id string, segments []string) {
+	if c, ok := s.contents[id]; !ok || c.Status == "trashed" {
+		writeError(w, http.StatusNotFound, "no content with id "+id)
+		return
+	}
+	switch {
+	case r.Method == http.MethodGet && len(segments) == 0:
+		page(w, r, s.labels[id])
+	case r.Method == http.MethodPost && len(segments) == 0:
+		var req []label
+		if err := decode(r, &req); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		for _, l := range req {
+			if l.Name == "" || strings.ContainsAny(l.Name, " :") {
+				writeError(w, http.StatusBadRequest, "invalid label name "+l.Name)
+				return
+			}
+			if !hasLabel(s.labels[id], l.Name) {
+				s.labels[id] = append(s.labels[id], label{Id: s.newId(), Prefix: l.Prefix, Name: l.Name})
+			}
+		}
+		page(w, r, s.labels[id])
+	case r.Method == http.MethodDelete:
+		name := r.URL.Query().Get("name")
+		if len(segments) == 1 {
+			name = segments[0]
+		}
+		if !hasLabel(s.labels[id], name) {
+			writeError(w, http.StatusNotFound, "no label "+name)
+			return
+		}
+		labels := []label{}
+		for _, l := range s.labels[id] {
+			if l.Name != name {
+				labels = append(labels, l)
+			}
+		}
+		s.labels[id] = labels
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func hasLabel(labels []label, name string) bool {
+	for _, l := range labels {
+		if l.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) serveRestrictions(w http.ResponseWriter, r *http.Request, id string) {
+	if _, ok := s.contents[id]; !ok {
+		writeError(w, http.StatusNotFound, "no content with id "+id)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		restrictions := s.restrictions[id]
+		if restrictions == nil {
+			restrictions = json.RawMessage(`[]`)
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"results": restrictions})
+	case http.MethodPut, http.MethodPost:
+		b, err := io.ReadAll(r.Body)
+		if err != nil || !json.Valid(b) {
+			writeError(w, http.StatusBadRequest, "invalid restrictions")
+			return
+		}
+		s.restrictions[id] = b
+		writeJSON(w, http.StatusOK, map[string]any{"results": s.restrictions[id]})
+	case http.MethodDelete:
+		delete(s.restrictions, id)
+		writeJSON(w, http.StatusOK, map[string]any{"results": []any{}})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// contentJSON renders the content with body, version, space and ancestors
+// expanded, the fake always expands them.
+func (s *Server) contentJSON(c *content) map[string]any {
+	ancestors := []map[string]any{}
+	for parentId := c.ParentId; parentId != ""; {
+		parent, ok := s.contents[parentId]
+		if !ok {
+			break
+		}
+		ancestors = append([]map[string]any{{"id": parent.Id, "type": parent.Type, "title": parent.Title}}, ancestors...)
+		parentId = parent.ParentId
+	}
+	body := map[string]any{
+		"id":        c.Id,
+		"type":      c.Type,
+		"status":    c.Status,
+		"title":     c.Title,
+		"ancestors": ancestors,
+		"body": map[string]any{
+			"storage": map[string]string{"value": c.Body, "representation": "storage"},
+		},
+		"version": map[string]int{"number": c.Version},
+	}
+	if sp, ok := s.space(c.SpaceKey); ok {
+		body["space"] = s.spaceJSON(sp)
+	}
+	if c.Type == "blogpost" {
+		body["history"] = map[string]any{"createdDate": c.Created}
+	}
+	if c.ContainerId != "" {
+		if container, ok := s.contents[c.ContainerId]; ok {
+			body["container"] = map[string]any{"id": container.Id, "type": container.Type, "title": container.Title}
+		}
+	}
+	if c.Type == "page" {
+		body["extensions"] = map[string]any{"position": c.Position}
+	}
+	if c.Type == "comment" {
+		extensions := map[string]any{"location": c.Location}
+		if c.Location == "inline" {
+			extensions["inlineProperties"] = map[string]any{"originalSelection": c.Selection, "markerRef": "marker-" + c.Id}
+		}
+		body["extensions"] = extensions
+	}
+	if c.Type == "attachment" {
+		body["extensions"] = map[string]any{"mediaType": c.MediaType, "fileSize": len(s.attachments[c.Id])}
+		body["_links"] = map[string]string{
+			"download": fmt.Sprintf("/download/attachments/%s/%s", c.ContainerId, c.Title),
+		}
+	}
+	return body
+}
@@ -0,0 +1,137 @@
+package confluencefake
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+)
+
+// exportPath is the path space export archives are downloaded from.
+const exportPath = "/wiki/download/temp/"
+
+// baseURL is the server base URL the fake is configured with, confluence
+// builds absolute links with it, whatever host the request was sent to.
+const baseURL = "https://confluence.example.com"
+
+// ExportSpacePath is the path of the exportSpace method of the JSON-RPC
+// remote API of confluence Data Center.
+const ExportSpacePath = "/wiki/rpc/json-rpc/confluenceservice-v2/exportSpace"
+
+// exportSpace serves the exportSpace JSON-RPC method, its parameters are the
+// space key and the export type. It builds the archive of a space and
+// answers with the URL of the archive. XML and HTML exports are zip archives
+// with an entry per current page or blog post, PDF exports are a text
+// document listing their titles.
+func (s *Server) exportSpace(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	var params []string
+	if err := decode(r, &params); err != nil || len(params) != 2 {
+		writeRPCError(w, fmt.Sprintf("exportSpace wants the space key and the export type, but got %v", params))
+		return
+	}
+	key, exportType := params[0], params[1]
+	sp, ok := s.space(key)
+	if !ok {
+		writeRPCError(w, "no space with key "+key)
+		return
+	}
+	contents := []*content{}
+	for _, id := range sortedKeys(s.contents) {
+		c := s.contents[id]
+		if c.SpaceKey == sp.Key && c.Status == "current" && (c.Type == "page" || c.Type == "blogpost") {
+			contents = append(contents, c)
+		}
+	}
+
+	var archive []byte
+	var err error
+	extension := strings.ToLower(strings.TrimPrefix(exportType, "TYPE_")) + ".zip"
+	switch exportType {
+	case "TYPE_XML":
+		archive, err = zipArchive(contents, "entities.xml", func(c *content) string {
+			return fmt.Sprintf("<object class=%q id=%q><title>%s</title><body>%s</body></object>\n",
+				c.Type, c.Id, html.EscapeString(c.Title), html.EscapeString(c.Body))
+		})
+	case "TYPE_HTML":
+		archive, err = zipArchive(contents, "", func(c *content) string {
+			return fmt.Sprintf("<html><head><title>%s</title></head><body>%s</body></html>\n", html.EscapeString(c.Title), c.Body)
+		})
+	case "TYPE_PDF":
+		extension = "pdf"
+		var b strings.Builder
+		b.WriteString("%PDF-1.4\n% " + sp.Name + "\n")
+		for _, c := range contents {
+			b.WriteString("% " + c.Title + "\n")
+		}
+		b.WriteString("%%EOF\n")
+		archive = []byte(b.String())
+	default:
+		writeRPCError(w, "unknown export type "+exportType)
+		return
+	}
+	if err != nil {
+		writeRPCError(w, err.Error())
+		return
+	}
+	name := fmt.Sprintf("Confluence-space-export-%s-%s.%s", sp.Key, s.newId(), extension)
+	s.exports[name] = archive
+	writeJSON(w, http.StatusOK, baseURL+exportPath+name)
+}
+
+// writeRPCError answers a JSON-RPC error, the remote API reports the
+// exceptions of its methods with an internal server error.
+func writeRPCError(w http.ResponseWriter, message string) {
+	writeJSON(w, http.StatusInternalServerError, map[string]any{
+		"error": map[string]any{"code": 500, "message": message},
+	})
+}
+
+// zipArchive writes an entry per content, named after its id, or a single
+// entry named entry with every content.
+func zipArchive(contents []*content, entry string, render func(c *content) string) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	if entry != "" {
+		f, err := zw.Create(entry)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range contents {
+			if _, err := f.Write([]byte(render(c))); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		for _, c := range contents {
+			f, err := zw.Create(c.Id + ".html")
+			if err != nil {
+				return nil, err
+			}
+			if _, err := f.Write([]byte(render(c))); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// downloadExport serves /wiki/download/temp/{name}.
+func (s *Server) downloadExport(w http.ResponseWriter, r *http.Request) {
+	archive, ok := s.exports[strings.TrimPrefix(r.URL.Path, exportPath)]
+	if !ok {
+		writeError(w, http.StatusNotFound, "no export archive "+r.URL.Path)
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.WriteHeader(http.StatusOK)
+	w.Write(archive)
+}
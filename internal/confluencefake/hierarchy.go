@@ -0,0 +1,232 @@
+package confluencefake
+
+import (
+	"net/http"
+	"sort"
+)
+
+// moveContent moves a page and its descendants next to or under the
+// target page, the move completes synchronously.
+func (s *Server) moveContent(w http.ResponseWriter, id, position, targetId string) {
+	c, ok := s.contents[id]
+	if !ok || c.Status != "current" || c.Type != "page" {
+		writeError(w, http.StatusNotFound, "no page with id "+id)
+		return
+	}
+	target, ok := s.contents[targetId]
+	if !ok || target.Status != "current" || target.Type != "page" {
+		writeError(w, http.StatusNotFound, "no page with id "+targetId)
+		return
+	}
+	parentId := target.ParentId
+	switch position {
+	case "append":
+		parentId = target.Id
+	case "before", "after":
+	default:
+		writeError(w, http.StatusBadRequest, "invalid position "+position)
+		return
+	}
+	if targetId == id || s.isDescendant(targetId, id) {
+		writeError(w, http.StatusBadRequest, "a page can not be moved under itself")
+		return
+	}
+	if target.SpaceKey != c.SpaceKey {
+		for _, d := range append(s.descendants(id), c) {
+			if s.titleTaken(target.SpaceKey, d.Title, d.Id) {
+				writeError(w, http.StatusBadRequest, "A page with this title already exists in this space: "+d.Title)
+				return
+			}
+		}
+		for _, d := range append(s.descendants(id), c) {
+			d.SpaceKey = target.SpaceKey
+		}
+	}
+	if position == "append" {
+		c.Position = s.appendPosition(parentId)
+		c.ParentId = parentId
+	} else {
+		c.ParentId = parentId
+		s.placeNextTo(c, target, position)
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"pageId": id})
+}
+
+// placeNextTo renumbers the siblings of the target with the page placed
+// before or after it.
+func (s *Server) placeNextTo(c, target *content, position string) {
+	siblings := []*content{}
+	for _, sibling := range s.children(target.ParentId) {
+		switch {
+		case sibling == c:
+		case sibling == target && position == "before":
+			siblings = append(siblings, c, target)
+		case sibling == target:
+			siblings = append(siblings, target, c)
+		default:
+			siblings = append(siblings, sibling)
+		}
+	}
+	for i, sibling := range siblings {
+		sibling.Position = i
+	}
+}
+
+// children returns the current child pages of a page in their sidebar order.
+func (s *Server) children(id string) []*content {
+	pages := []*content{}
+	for _, key := range sortedKeys(s.contents) {
+		c := s.contents[key]
+		if c.ParentId == id && c.Type == "page" && c.Status == "current" {
+			pages = append(pages, c)
+		}
+	}
+	sort.SliceStable(pages, func(i, j int) bool { return pages[i].Position < pages[j].Position })
+	return pages
+}
+
+// appendPosition returns the position after the last child page of a page.
+func (s *Server) appendPosition(id string) int {
+	position := 0
+	for _, c := range s.contents {
+		if c.ParentId == id && c.Type == "page" && c.Position >= position {
+			position = c.Position + 1
+		}
+	}
+	return position
+}
+
+// isDescendant reports whether the content is a descendant of the page.
+func (s *Server) isDescendant(id, pageId string) bool {
+	for c, ok := s.contents[id]; ok && c.ParentId != ""; c, ok = s.contents[c.ParentId] {
+		if c.ParentId == pageId {
+			return true
+		}
+	}
+	return false
+}
+
+// descendants returns the current descendant pages of a page, parents first.
+func (s *Server) descendants(id string) []*content {
+	pages := []*content{}
+	for _, c := range s.children(id) {
+		pages = append(pages, c)
+		pages = append(pages, s.descendants(c.Id)...)
+	}
+	return pages
+}
+
+func (s *Server) copyContent(w http.ResponseWriter, r *http.Request, id string) {
+	var req copyRequest
+	if err := decode(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	src, ok := s.contents[id]
+	if !ok || src.Status != "current" || src.Type != "page" {
+		writeError(w, http.StatusNotFound, "no page with id "+id)
+		return
+	}
+	if req.Destination.Type != "parent_page" {
+		writeError(w, http.StatusBadRequest, "unsupported destination type "+req.Destination.Type)
+		return
+	}
+	parent, ok := s.contents[req.Destination.Value]
+	if !ok || parent.Status != "current" {
+		writeError(w, http.StatusNotFound, "no destination page with id "+req.Destination.Value)
+		return
+	}
+	title := src.Title
+	if req.PageTitle != "" {
+		title = req.PageTitle
+	}
+	if s.titleTaken(parent.SpaceKey, title, "") {
+		writeError(w, http.StatusBadRequest, "A page with this title already exists in this space: "+title)
+		return
+	}
+	c := s.copyPage(src, parent, title, req.copyOptions)
+	writeJSON(w, http.StatusOK, s.contentJSON(c))
+}
+
+func (s *Server) copyPageHierarchy(w http.ResponseWriter, r *http.Request, id string) {
+	var req copyPageHierarchyRequest
+	if err := decode(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	src, ok := s.contents[id]
+	if !ok || src.Status != "current" || src.Type != "page" {
+		writeError(w, http.StatusNotFound, "no page with id "+id)
+		return
+	}
+	parent, ok := s.contents[req.DestinationPageId]
+	if !ok || parent.Status != "current" {
+		writeError(w, http.StatusNotFound, "no destination page with id "+req.DestinationPageId)
+		return
+	}
+	if req.DestinationPageId == id || s.isDescendant(req.DestinationPageId, id) {
+		writeError(w, http.StatusBadRequest, "a page can not be copied under itself")
+		return
+	}
+	for _, c := range append([]*content{src}, s.descendants(id)...) {
+		if s.titleTaken(parent.SpaceKey, req.TitleOptions.Prefix+c.Title, "") {
+			writeError(w, http.StatusBadRequest, "A page with this title already exists in this space: "+req.TitleOptions.Prefix+c.Title)
+			return
+		}
+	}
+	var copyTree func(src, parent *content)
+	copyTree = func(src, parent *content) {
+		children := s.descendants(src.Id)
+		c := s.copyPage(src, parent, req.TitleOptions.Prefix+src.Title, req.copyOptions)
+		for _, child := range children {
+			if child.ParentId == src.Id {
+				copyTree(child, c)
+			}
+		}
+	}
+	copyTree(src, parent)
+	s.newLongTask(w, "copy page hierarchy")
+}
+
+// copyPage adds a copy of the page under the parent with the parts of the
+// options.
+func (s *Server) copyPage(src, parent *content, title string, options copyOptions) *content {
+	c := &content{
+		Id:       s.newId(),
+		Type:     "page",
+		Title:    title,
+		Status:   "current",
+		SpaceKey: parent.SpaceKey,
+		ParentId: parent.Id,
+		Position: s.appendPosition(parent.Id),
+		Body:     src.Body,
+		Version:  1,
+	}
+	s.contents[c.Id] = c
+	s.recordVersion(c, "")
+	if options.CopyLabels && len(s.labels[src.Id]) > 0 {
+		s.labels[c.Id] = append([]label{}, s.labels[src.Id]...)
+	}
+	if options.CopyPermissions && s.restrictions[src.Id] != nil {
+		s.restrictions[c.Id] = s.restrictions[src.Id]
+	}
+	if options.CopyAttachments {
+		attachments := []*content{}
+		for _, a := range s.contents {
+			if a.Type == "attachment" && a.ContainerId == src.Id && a.Status == "current" {
+				attachments = append(attachments, a)
+			}
+		}
+		sort.Slice(attachments, func(i, j int) bool { return attachments[i].Title < attachments[j].Title })
+		for _, a := range attachments {
+			copied := *a
+			copied.Id = s.newId()
+			copied.ContainerId = c.Id
+			copied.SpaceKey = c.SpaceKey
+			copied.Version = 1
+			s.contents[copied.Id] = &copied
+			s.attachments[copied.Id] = s.attachments[a.Id]
+		}
+	}
+	return c
+}
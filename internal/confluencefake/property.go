@@ -0,0 +1,111 @@
+package confluencefake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// contentProperties returns the properties of a content, nil when the
+// content does not exist.
+func (s *Server) contentProperties(id string) map[string]*property {
+	if c, ok := s.contents[id]; !ok || c.Status == "trashed" {
+		return nil
+	}
+	if s.properties[id] == nil {
+		s.properties[id] = map[string]*property{}
+	}
+	return s.properties[id]
+}
+
+func (s *Server) spaceProperties(key string) map[string]*property {
+	if s.properties[spacePropertiesKey(key)] == nil {
+		s.properties[spacePropertiesKey(key)] = map[string]*property{}
+	}
+	return s.properties[spacePropertiesKey(key)]
+}
+
+// spacePropertiesKey keeps space properties apart from content properties,
+// content ids are numeric so they never collide.
+func spacePropertiesKey(key string) string {
+	return "space:" + key
+}
+
+// serveProperties implements the property endpoints shared by contents and
+// spaces, updates must increment the property version.
+func (s *Server) serveProperties(w http.ResponseWriter, r *http.Request, properties map[string]*property, segments []string) {
+	if properties == nil {
+		writeError(w, http.StatusNotFound, "no content found for the property")
+		return
+	}
+	if len(segments) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			results := []*property{}
+			for _, key := range sortedKeys(properties) {
+				results = append(results, properties[key])
+			}
+			page(w, r, results)
+		case http.MethodPost:
+			var req property
+			if err := decode(r, &req); err != nil {
+				writeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			if req.Key == "" || !json.Valid(req.Value) {
+				writeError(w, http.StatusBadRequest, "key and a JSON value are required")
+				return
+			}
+			if _, ok := properties[req.Key]; ok {
+				writeError(w, http.StatusConflict, "a property with key "+req.Key+" already exists")
+				return
+			}
+			p := &property{Id: s.newId(), Key: req.Key, Value: req.Value, Version: propertyVersion{Number: 1}}
+			properties[p.Key] = p
+			writeJSON(w, http.StatusOK, p)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+		return
+	}
+
+	key := segments[0]
+	p, ok := properties[key]
+	switch r.Method {
+	case http.MethodGet:
+		if !ok {
+			writeError(w, http.StatusNotFound, "no property with key "+key)
+			return
+		}
+		writeJSON(w, http.StatusOK, p)
+	case http.MethodPut:
+		var req property
+		if err := decode(r, &req); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if !json.Valid(req.Value) {
+			writeError(w, http.StatusBadRequest, "a JSON value is required")
+			return
+		}
+		if !ok {
+			p = &property{Id: s.newId(), Key: key}
+			properties[key] = p
+		} else if req.Version.Number != p.Version.Number+1 {
+			writeError(w, http.StatusConflict, fmt.Sprintf("Version must be incremented on update. Current version is: %d", p.Version.Number))
+			return
+		}
+		p.Value = req.Value
+		p.Version.Number++
+		writeJSON(w, http.StatusOK, p)
+	case http.MethodDelete:
+		if !ok {
+			writeError(w, http.StatusNotFound, "no property with key "+key)
+			return
+		}
+		delete(properties, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
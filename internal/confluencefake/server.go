@@ -0,0 +1,361 @@
+// Package confluencefake implements an in-memory confluence REST API server
+// for tests. It keeps spaces, contents, labels, attachments, properties,
+// restrictions, templates, watchers and space exports in memory, follows the confluence
+// versioning, 404 and 409 semantics and can inject faults on any request.
+package confluencefake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// APIPath is the path the REST API is served from, the same as confluence cloud.
+const APIPath = "/wiki/rest/api"
+
+// xsrfNoCheck is the X-Atlassian-Token header value that opts out of the
+// XSRF check on uploads and watch changes.
+const xsrfNoCheck = "no-check"
+
+// Server is a stateful fake confluence server, it is safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	mu           sync.Mutex
+	nextId       int
+	spaces       map[string]*space
+	contents     map[string]*content
+	labels       map[string][]label
+	attachments  map[string][]byte
+	properties   map[string]map[string]*property
+	restrictions map[string]json.RawMessage
+	longTasks    map[string]*longTask
+	users        map[string]*user
+	groups       map[string]*group
+	groupIds     int
+	templates    map[string]*template
+	templateIds  int
+	faults       []*Fault
+	requests     []string
+
+	// watchers are the account ids watching a content/{id} or a space/{key}.
+	watchers map[string][]string
+	// versions are the history of the contents by id, oldest first.
+	versions map[string][]*version
+	// exports are the space export archives by file name.
+	exports map[string][]byte
+}
+
+// Fault makes the server answer with Status and Body instead of serving the
+// request, for requests matching Method and Path.
+type Fault struct {
+	// Method matches the request method, empty matches every method.
+	Method string
+	// Path matches the request path prefix relative to APIPath, e.g: /content/1001,
+	// empty matches every path.
+	Path   string
+	Status int
+	Body   string
+	// Times is the number of requests the fault applies to, 0 applies it forever.
+	Times int
+}
+
+// NewServer starts a fake confluence server, it must be closed by the caller.
+func NewServer() *Server {
+	s := &Server{
+		nextId:       1000,
+		spaces:       map[string]*space{},
+		contents:     map[string]*content{},
+		labels:       map[string][]label{},
+		attachments:  map[string][]byte{},
+		properties:   map[string]map[string]*property{},
+		restrictions: map[string]json.RawMessage{},
+		longTasks:    map[string]*longTask{},
+		users:        map[string]*user{},
+		groups:       map[string]*group{},
+		watchers:     map[string][]string{},
+		versions:     map[string][]*version{},
+		exports:      map[string][]byte{},
+		templates:    map[string]*template{},
+	}
+	s.users[CurrentAccountId] = &user{
+		AccountId:   CurrentAccountId,
+		Username:    "terraform",
+		DisplayName: "Terraform",
+		Email:       "user@example.com",
+	}
+	s.addBlueprints()
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// SiteURL returns the URL of the site, the REST API is served under the
+// /wiki context path like confluence cloud.
+func (s *Server) SiteURL() string {
+	return s.URL + strings.TrimSuffix(APIPath, "/rest/api")
+}
+
+// AddFault registers a fault, faults are matched in the order they were added.
+func (s *Server) AddFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+// Requests returns every request served, as "METHOD /path?query" relative to APIPath.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.requests...)
+}
+
+// AddSpace creates a space with its homepage and returns the space id.
+func (s *Server) AddSpace(key, name string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addSpace(key, name, "").Id
+}
+
+// SetSpaceLabels replaces the labels of a space, they filter the space listing.
+func (s *Server) SetSpaceLabels(key string, labels ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if sp, ok := s.space(key); ok {
+		sp.Labels = labels
+	}
+}
+
+// SetSpaceFavourite marks a space as a favourite of the calling user.
+func (s *Server) SetSpaceFavourite(key string, favourite bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if sp, ok := s.space(key); ok {
+		sp.Favourite = favourite
+	}
+}
+
+// AddContent stores a current content and returns its id, parentId may be empty.
+func (s *Server) AddContent(spaceKey, contentType, title, body, parentId string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if sp, ok := s.space(spaceKey); ok {
+		spaceKey = sp.Key
+	}
+	c := &content{
+		Id:       s.newId(),
+		Type:     contentType,
+		Title:    title,
+		Status:   "current",
+		SpaceKey: spaceKey,
+		ParentId: parentId,
+		Position: s.appendPosition(parentId),
+		Body:     body,
+		Version:  1,
+		Created:  time.Now().UTC().Format(createdLayout),
+	}
+	s.contents[c.Id] = c
+	s.recordVersion(c, "")
+	return c.Id
+}
+
+// Content returns a copy of the stored content as it would be served, the
+// second value reports whether the content exists in any status.
+func (s *Server) Content(id string) (map[string]any, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.contents[id]
+	if !ok {
+		return nil, false
+	}
+	return s.contentJSON(c), true
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rel := strings.TrimPrefix(r.URL.Path, APIPath)
+	uri := r.Method + " " + rel
+	if r.URL.RawQuery != "" {
+		uri += "?" + r.URL.RawQuery
+	}
+	s.requests = append(s.requests, uri)
+
+	if f := s.matchFault(r.Method, rel); f != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(f.Status)
+		w.Write([]byte(f.Body))
+		return
+	}
+
+	if strings.HasPrefix(r.URL.Path, "/wiki/download/attachments/") {
+		s.downloadAttachment(w, r)
+		return
+	}
+	if strings.HasPrefix(r.URL.Path, exportPath) {
+		s.downloadExport(w, r)
+		return
+	}
+	if r.URL.Path == ExportSpacePath {
+		s.exportSpace(w, r)
+		return
+	}
+	if !strings.HasPrefix(r.URL.Path, APIPath) {
+		writeError(w, http.StatusNotFound, "unknown path "+r.URL.Path)
+		return
+	}
+	segments := strings.Split(strings.Trim(rel, "/"), "/")
+	for i := range segments {
+		segments[i], _ = url.PathUnescape(segments[i])
+	}
+
+	switch segments[0] {
+	case "content":
+		s.serveContent(w, r, segments[1:])
+	case "space":
+		s.serveSpace(w, r, segments[1:])
+	case "longtask":
+		s.serveLongTask(w, r, segments[1:])
+	case "user":
+		s.serveUser(w, r, segments[1:])
+	case "search":
+		s.serveSearch(w, r, segments[1:])
+	case "group":
+		s.serveGroup(w, r, segments[1:])
+	case "admin":
+		s.serveAdmin(w, r, segments[1:])
+	case "template":
+		s.serveTemplate(w, r, segments[1:])
+	default:
+		writeError(w, http.StatusNotFound, "unknown path "+r.URL.Path)
+	}
+}
+
+func (s *Server) matchFault(method, path string) *Fault {
+	for i, f := range s.faults {
+		if f.Method != "" && f.Method != method {
+			continue
+		}
+		if f.Path != "" && !strings.HasPrefix(path, f.Path) {
+			continue
+		}
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+func (s *Server) newId() string {
+	s.nextId++
+	return strconv.Itoa(s.nextId)
+}
+
+// newLongTask registers a task that is already finished, every long running
+// operation of the fake completes synchronously.
+func (s *Server) newLongTask(w http.ResponseWriter, name string) {
+	task := &longTask{
+		Id:                 s.newId(),
+		Name:               map[string]string{"key": name},
+		PercentageComplete: 100,
+		Successful:         true,
+		Finished:           true,
+		Messages:           []map[string]any{{"translation": name + " finished"}},
+	}
+	s.longTasks[task.Id] = task
+	writeJSON(w, http.StatusAccepted, map[string]any{
+		"id":    task.Id,
+		"links": map[string]string{"status": "/rest/api/longtask/" + task.Id},
+	})
+}
+
+func (s *Server) serveLongTask(w http.ResponseWriter, r *http.Request, segments []string) {
+	if len(segments) != 1 || r.Method != http.MethodGet {
+		writeError(w, http.StatusNotFound, "unknown long task path")
+		return
+	}
+	task, ok := s.longTasks[segments[0]]
+	if !ok {
+		writeError(w, http.StatusNotFound, "no long task with id "+segments[0])
+		return
+	}
+	writeJSON(w, http.StatusOK, task)
+}
+
+// page slices results with the start and limit query parameters and writes
+// them as a confluence paginated response.
+func page[T any](w http.ResponseWriter, r *http.Request, results []T) {
+	start, _ := strconv.Atoi(r.URL.Query().Get("start"))
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 25
+	}
+	if start > len(results) {
+		start = len(results)
+	}
+	end := start + limit
+	if end > len(results) {
+		end = len(results)
+	}
+	body := map[string]any{
+		"results": results[start:end],
+		"start":   start,
+		"limit":   limit,
+		"size":    end - start,
+		"_links":  map[string]string{},
+	}
+	if end < len(results) {
+		q := r.URL.Query()
+		q.Set("start", strconv.Itoa(end))
+		body["_links"] = map[string]string{"next": r.URL.Path + "?" + q.Encode()}
+	}
+	writeJSON(w, http.StatusOK, body)
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, errA := strconv.Atoi(keys[i])
+		b, errB := strconv.Atoi(keys[j])
+		if errA == nil && errB == nil {
+			return a < b
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+func decode(r *http.Request, v any) error {
+	err := json.NewDecoder(r.Body).Decode(v)
+	if err != nil {
+		return fmt.Errorf("invalid request body: %w", err)
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]any{
+		"statusCode": status,
+		"message":    message,
+	})
+}
@@ -0,0 +1,137 @@
+package confluencefake
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestServerContentVersioning(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.AddSpace("DEVOPS", "devops")
+
+	page := request(t, s, http.MethodPost, "/content", `{"type":"page","title":"Runbook","space":{"key":"DEVOPS"},"body":{"storage":{"value":"<p>v1</p>"}}}`, http.StatusOK)
+	id := page["id"].(string)
+
+	testCases := []struct {
+		desc   string
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{
+			desc:   "Duplicated title",
+			method: http.MethodPost,
+			path:   "/content",
+			body:   `{"type":"page","title":"Runbook","space":{"key":"DEVOPS"}}`,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "Stale version",
+			method: http.MethodPut,
+			path:   "/content/" + id,
+			body:   `{"type":"page","title":"Runbook","version":{"number":1}}`,
+			status: http.StatusConflict,
+		},
+		{
+			desc:   "Next version",
+			method: http.MethodPut,
+			path:   "/content/" + id,
+			body:   `{"type":"page","title":"Runbook","version":{"number":2},"body":{"storage":{"value":"<p>v2</p>"}}}`,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "Trash",
+			method: http.MethodDelete,
+			path:   "/content/" + id,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "Trashed content is not found",
+			method: http.MethodGet,
+			path:   "/content/" + id,
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "Purge",
+			method: http.MethodDelete,
+			path:   "/content/" + id + "?status=trashed",
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "Purged content is not found",
+			method: http.MethodGet,
+			path:   "/content/" + id + "?status=trashed",
+			status: http.StatusNotFound,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			request(t, s, tC.method, tC.path, tC.body, tC.status)
+		})
+	}
+}
+
+func TestServerFault(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.AddSpace("DEVOPS", "devops")
+	s.AddFault(Fault{Method: http.MethodGet, Path: "/space/DEVOPS", Status: http.StatusTooManyRequests, Times: 1})
+
+	request(t, s, http.MethodGet, "/space/DEVOPS", "", http.StatusTooManyRequests)
+	request(t, s, http.MethodGet, "/space/DEVOPS", "", http.StatusOK)
+
+	if got := len(s.Requests()); got != 2 {
+		t.Errorf("wants 2 requests, but got %v", got)
+	}
+}
+
+func TestServerProperties(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.AddSpace("DEVOPS", "devops")
+	id := s.AddContent("DEVOPS", "page", "Runbook", "", "")
+
+	request(t, s, http.MethodPost, "/content/"+id+"/property", `{"key":"owner","value":{"team":"sre"}}`, http.StatusOK)
+	request(t, s, http.MethodPost, "/content/"+id+"/property", `{"key":"owner","value":{"team":"sre"}}`, http.StatusConflict)
+	request(t, s, http.MethodPut, "/content/"+id+"/property/owner", `{"key":"owner","value":{"team":"dev"},"version":{"number":1}}`, http.StatusConflict)
+	property := request(t, s, http.MethodPut, "/content/"+id+"/property/owner", `{"key":"owner","value":{"team":"dev"},"version":{"number":2}}`, http.StatusOK)
+	if property["version"].(map[string]any)["number"].(float64) != 2 {
+		t.Errorf("wants property version 2, but got %v", property["version"])
+	}
+	request(t, s, http.MethodPost, "/space/DEVOPS/property", `{"key":"cost-centre","value":"1234"}`, http.StatusOK)
+}
+
+func TestServerWatchXSRF(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.AddSpace("DEVOPS", "devops")
+	id := s.AddContent("DEVOPS", "page", "Runbook", "", "")
+
+	// Watch changes without the X-Atlassian-Token header are rejected.
+	request(t, s, http.MethodPost, "/user/watch/content/"+id+"?accountId="+CurrentAccountId, "", http.StatusForbidden)
+	request(t, s, http.MethodDelete, "/user/watch/space/DEVOPS?accountId="+CurrentAccountId, "", http.StatusForbidden)
+	request(t, s, http.MethodGet, "/user/watch/content/"+id+"?accountId="+CurrentAccountId, "", http.StatusOK)
+}
+
+func request(t *testing.T, s *Server, method, path, body string, status int) map[string]any {
+	t.Helper()
+	req, err := http.NewRequest(method, s.URL+APIPath+path, bytes.NewBufferString(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := s.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != status {
+		t.Fatalf("%s %s wants status %v, but got %v", method, path, status, resp.StatusCode)
+	}
+	result := map[string]any{}
+	json.NewDecoder(resp.Body).Decode(&result)
+	return result
+}
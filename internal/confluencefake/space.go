@@ -0,0 +1,180 @@
+package confluencefake
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+func (s *Server) serveSpace(w http.ResponseWriter, r *http.Request, segments []string) {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		s.listSpaces(w, r)
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		s.createSpace(w, r)
+	case len(segments) == 1:
+		s.serveSpaceByKey(w, r, segments[0])
+	case len(segments) == 2 && segments[1] == "watch" && r.Method == http.MethodGet:
+		s.listWatchers(w, r, "space", segments[0])
+	case len(segments) >= 2 && segments[1] == "property":
+		sp, ok := s.space(segments[0])
+		if !ok {
+			writeError(w, http.StatusNotFound, "no space with key "+segments[0])
+			return
+		}
+		s.serveProperties(w, r, s.spaceProperties(sp.Key), segments[2:])
+	default:
+		writeError(w, http.StatusNotFound, "unknown space path "+r.URL.Path)
+	}
+}
+
+func (s *Server) listSpaces(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	keys := map[string]bool{}
+	for _, key := range q["spaceKey"] {
+		keys[strings.ToUpper(key)] = true
+	}
+	results := []map[string]any{}
+	for _, key := range sortedKeys(s.spaces) {
+		sp := s.spaces[key]
+		if len(keys) > 0 && !keys[strings.ToUpper(sp.Key)] {
+			continue
+		}
+		if q.Get("type") != "" && sp.Type != q.Get("type") {
+			continue
+		}
+		if q.Get("status") != "" && sp.Status != q.Get("status") {
+			continue
+		}
+		if q.Get("favourite") == "true" && !sp.Favourite {
+			continue
+		}
+		if !hasLabels(sp.Labels, q["label"]) {
+			continue
+		}
+		results = append(results, s.spaceJSON(sp))
+	}
+	page(w, r, results)
+}
+
+func (s *Server) createSpace(w http.ResponseWriter, r *http.Request) {
+	var req spaceRequest
+	if err := decode(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if req.Key == "" || req.Name == "" {
+		writeError(w, http.StatusBadRequest, "key and name are required")
+		return
+	}
+	if _, ok := s.space(req.Key); ok {
+		writeError(w, http.StatusBadRequest, "A space already exists with key "+req.Key)
+		return
+	}
+	description := ""
+	if req.Description != nil {
+		description = req.Description.Plain.Value
+	}
+	writeJSON(w, http.StatusOK, s.spaceJSON(s.addSpace(req.Key, req.Name, description)))
+}
+
+func (s *Server) addSpace(key, name, description string) *space {
+	id, _ := strconv.Atoi(s.newId())
+	sp := &space{
+		Id:          id,
+		Key:         key,
+		Name:        name,
+		Type:        "global",
+		Status:      "current",
+		Description: description,
+	}
+	s.spaces[strings.ToUpper(key)] = sp
+	home := &content{
+		Id:       s.newId(),
+		Type:     "page",
+		Title:    name + " Home",
+		Status:   "current",
+		SpaceKey: key,
+		Version:  1,
+	}
+	s.contents[home.Id] = home
+	sp.HomepageId = home.Id
+	return sp
+}
+
+// hasLabels reports whether labels contains every wanted label.
+func hasLabels(labels, want []string) bool {
+	for _, w := range want {
+		found := false
+		for _, l := range labels {
+			found = found || l == w
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// space looks up a space by key, confluence space keys are case insensitive.
+func (s *Server) space(key string) (*space, bool) {
+	sp, ok := s.spaces[strings.ToUpper(key)]
+	return sp, ok
+}
+
+func (s *Server) serveSpaceByKey(w http.ResponseWriter, r *http.Request, key string) {
+	sp, ok := s.space(key)
+	if !ok {
+		writeError(w, http.StatusNotFound, "no space with key "+key)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, s.spaceJSON(sp))
+	case http.MethodPut:
+		var req spaceRequest
+		if err := decode(r, &req); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if req.Name != "" {
+			sp.Name = req.Name
+		}
+		if req.Description != nil {
+			sp.Description = req.Description.Plain.Value
+		}
+		writeJSON(w, http.StatusOK, s.spaceJSON(sp))
+	case http.MethodDelete:
+		for id, c := range s.contents {
+			if c.SpaceKey == sp.Key {
+				delete(s.contents, id)
+				delete(s.labels, id)
+				delete(s.attachments, id)
+				delete(s.properties, id)
+				delete(s.restrictions, id)
+			}
+		}
+		delete(s.properties, spacePropertiesKey(sp.Key))
+		delete(s.spaces, strings.ToUpper(sp.Key))
+		s.newLongTask(w, "delete space")
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (s *Server) spaceJSON(sp *space) map[string]any {
+	body := map[string]any{
+		"id":     sp.Id,
+		"key":    sp.Key,
+		"name":   sp.Name,
+		"type":   sp.Type,
+		"status": sp.Status,
+		"description": map[string]any{
+			"plain": map[string]string{"value": sp.Description, "representation": "plain"},
+		},
+	}
+	if home, ok := s.contents[sp.HomepageId]; ok {
+		body["homepage"] = map[string]any{"id": home.Id, "type": home.Type, "title": home.Title}
+	}
+	return body
+}
@@ -0,0 +1,192 @@
+package confluencefake
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// MeetingNotesBlueprint is the module key of the global blueprint template
+// every fake server starts with.
+const MeetingNotesBlueprint = "meeting-notes-page"
+
+// AddTemplate stores a page template and returns its id, an empty space key
+// stores a global template.
+func (s *Server) AddTemplate(spaceKey, name, body string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := &template{
+		Id:       s.newTemplateId(),
+		Name:     name,
+		SpaceKey: strings.ToUpper(spaceKey),
+		Body:     body,
+	}
+	s.templates[t.Id] = t
+	return t.Id
+}
+
+// addBlueprints stores the global blueprint templates of a new server.
+func (s *Server) addBlueprints() {
+	t := &template{
+		Id:          s.newTemplateId(),
+		Name:        "Meeting notes",
+		Description: "Plan meetings and share notes and action items with your team.",
+		Body:        `<h2>Date</h2><p><at:var at:name="date" /></p><h2>Attendees</h2><p><at:var at:name="attendees" /></p><h2>Action items</h2>`,
+		PluginKey:   "com.atlassian.confluence.plugins.confluence-business-blueprints",
+		ModuleKey:   MeetingNotesBlueprint,
+	}
+	s.templates[t.Id] = t
+}
+
+// newTemplateId returns a template id, they are not taken from the content
+// ids so templates do not change the ids of the contents.
+func (s *Server) newTemplateId() string {
+	s.templateIds++
+	return strconv.Itoa(98304 + s.templateIds)
+}
+
+func (s *Server) serveTemplate(w http.ResponseWriter, r *http.Request, segments []string) {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		s.saveTemplate(w, r, false)
+	case len(segments) == 0 && r.Method == http.MethodPut:
+		s.saveTemplate(w, r, true)
+	case len(segments) == 1 && (segments[0] == "page" || segments[0] == "blueprint") && r.Method == http.MethodGet:
+		s.listTemplates(w, r, segments[0] == "blueprint")
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		t, ok := s.templates[segments[0]]
+		if !ok {
+			writeError(w, http.StatusNotFound, "no template with id "+segments[0])
+			return
+		}
+		writeJSON(w, http.StatusOK, templateJSON(t, strings.Contains(r.URL.Query().Get("expand"), "body")))
+	case len(segments) == 1 && r.Method == http.MethodDelete:
+		t, ok := s.templates[segments[0]]
+		if !ok {
+			writeError(w, http.StatusNotFound, "no template with id "+segments[0])
+			return
+		}
+		if t.ModuleKey != "" {
+			writeError(w, http.StatusBadRequest, "blueprint templates can not be deleted")
+			return
+		}
+		delete(s.templates, t.Id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusNotFound, "unknown template path "+r.URL.Path)
+	}
+}
+
+// saveTemplate creates a template, or updates the template of the request.
+func (s *Server) saveTemplate(w http.ResponseWriter, r *http.Request, update bool) {
+	var req templateRequest
+	if err := decode(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if req.Name == "" || req.TemplateType != "page" {
+		writeError(w, http.StatusBadRequest, "templates require a name and the page template type")
+		return
+	}
+	t := &template{}
+	if update {
+		stored, ok := s.templates[req.TemplateId]
+		if !ok {
+			writeError(w, http.StatusNotFound, "no template with id "+req.TemplateId)
+			return
+		}
+		if stored.ModuleKey != "" {
+			writeError(w, http.StatusBadRequest, "blueprint templates can not be updated")
+			return
+		}
+		t = stored
+	}
+	spaceKey := ""
+	if req.Space != nil && req.Space.Key != "" {
+		sp, ok := s.space(req.Space.Key)
+		if !ok {
+			writeError(w, http.StatusNotFound, "no space with key "+req.Space.Key)
+			return
+		}
+		spaceKey = sp.Key
+	}
+	if update && spaceKey != t.SpaceKey {
+		writeError(w, http.StatusBadRequest, "the space of a template can not be changed")
+		return
+	}
+	for _, other := range s.templates {
+		if other.Id != t.Id && other.SpaceKey == spaceKey && other.ModuleKey == "" && other.Name == req.Name {
+			writeError(w, http.StatusBadRequest, "a template with the name "+req.Name+" already exists")
+			return
+		}
+	}
+	if !update {
+		t.Id = s.newTemplateId()
+	}
+	t.Name = req.Name
+	t.Description = req.Description
+	t.SpaceKey = spaceKey
+	t.Body = req.Body.Storage.Value
+	t.Labels = nil
+	for _, l := range req.Labels {
+		t.Labels = append(t.Labels, l.Name)
+	}
+	s.templates[t.Id] = t
+	writeJSON(w, http.StatusOK, templateJSON(t, true))
+}
+
+// listTemplates lists the page or blueprint templates of the space in the
+// spaceKey parameter, or the global ones. Global blueprints are listed for
+// every space.
+func (s *Server) listTemplates(w http.ResponseWriter, r *http.Request, blueprints bool) {
+	spaceKey := r.URL.Query().Get("spaceKey")
+	if spaceKey != "" {
+		sp, ok := s.space(spaceKey)
+		if !ok {
+			writeError(w, http.StatusNotFound, "no space with key "+spaceKey)
+			return
+		}
+		spaceKey = sp.Key
+	}
+	expandBody := strings.Contains(r.URL.Query().Get("expand"), "body")
+	results := []map[string]any{}
+	for _, id := range sortedKeys(s.templates) {
+		t := s.templates[id]
+		if (t.ModuleKey != "") != blueprints {
+			continue
+		}
+		if t.SpaceKey != spaceKey && !(blueprints && t.SpaceKey == "") {
+			continue
+		}
+		results = append(results, templateJSON(t, expandBody))
+	}
+	page(w, r, results)
+}
+
+func templateJSON(t *template, expandBody bool) map[string]any {
+	labels := []label{}
+	for _, name := range t.Labels {
+		labels = append(labels, label{Prefix: "global", Name: name})
+	}
+	body := map[string]any{
+		"templateId":    t.Id,
+		"name":          t.Name,
+		"description":   t.Description,
+		"templateType":  "page",
+		"editorVersion": "v2",
+		"labels":        labels,
+	}
+	if t.SpaceKey != "" {
+		body["space"] = map[string]any{"key": t.SpaceKey}
+	}
+	if t.ModuleKey != "" {
+		body["originalTemplate"] = map[string]string{"pluginKey": t.PluginKey, "moduleKey": t.ModuleKey}
+		body["referencingBlueprint"] = t.PluginKey + ":" + t.ModuleKey
+	}
+	if expandBody {
+		body["body"] = map[string]any{
+			"storage": map[string]string{"value": t.Body, "representation": "storage"},
+		}
+	}
+	return body
+}
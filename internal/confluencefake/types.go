@@ -0,0 +1,197 @@
+package confluencefake
+
+import "encoding/json"
+
+type space struct {
+	Id          int
+	Key         string
+	Name        string
+	Type        string
+	Status      string
+	Description string
+	HomepageId  string
+	Labels      []string
+	Favourite   bool
+}
+
+type content struct {
+	Id       string
+	Type     string
+	Title    string
+	Status   string
+	SpaceKey string
+	ParentId string
+	// Position orders the child pages of a parent in the sidebar.
+	Position    int
+	ContainerId string
+	Body        string
+	Version     int
+	MediaType   string
+	// Location and Selection are set on comments, the selection is the
+	// text of the container an inline comment is anchored to.
+	Location  string
+	Selection string
+	// Created is the creation date, e.g: 2023-05-01T00:00:00.000Z, the fake
+	// only returns it for blog posts.
+	Created string
+}
+
+type label struct {
+	Id     string `json:"id"`
+	Prefix string `json:"prefix"`
+	Name   string `json:"name"`
+}
+
+type property struct {
+	Id      string          `json:"id"`
+	Key     string          `json:"key"`
+	Value   json.RawMessage `json:"value"`
+	Version propertyVersion `json:"version"`
+}
+
+type propertyVersion struct {
+	Number int `json:"number"`
+}
+
+// version is a version of a content in its history.
+type version struct {
+	Number  int
+	Title   string
+	Body    string
+	When    string
+	Message string
+}
+
+type versionRestoreRequest struct {
+	OperationKey string `json:"operationKey"`
+	Params       struct {
+		VersionNumber int    `json:"versionNumber"`
+		Message       string `json:"message"`
+		RestoreTitle  bool   `json:"restoreTitle"`
+	} `json:"params"`
+}
+
+type user struct {
+	AccountId   string
+	Username    string
+	DisplayName string
+	Email       string
+}
+
+type group struct {
+	Id      string
+	Name    string
+	Members []string
+}
+
+// template is a page template, blueprint templates have a plugin and a
+// module key.
+type template struct {
+	Id          string
+	Name        string
+	Description string
+	SpaceKey    string
+	Body        string
+	Labels      []string
+	PluginKey   string
+	ModuleKey   string
+}
+
+type longTask struct {
+	Id                 string            `json:"id"`
+	Name               map[string]string `json:"name"`
+	PercentageComplete int               `json:"percentageComplete"`
+	Successful         bool              `json:"successful"`
+	Finished           bool              `json:"finished"`
+	Messages           []map[string]any  `json:"messages"`
+}
+
+// copyOptions are the parts of a page copied with it.
+type copyOptions struct {
+	CopyAttachments bool `json:"copyAttachments"`
+	CopyPermissions bool `json:"copyPermissions"`
+	CopyLabels      bool `json:"copyLabels"`
+}
+
+// copyRequest is the body accepted when a single page is copied.
+type copyRequest struct {
+	copyOptions
+	Destination struct {
+		Type  string `json:"type"`
+		Value string `json:"value"`
+	} `json:"destination"`
+	PageTitle string `json:"pageTitle"`
+}
+
+// copyPageHierarchyRequest is the body accepted when a page is copied with
+// its descendants.
+type copyPageHierarchyRequest struct {
+	copyOptions
+	DestinationPageId string `json:"destinationPageId"`
+	TitleOptions      struct {
+		Prefix string `json:"prefix"`
+	} `json:"titleOptions"`
+}
+
+// contentRequest is the body accepted when contents are created or updated.
+type contentRequest struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status string `json:"status"`
+	Space  *struct {
+		Key string `json:"key"`
+	} `json:"space"`
+	Ancestors []struct {
+		Id string `json:"id"`
+	} `json:"ancestors"`
+	Container *struct {
+		Id string `json:"id"`
+	} `json:"container"`
+	Body struct {
+		Storage struct {
+			Value string `json:"value"`
+		} `json:"storage"`
+	} `json:"body"`
+	Version *struct {
+		Number  int    `json:"number"`
+		Message string `json:"message"`
+	} `json:"version"`
+	History *struct {
+		CreatedDate string `json:"createdDate"`
+	} `json:"history"`
+	Extensions *struct {
+		Location         string `json:"location"`
+		InlineProperties *struct {
+			OriginalSelection string `json:"originalSelection"`
+		} `json:"inlineProperties"`
+	} `json:"extensions"`
+}
+
+type spaceRequest struct {
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description *struct {
+		Plain struct {
+			Value string `json:"value"`
+		} `json:"plain"`
+	} `json:"description"`
+}
+
+type templateRequest struct {
+	TemplateId   string `json:"templateId"`
+	Name         string `json:"name"`
+	TemplateType string `json:"templateType"`
+	Description  string `json:"description"`
+	Body         struct {
+		Storage struct {
+			Value string `json:"value"`
+		} `json:"storage"`
+	} `json:"body"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	Space *struct {
+		Key string `json:"key"`
+	} `json:"space"`
+}
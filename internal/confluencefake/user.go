@@ -0,0 +1,228 @@
+package confluencefake
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// CurrentAccountId is the account id of the user the fake serves requests as.
+const CurrentAccountId = "557058:00000000-0000-0000-0000-000000000000"
+
+// cqlClause matches the user search clauses the fake understands, e.g:
+// user.fullname~"Jane" or user="557058:...". Other clauses such as type=user
+// are ignored.
+var cqlClause = regexp.MustCompile(`(user(?:\.fullname|\.accountid)?)\s*(~|=)\s*"((?:[^"\\]|\\.)*)"`)
+
+// AddUser stores a user, it can be looked up by account id and searched by
+// display name or email. The username used by the Data Center endpoints is
+// the local part of the email.
+func (s *Server) AddUser(accountId, displayName, email string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	username, _, _ := strings.Cut(email, "@")
+	s.users[accountId] = &user{
+		AccountId:   accountId,
+		Username:    username,
+		DisplayName: displayName,
+		Email:       email,
+	}
+}
+
+// AddGroup stores a group with the given members, the members are account ids.
+func (s *Server) AddGroup(name string, members ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.groups[strings.ToLower(name)] = &group{
+		Id:      s.newGroupId(),
+		Name:    name,
+		Members: members,
+	}
+}
+
+// newGroupId returns a group id, they are not taken from the content ids so
+// adding groups does not change the ids of the contents created afterwards.
+func (s *Server) newGroupId() string {
+	s.groupIds++
+	return fmt.Sprintf("%08d-0000-0000-0000-000000000000", s.groupIds)
+}
+
+func (s *Server) serveUser(w http.ResponseWriter, r *http.Request, segments []string) {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		u, ok := s.users[r.URL.Query().Get("accountId")]
+		if !ok {
+			writeError(w, http.StatusNotFound, "no user with account id "+r.URL.Query().Get("accountId"))
+			return
+		}
+		writeJSON(w, http.StatusOK, userJSON(u))
+	case len(segments) == 1 && segments[0] == "current" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, userJSON(s.users[CurrentAccountId]))
+	case len(segments) == 3 && segments[0] == "watch":
+		s.serveWatch(w, r, segments[1], segments[2])
+	case len(segments) == 3 && segments[1] == "group":
+		s.serveGroupMember(w, r, segments[0], segments[2])
+	default:
+		writeError(w, http.StatusNotFound, "unknown user path "+r.URL.Path)
+	}
+}
+
+func (s *Server) serveSearch(w http.ResponseWriter, r *http.Request, segments []string) {
+	if len(segments) != 1 || segments[0] != "user" || r.Method != http.MethodGet {
+		writeError(w, http.StatusNotFound, "unknown search path "+r.URL.Path)
+		return
+	}
+	cql := r.URL.Query().Get("cql")
+	clauses := cqlClause.FindAllStringSubmatch(cql, -1)
+	if len(clauses) == 0 {
+		writeError(w, http.StatusBadRequest, "Could not parse cql : "+cql)
+		return
+	}
+	results := []map[string]any{}
+	for _, accountId := range sortedKeys(s.users) {
+		u := s.users[accountId]
+		if matchUser(u, clauses) {
+			results = append(results, map[string]any{"user": userJSON(u)})
+		}
+	}
+	page(w, r, results)
+}
+
+// matchUser reports whether the user matches every clause, user.fullname~
+// matches part of the display name like confluence does.
+func matchUser(u *user, clauses [][]string) bool {
+	for _, clause := range clauses {
+		field, op, value := clause[1], clause[2], strings.ReplaceAll(clause[3], `\"`, `"`)
+		switch {
+		case field == "user.fullname" && op == "~":
+			value = strings.ToLower(value)
+			if !strings.Contains(strings.ToLower(u.DisplayName), value) {
+				return false
+			}
+		case field == "user.fullname":
+			if !strings.EqualFold(u.DisplayName, value) {
+				return false
+			}
+		default:
+			if u.AccountId != value {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (s *Server) serveGroup(w http.ResponseWriter, r *http.Request, segments []string) {
+	if len(segments) == 0 || r.Method != http.MethodGet {
+		writeError(w, http.StatusNotFound, "unknown group path "+r.URL.Path)
+		return
+	}
+	g, ok := s.groups[strings.ToLower(segments[0])]
+	if !ok {
+		writeError(w, http.StatusNotFound, "no group with name "+segments[0])
+		return
+	}
+	switch {
+	case len(segments) == 1:
+		writeJSON(w, http.StatusOK, groupJSON(g))
+	case len(segments) == 2 && segments[1] == "member":
+		results := []map[string]any{}
+		for _, accountId := range g.Members {
+			if u, ok := s.users[accountId]; ok {
+				results = append(results, userJSON(u))
+			}
+		}
+		page(w, r, results)
+	default:
+		writeError(w, http.StatusNotFound, "unknown group path "+r.URL.Path)
+	}
+}
+
+// serveAdmin serves the Data Center group administration.
+func (s *Server) serveAdmin(w http.ResponseWriter, r *http.Request, segments []string) {
+	switch {
+	case len(segments) == 1 && segments[0] == "group" && r.Method == http.MethodPost:
+		var req struct {
+			Name string `json:"name"`
+		}
+		if err := decode(r, &req); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if req.Name == "" {
+			writeError(w, http.StatusBadRequest, "name is required")
+			return
+		}
+		if _, ok := s.groups[strings.ToLower(req.Name)]; ok {
+			writeError(w, http.StatusBadRequest, "A group already exists with name "+req.Name)
+			return
+		}
+		g := &group{
+			Id:   s.newGroupId(),
+			Name: req.Name,
+		}
+		s.groups[strings.ToLower(req.Name)] = g
+		writeJSON(w, http.StatusCreated, groupJSON(g))
+	case len(segments) == 2 && segments[0] == "group" && r.Method == http.MethodDelete:
+		if _, ok := s.groups[strings.ToLower(segments[1])]; !ok {
+			writeError(w, http.StatusNotFound, "no group with name "+segments[1])
+			return
+		}
+		delete(s.groups, strings.ToLower(segments[1]))
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusNotFound, "unknown admin path "+r.URL.Path)
+	}
+}
+
+// serveGroupMember adds or removes a user from a group by username.
+func (s *Server) serveGroupMember(w http.ResponseWriter, r *http.Request, username, name string) {
+	var u *user
+	for _, candidate := range s.users {
+		if candidate.Username == username {
+			u = candidate
+		}
+	}
+	g, ok := s.groups[strings.ToLower(name)]
+	if u == nil || !ok {
+		writeError(w, http.StatusNotFound, "no user "+username+" or group "+name)
+		return
+	}
+	members := []string{}
+	for _, accountId := range g.Members {
+		if accountId != u.AccountId {
+			members = append(members, accountId)
+		}
+	}
+	switch r.Method {
+	case http.MethodPut:
+		g.Members = append(members, u.AccountId)
+	case http.MethodDelete:
+		g.Members = members
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func userJSON(u *user) map[string]any {
+	return map[string]any{
+		"type":        "known",
+		"accountId":   u.AccountId,
+		"username":    u.Username,
+		"accountType": "atlassian",
+		"email":       u.Email,
+		"publicName":  u.DisplayName,
+		"displayName": u.DisplayName,
+	}
+}
+
+func groupJSON(g *group) map[string]any {
+	return map[string]any{
+		"type": "group",
+		"name": g.Name,
+		"id":   g.Id,
+	}
+}
@@ -0,0 +1,112 @@
+package confluencefake
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// recordVersion adds the current title and body of the content to its
+// history, every version is made by the current user.
+func (s *Server) recordVersion(c *content, message string) {
+	s.versions[c.Id] = append(s.versions[c.Id], &version{
+		Number:  c.Version,
+		Title:   c.Title,
+		Body:    c.Body,
+		When:    time.Now().UTC().Format(createdLayout),
+		Message: message,
+	})
+}
+
+// serveVersions lists the history of a content newest first, returns one of
+// its versions or restores it.
+func (s *Server) serveVersions(w http.ResponseWriter, r *http.Request, id string, segments []string) {
+	c, ok := s.contents[id]
+	if !ok || c.Status != "current" {
+		writeError(w, http.StatusNotFound, "no content with id "+id)
+		return
+	}
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		results := []map[string]any{}
+		for i := len(s.versions[id]) - 1; i >= 0; i-- {
+			results = append(results, s.versionJSON(c, s.versions[id][i], false))
+		}
+		page(w, r, results)
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		s.restoreVersion(w, r, c)
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		v, ok := s.version(id, segments[0])
+		if !ok {
+			writeError(w, http.StatusNotFound, "no version "+segments[0]+" of content "+id)
+			return
+		}
+		writeJSON(w, http.StatusOK, s.versionJSON(c, v, true))
+	default:
+		writeError(w, http.StatusNotFound, "unknown version path "+r.URL.Path)
+	}
+}
+
+// restoreVersion makes the body, and the title when restoreTitle is set, of
+// a previous version the next version of the content.
+func (s *Server) restoreVersion(w http.ResponseWriter, r *http.Request, c *content) {
+	var req versionRestoreRequest
+	if err := decode(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if req.OperationKey != "restore" {
+		writeError(w, http.StatusBadRequest, "unsupported operation "+req.OperationKey)
+		return
+	}
+	v, ok := s.version(c.Id, strconv.Itoa(req.Params.VersionNumber))
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no version %d of content %s", req.Params.VersionNumber, c.Id))
+		return
+	}
+	if req.Params.RestoreTitle && c.Type == "page" && s.titleTaken(c.SpaceKey, v.Title, c.Id) {
+		writeError(w, http.StatusBadRequest, "A page with this title already exists in this space: "+v.Title)
+		return
+	}
+	if req.Params.RestoreTitle {
+		c.Title = v.Title
+	}
+	c.Body = v.Body
+	c.Version++
+	s.recordVersion(c, req.Params.Message)
+	writeJSON(w, http.StatusOK, s.versionJSON(c, s.versions[c.Id][len(s.versions[c.Id])-1], false))
+}
+
+func (s *Server) version(id, number string) (*version, bool) {
+	for _, v := range s.versions[id] {
+		if strconv.Itoa(v.Number) == number {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// versionJSON renders a version, the content of the version is rendered
+// with its title and body when withContent is set.
+func (s *Server) versionJSON(c *content, v *version, withContent bool) map[string]any {
+	body := map[string]any{
+		"by":        userJSON(s.users[CurrentAccountId]),
+		"when":      v.When,
+		"message":   v.Message,
+		"number":    v.Number,
+		"minorEdit": false,
+	}
+	if withContent {
+		body["content"] = map[string]any{
+			"id":     c.Id,
+			"type":   c.Type,
+			"status": c.Status,
+			"title":  v.Title,
+			"body": map[string]any{
+				"storage": map[string]string{"value": v.Body, "representation": "storage"},
+			},
+		}
+	}
+	return body
+}
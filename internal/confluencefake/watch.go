@@ -0,0 +1,90 @@
+package confluencefake
+
+import (
+	"net/http"
+)
+
+// serveWatch adds, removes or checks the watch of a user on a content or a
+// space, e.g: /user/watch/content/1001?accountId=...
+func (s *Server) serveWatch(w http.ResponseWriter, r *http.Request, kind, id string) {
+	if r.Method != http.MethodGet && r.Header.Get("X-Atlassian-Token") != xsrfNoCheck {
+		writeError(w, http.StatusForbidden, "XSRF check failed")
+		return
+	}
+	key, ok := s.watchKey(kind, id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "no "+kind+" with id "+id)
+		return
+	}
+	accountId := r.URL.Query().Get("accountId")
+	if _, ok := s.users[accountId]; !ok {
+		writeError(w, http.StatusNotFound, "no user with account id "+accountId)
+		return
+	}
+	watchers := []string{}
+	watching := false
+	for _, watcher := range s.watchers[key] {
+		if watcher == accountId {
+			watching = true
+			continue
+		}
+		watchers = append(watchers, watcher)
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]bool{"watching": watching})
+		return
+	case http.MethodPost:
+		s.watchers[key] = append(watchers, accountId)
+	case http.MethodDelete:
+		s.watchers[key] = watchers
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// listWatchers returns the watches of a content or a space in the order the
+// users started watching.
+func (s *Server) listWatchers(w http.ResponseWriter, r *http.Request, kind, id string) {
+	key, ok := s.watchKey(kind, id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "no "+kind+" with id "+id)
+		return
+	}
+	results := []map[string]any{}
+	for _, accountId := range s.watchers[key] {
+		watch := map[string]any{
+			"type":    "watch",
+			"watcher": userJSON(s.users[accountId]),
+		}
+		if kind == "content" {
+			watch["contentId"] = id
+		} else {
+			watch["spaceKey"] = key[len("space/"):]
+		}
+		results = append(results, watch)
+	}
+	page(w, r, results)
+}
+
+// watchKey returns the key of the watchers of a current content or of a
+// space, space keys are case insensitive.
+func (s *Server) watchKey(kind, id string) (string, bool) {
+	switch kind {
+	case "content":
+		c, ok := s.contents[id]
+		if !ok || c.Status != "current" {
+			return "", false
+		}
+		return "content/" + c.Id, true
+	case "space":
+		sp, ok := s.space(id)
+		if !ok {
+			return "", false
+		}
+		return "space/" + sp.Key, true
+	}
+	return "", false
+}
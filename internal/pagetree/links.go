@@ -0,0 +1,124 @@
+package pagetree
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// linkTag matches a link of a body, the first group holds the attributes
+// and the second the link text.
+var linkTag = regexp.MustCompile(`(?s)<a\s([^>]*)>(.*?)</a>`)
+
+// Link is the target of a relative link.
+type Link struct {
+	// Title is the title of the linked page, empty for attachment links.
+	Title string
+	// Attachment is the file name of the linked attachment of the page.
+	Attachment string
+}
+
+// IsPage reports whether the file is a page source, e.g: a Markdown file.
+func IsPage(name string) bool {
+	return sourceFormat(name) != ""
+}
+
+// ResolveLinks replaces the relative links of a body written in the file at
+// the slash separated path from with links to confluence pages and
+// attachments. resolve returns the target of the slash separated path of
+// a link relative to the same root as from, e.g: ops/oncall.md for the link
+// ../ops/oncall.md of docs/index.md, and false when it can not be resolved.
+// Unresolved links are replaced by their text and returned, links with a URL
+// or an absolute path and anchors of the page are kept as they are.
+func ResolveLinks(body, from string, resolve func(target string) (Link, bool)) (string, []string) {
+	unresolved := []string{}
+	body = linkTag.ReplaceAllStringFunc(body, func(tag string) string {
+		m := linkTag.FindStringSubmatch(tag)
+		href := ""
+		for _, attr := range tagAttribute.FindAllStringSubmatch(m[1], -1) {
+			if attr[1] == "href" {
+				href = html.UnescapeString(attr[2])
+			}
+		}
+		u, err := url.Parse(href)
+		if err != nil || u.Scheme != "" || u.Host != "" || u.Path == "" || strings.HasPrefix(u.Path, "/") {
+			return tag
+		}
+		target := path.Clean(path.Join(path.Dir(from), u.Path))
+		if strings.HasSuffix(u.Path, "/") {
+			target += "/"
+		}
+		link, ok := resolve(target)
+		if !ok {
+			unresolved = append(unresolved, href)
+			return m[2]
+		}
+		return linkMarkup(link, u.Fragment, m[2])
+	})
+	return body, unresolved
+}
+
+// linkMarkup returns the storage format of a link to a page or an
+// attachment.
+func linkMarkup(link Link, anchor, text string) string {
+	markup := "<ac:link"
+	if anchor != "" {
+		markup += fmt.Sprintf(` ac:anchor="%s"`, html.EscapeString(anchor))
+	}
+	if link.Attachment != "" {
+		markup += fmt.Sprintf(`><ri:attachment ri:filename="%s" />`, html.EscapeString(link.Attachment))
+	} else {
+		markup += fmt.Sprintf(`><ri:page ri:content-title="%s" />`, html.EscapeString(link.Title))
+	}
+	if text != "" {
+		markup += "<ac:link-body>" + text + "</ac:link-body>"
+	}
+	return markup + "</ac:link>"
+}
+
+// resolveLinks resolves the relative links of the pages to the other pages
+// of the tree and to local files, the files are added to the attachments of
+// the page.
+func (t *tree) resolveLinks() {
+	for rel, page := range t.pages {
+		if strings.HasSuffix(rel, "/") {
+			continue
+		}
+		page.Body, page.Unresolved = ResolveLinks(page.Body, rel, func(target string) (Link, bool) {
+			if other, ok := t.page(target); ok {
+				return Link{Title: other.Title}, true
+			}
+			if IsPage(target) || strings.HasPrefix(target, "../") {
+				return Link{}, false
+			}
+			attachment, err := t.attachment(path.Base(target), target)
+			if err != nil {
+				return Link{}, false
+			}
+			for _, a := range page.Attachments {
+				if a.Name == attachment.Name {
+					// Another file with the same name is already attached.
+					return Link{Attachment: a.Name}, a.File == attachment.File
+				}
+			}
+			page.Attachments = append(page.Attachments, attachment)
+			return Link{Attachment: attachment.Name}, true
+		})
+	}
+}
+
+// page returns the page of a file or of a directory of the tree.
+func (t *tree) page(target string) (*Page, bool) {
+	if page, ok := t.pages[target]; ok {
+		return page, true
+	}
+	d := strings.TrimSuffix(target, "/")
+	if index, ok := t.indexes[d]; ok {
+		return t.pages[index], true
+	}
+	page, ok := t.pages[d+"/"]
+	return page, ok
+}
@@ -0,0 +1,97 @@
+package pagetree
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResolveLinks(t *testing.T) {
+	targets := map[string]Link{
+		"ops/oncall.md":    {Title: "On-call & escalation"},
+		"docs/diagram.png": {Attachment: "diagram.png"},
+	}
+	resolve := func(target string) (Link, bool) {
+		link, ok := targets[target]
+		return link, ok
+	}
+	testCases := []struct {
+		desc           string
+		body           string
+		wantBody       string
+		wantUnresolved []string
+	}{
+		{
+			desc:     "page",
+			body:     `<p>See <a href="../ops/oncall.md">on-call</a>.</p>`,
+			wantBody: `<p>See <ac:link><ri:page ri:content-title="On-call &amp; escalation" /><ac:link-body>on-call</ac:link-body></ac:link>.</p>`,
+		},
+		{
+			desc:     "page anchor",
+			body:     `<a href="../ops/oncall.md#paging">paging</a>`,
+			wantBody: `<ac:link ac:anchor="paging"><ri:page ri:content-title="On-call &amp; escalation" /><ac:link-body>paging</ac:link-body></ac:link>`,
+		},
+		{
+			desc:     "attachment",
+			body:     `<a title="diagram" href="diagram.png"><em>diagram</em></a>`,
+			wantBody: `<ac:link><ri:attachment ri:filename="diagram.png" /><ac:link-body><em>diagram</em></ac:link-body></ac:link>`,
+		},
+		{
+			desc:     "kept",
+			body:     `<a href="https://example.com/a.md">a</a> <a href="/wiki/b.md">b</a> <a href="#usage">usage</a>`,
+			wantBody: `<a href="https://example.com/a.md">a</a> <a href="/wiki/b.md">b</a> <a href="#usage">usage</a>`,
+		},
+		{
+			desc:           "unresolved",
+			body:           `<p>See <a href="missing.md">the <em>missing</em> page</a>.</p>`,
+			wantBody:       `<p>See the <em>missing</em> page.</p>`,
+			wantUnresolved: []string{"missing.md"},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			body, unresolved := ResolveLinks(tC.body, "docs/index.md", resolve)
+			if body != tC.wantBody {
+				t.Errorf("wants body %q, but got %q", tC.wantBody, body)
+			}
+			if strings.Join(unresolved, ",") != strings.Join(tC.wantUnresolved, ",") {
+				t.Errorf("wants unresolved %v, but got %v", tC.wantUnresolved, unresolved)
+			}
+		})
+	}
+}
+
+func TestReadLinks(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"index.md":           "# Docs\n\n[On-call](ops/oncall.md), [runbooks](ops/runbooks/), [slides](talk.pdf), [todo](todo.md)\n",
+		"talk.pdf":           "pdf",
+		"ops/index.md":       "# Operations\n",
+		"ops/oncall.md":      "# On-call\n\n[Operations](./) [home](../index.md)\n",
+		"ops/runbooks/db.md": "# Database\n",
+	})
+	pages, err := Read(dir, "**")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bodies := map[string]Page{}
+	for _, page := range pages {
+		bodies[page.Path] = page
+	}
+	index := bodies["index.md"]
+	want := `<p><ac:link><ri:page ri:content-title="On-call" /><ac:link-body>On-call</ac:link-body></ac:link>, ` +
+		`<ac:link><ri:page ri:content-title="runbooks" /><ac:link-body>runbooks</ac:link-body></ac:link>, ` +
+		`<ac:link><ri:attachment ri:filename="talk.pdf" /><ac:link-body>slides</ac:link-body></ac:link>, todo</p>`
+	if index.Body != want {
+		t.Errorf("wants body %q, but got %q", want, index.Body)
+	}
+	if len(index.Attachments) != 1 || index.Attachments[0].Name != "talk.pdf" {
+		t.Errorf("wants the talk.pdf attachment, but got %v", index.Attachments)
+	}
+	if strings.Join(index.Unresolved, ",") != "todo.md" {
+		t.Errorf("wants todo.md unresolved, but got %v", index.Unresolved)
+	}
+	want = `<p><ac:link><ri:page ri:content-title="Operations" /><ac:link-body>Operations</ac:link-body></ac:link> ` +
+		`<ac:link><ri:page ri:content-title="Docs" /><ac:link-body>home</ac:link-body></ac:link></p>`
+	if got := bodies["ops/oncall.md"].Body; got != want {
+		t.Errorf("wants body %q, but got %q", want, got)
+	}
+}
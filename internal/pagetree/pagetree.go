@@ -0,0 +1,287 @@
+// Package pagetree reads a directory of Markdown and XHTML files into the
+// confluence pages it describes, the layout of the directory is the page
+// hierarchy.
+//
+// Every file is a page, the pages of a directory are the children of its
+// index file, e.g: ops/index.md is the parent of ops/oncall.md. A directory
+// without an index file is a page that lists its children. Titles are taken
+// from the first level one heading of Markdown files, or from the file name.
+// Relative links between the files are links between the pages, and links
+// to other local files are links to attachments.
+package pagetree
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// childrenMacro is the body of the pages of directories without an index file.
+const childrenMacro = `<ac:structured-macro ac:name="children" />`
+
+// Page is a confluence page of the tree.
+type Page struct {
+	// Path is the slash separated path of the source file relative to the
+	// directory, or the path of a directory with a trailing slash for the
+	// pages of directories without an index file.
+	Path string
+	// Parent is the Path of the parent page, empty for the top level pages.
+	Parent string
+	Title  string
+	// Body is the body of the page in storage format.
+	Body        string
+	Attachments []Attachment
+	// Unresolved are the relative links of the body that are neither a page
+	// of the tree nor a local file, they are published as plain text.
+	Unresolved []string
+	// Hash changes whenever the title, the parent, the body or an
+	// attachment of the page changes.
+	Hash string
+}
+
+// Attachment is a local file referenced by a page, e.g: an image.
+type Attachment struct {
+	// Name is the file name of the attachment on the page.
+	Name string
+	// File is the path of the file on disk.
+	File      string
+	MediaType string
+	Hash      string
+}
+
+// Read returns the pages of the files of dir whose slash separated relative
+// path matches pattern, parents before their children. Files that are not
+// Markdown (.md, .markdown) nor XHTML (.xhtml, .html) are ignored.
+func Read(dir, pattern string) ([]Page, error) {
+	sources := []string{}
+	err := filepath.WalkDir(dir, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || sourceFormat(name) == "" {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, name)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		ok, err := Match(pattern, rel)
+		if err != nil {
+			return err
+		}
+		if ok {
+			sources = append(sources, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	t := &tree{dir: dir, indexes: map[string]string{}, pages: map[string]*Page{}}
+	for _, rel := range sources {
+		if d := path.Dir(rel); d != "." && isIndex(rel) {
+			if other, ok := t.indexes[d]; ok {
+				return nil, fmt.Errorf("directory %s has two index files, %s and %s", d, other, rel)
+			}
+			t.indexes[d] = rel
+		}
+	}
+	for _, rel := range sources {
+		err = t.addFile(rel)
+		if err != nil {
+			return nil, err
+		}
+	}
+	t.resolveLinks()
+	return t.sorted()
+}
+
+type tree struct {
+	dir string
+	// indexes are the index files by directory.
+	indexes map[string]string
+	pages   map[string]*Page
+}
+
+// addFile adds the page of a source file and the pages of its directories.
+func (t *tree) addFile(rel string) error {
+	page := &Page{Path: rel}
+	d := path.Dir(rel)
+	if isIndex(rel) && d != "." {
+		page.Parent = t.addDir(path.Dir(d))
+	} else {
+		page.Parent = t.addDir(d)
+	}
+	src, err := readFile(t.dir, rel)
+	if err != nil {
+		return err
+	}
+	body := string(src)
+	title := ""
+	if sourceFormat(rel) == "markdown" {
+		title, body = markdownTitle(body)
+		body = renderMarkdown(body)
+	}
+	if title == "" {
+		title = fileTitle(rel)
+	}
+	page.Title = title
+	page.Body, page.Attachments, err = t.attachImages(rel, strings.TrimSpace(body))
+	if err != nil {
+		return err
+	}
+	t.pages[rel] = page
+	return nil
+}
+
+// addDir returns the path of the page of a directory, the page of a
+// directory without an index file is added on the first call.
+func (t *tree) addDir(d string) string {
+	if d == "." {
+		return ""
+	}
+	if index, ok := t.indexes[d]; ok {
+		return index
+	}
+	key := d + "/"
+	if _, ok := t.pages[key]; !ok {
+		t.pages[key] = &Page{
+			Path:   key,
+			Parent: t.addDir(path.Dir(d)),
+			Title:  path.Base(d),
+			Body:   childrenMacro,
+		}
+	}
+	return key
+}
+
+// sorted returns the pages with their hash, parents first and siblings by
+// path. Titles must be unique in a space.
+func (t *tree) sorted() ([]Page, error) {
+	depths := map[string]int{}
+	var depth func(key string) int
+	depth = func(key string) int {
+		if key == "" {
+			return 0
+		}
+		if d, ok := depths[key]; ok {
+			return d
+		}
+		depths[key] = depth(t.pages[key].Parent) + 1
+		return depths[key]
+	}
+	pages := make([]Page, 0, len(t.pages))
+	titles := map[string]string{}
+	for key, page := range t.pages {
+		if other, ok := titles[page.Title]; ok {
+			first, second := other, key
+			if first > second {
+				first, second = second, first
+			}
+			return nil, fmt.Errorf("pages %s and %s have the same title %q, titles must be unique in a space", first, second, page.Title)
+		}
+		titles[page.Title] = key
+		page.Hash = pageHash(page)
+		pages = append(pages, *page)
+	}
+	sort.Slice(pages, func(i, j int) bool {
+		di, dj := depth(pages[i].Path), depth(pages[j].Path)
+		if di != dj {
+			return di < dj
+		}
+		return pages[i].Path < pages[j].Path
+	})
+	return pages, nil
+}
+
+// Match reports whether the slash separated name matches the pattern, the
+// pattern has the syntax of path.Match and ** matches any number of
+// directories, e.g: docs/**/*.md.
+func Match(pattern, name string) (bool, error) {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(pattern, name []string) (bool, error) {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				ok, err := matchSegments(pattern[1:], name[i:])
+				if ok || err != nil {
+					return ok, err
+				}
+			}
+			return false, nil
+		}
+		if len(name) == 0 {
+			return false, nil
+		}
+		ok, err := path.Match(pattern[0], name[0])
+		if !ok || err != nil {
+			return false, err
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0, nil
+}
+
+// sourceFormat returns the format of a page source file, empty when the file
+// is not a page.
+func sourceFormat(name string) string {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".md", ".markdown":
+		return "markdown"
+	case ".xhtml", ".html":
+		return "xhtml"
+	}
+	return ""
+}
+
+func isIndex(rel string) bool {
+	base := path.Base(rel)
+	return strings.TrimSuffix(base, path.Ext(base)) == "index"
+}
+
+// fileTitle returns the title of a file without heading, the directory name
+// for index files.
+func fileTitle(rel string) string {
+	if isIndex(rel) && path.Dir(rel) != "." {
+		return path.Base(path.Dir(rel))
+	}
+	base := path.Base(rel)
+	return strings.TrimSuffix(base, path.Ext(base))
+}
+
+func pageHash(page *Page) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%s\x00", page.Title, page.Parent, page.Body)
+	for _, a := range page.Attachments {
+		fmt.Fprintf(h, "%s\x00%s\x00", a.Name, a.Hash)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// SortChildrenFirst sorts page paths so the children of a page come before
+// it, e.g: to delete a tree without moving pages to the parent of a deleted one.
+func SortChildrenFirst(paths []string) {
+	rank := func(p string) int {
+		r := 2 * strings.Count(strings.TrimSuffix(p, "/"), "/")
+		if isIndex(p) && path.Dir(p) != "." {
+			r--
+		}
+		return r
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		ri, rj := rank(paths[i]), rank(paths[j])
+		if ri != rj {
+			return ri > rj
+		}
+		return paths[i] < paths[j]
+	})
+}
@@ -0,0 +1,167 @@
+package pagetree
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeFiles creates the files in a temporary directory and returns it.
+func writeFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		file := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestRead(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"getting-started.md":         "# Getting started\n\nRead the *docs*.\n",
+		"ops/index.md":               "# Operations\n\nHow we run services.\n",
+		"ops/oncall.md":              "# On-call\n\n![Escalation](diagram.png)\n",
+		"ops/diagram.png":            "png",
+		"ops/runbooks/billing.xhtml": "<p>Billing runbook</p>\n",
+		"notes.txt":                  "not a page",
+	})
+	pages, err := Read(dir, "**")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct{ path, parent, title string }{
+		{"getting-started.md", "", "Getting started"},
+		{"ops/index.md", "", "Operations"},
+		{"ops/oncall.md", "ops/index.md", "On-call"},
+		{"ops/runbooks/", "ops/index.md", "runbooks"},
+		{"ops/runbooks/billing.xhtml", "ops/runbooks/", "billing"},
+	}
+	if len(pages) != len(want) {
+		t.Fatalf("wants %d pages, but got %v", len(want), pages)
+	}
+	for i, w := range want {
+		if pages[i].Path != w.path || pages[i].Parent != w.parent || pages[i].Title != w.title {
+			t.Errorf("page %d, wants %s under %q titled %q, but got %s under %q titled %q",
+				i, w.path, w.parent, w.title, pages[i].Path, pages[i].Parent, pages[i].Title)
+		}
+	}
+	if got := pages[0].Body; got != "<p>Read the <em>docs</em>.</p>" {
+		t.Errorf("wants the rendered markdown without title, but got %q", got)
+	}
+	if got := pages[2].Body; got != `<p><ac:image ac:alt="Escalation"><ri:attachment ri:filename="diagram.png" /></ac:image></p>` {
+		t.Errorf("wants an attachment image, but got %q", got)
+	}
+	if len(pages[2].Attachments) != 1 || pages[2].Attachments[0].Name != "diagram.png" || pages[2].Attachments[0].MediaType != "image/png" {
+		t.Errorf("wants the diagram.png attachment, but got %v", pages[2].Attachments)
+	}
+	if pages[3].Body != childrenMacro {
+		t.Errorf("wants the children macro, but got %q", pages[3].Body)
+	}
+}
+
+func TestReadHash(t *testing.T) {
+	files := map[string]string{
+		"oncall.md":   "# On-call\n\n![diagram](diagram.png)\n",
+		"diagram.png": "v1",
+	}
+	read := func() string {
+		pages, err := Read(writeFiles(t, files), "**/*.md")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pages[0].Hash
+	}
+	first := read()
+	if read() != first {
+		t.Error("wants the same hash for the same files")
+	}
+	files["diagram.png"] = "v2"
+	if read() == first {
+		t.Error("wants another hash when an image changes")
+	}
+}
+
+func TestReadError(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		files   map[string]string
+		wantErr string
+	}{
+		{
+			desc:    "Duplicate titles",
+			files:   map[string]string{"a.md": "# Runbook\n", "b.md": "# Runbook\n"},
+			wantErr: `pages a.md and b.md have the same title "Runbook"`,
+		},
+		{
+			desc:    "Two index files",
+			files:   map[string]string{"ops/index.md": "", "ops/index.xhtml": ""},
+			wantErr: "directory ops has two index files",
+		},
+		{
+			desc:    "Missing image",
+			files:   map[string]string{"a.md": "![diagram](diagram.png)\n"},
+			wantErr: "image diagram.png of a.md",
+		},
+		{
+			desc:    "Image outside of the directory",
+			files:   map[string]string{"a.md": "![diagram](../diagram.png)\n"},
+			wantErr: "is outside of the directory",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			_, err := Read(writeFiles(t, tC.files), "**")
+			if err == nil || !strings.Contains(err.Error(), tC.wantErr) {
+				t.Errorf("wants error %q, but got %v", tC.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	testCases := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"**", "ops/oncall.md", true},
+		{"**/*.md", "oncall.md", true},
+		{"**/*.md", "ops/runbooks/billing.md", true},
+		{"**/*.md", "ops/billing.xhtml", false},
+		{"ops/**", "ops/runbooks/billing.md", true},
+		{"ops/*.md", "ops/runbooks/billing.md", false},
+		{"*.md", "oncall.md", true},
+	}
+	for _, tC := range testCases {
+		got, err := Match(tC.pattern, tC.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tC.want {
+			t.Errorf("Match(%q, %q), wants %v, but got %v", tC.pattern, tC.name, tC.want, got)
+		}
+	}
+}
+
+func TestSortChildrenFirst(t *testing.T) {
+	paths := []string{
+		"getting-started.md",
+		"ops/index.md",
+		"ops/oncall.md",
+		"ops/runbooks/",
+		"ops/runbooks/billing.xhtml",
+		"ops/runbooks/payments/index.md",
+	}
+	SortChildrenFirst(paths)
+	want := "ops/runbooks/payments/index.md,ops/runbooks/billing.xhtml,ops/oncall.md,ops/runbooks/,ops/index.md,getting-started.md"
+	if got := strings.Join(paths, ","); got != want {
+		t.Errorf("wants %s, but got %s", want, got)
+	}
+}
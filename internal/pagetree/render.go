@@ -0,0 +1,118 @@
+package pagetree
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/russross/blackfriday"
+)
+
+// markdownExtensions are the Markdown extensions of GitHub flavoured
+// documentation, e.g: tables and fenced code blocks.
+const markdownExtensions = blackfriday.EXTENSION_NO_INTRA_EMPHASIS |
+	blackfriday.EXTENSION_TABLES |
+	blackfriday.EXTENSION_FENCED_CODE |
+	blackfriday.EXTENSION_AUTOLINK |
+	blackfriday.EXTENSION_STRIKETHROUGH |
+	blackfriday.EXTENSION_SPACE_HEADERS
+
+var (
+	// imageTag matches an image of a rendered body, the first group holds
+	// the attributes.
+	imageTag = regexp.MustCompile(`<img\s([^>]*?)\s*/?>`)
+	// tagAttribute matches the attributes of a tag.
+	tagAttribute = regexp.MustCompile(`([a-zA-Z:-]+)="([^"]*)"`)
+)
+
+// renderMarkdown renders Markdown as XHTML, confluence storage format is
+// based on XHTML.
+func renderMarkdown(src string) string {
+	renderer := blackfriday.HtmlRenderer(blackfriday.HTML_USE_XHTML, "", "")
+	return string(blackfriday.Markdown([]byte(src), renderer, markdownExtensions))
+}
+
+// markdownTitle returns the text of a level one heading on the first line
+// of src and src without it, or an empty title and src.
+func markdownTitle(src string) (string, string) {
+	trimmed := strings.TrimLeft(src, " \t\r\n")
+	line, rest, _ := strings.Cut(trimmed, "\n")
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "# ") {
+		return "", src
+	}
+	return strings.TrimSpace(strings.TrimRight(line[2:], "#")), rest
+}
+
+// attachImages replaces the images of a body that reference local files with
+// attachment images, the files are returned as the attachments of the page.
+// Images with a URL are kept as they are.
+func (t *tree) attachImages(rel, body string) (string, []Attachment, error) {
+	attachments := []Attachment{}
+	files := map[string]string{}
+	var err error
+	body = imageTag.ReplaceAllStringFunc(body, func(tag string) string {
+		if err != nil {
+			return tag
+		}
+		attrs := map[string]string{}
+		for _, m := range tagAttribute.FindAllStringSubmatch(imageTag.FindStringSubmatch(tag)[1], -1) {
+			attrs[m[1]] = html.UnescapeString(m[2])
+		}
+		src := attrs["src"]
+		if src == "" || strings.Contains(src, ":") || strings.HasPrefix(src, "/") {
+			return tag
+		}
+		file := path.Clean(path.Join(path.Dir(rel), src))
+		if strings.HasPrefix(file, "../") {
+			err = fmt.Errorf("image %s of %s is outside of the directory", src, rel)
+			return tag
+		}
+		name := path.Base(file)
+		if other, ok := files[name]; ok && other != file {
+			err = fmt.Errorf("images %s and %s of %s have the same file name", other, file, rel)
+			return tag
+		}
+		if _, ok := files[name]; !ok {
+			files[name] = file
+			var attachment Attachment
+			attachment, err = t.attachment(name, file)
+			if err != nil {
+				err = fmt.Errorf("image %s of %s: %w", src, rel, err)
+				return tag
+			}
+			attachments = append(attachments, attachment)
+		}
+		image := "<ac:image"
+		if alt := attrs["alt"]; alt != "" {
+			image += fmt.Sprintf(` ac:alt="%s"`, html.EscapeString(alt))
+		}
+		return image + fmt.Sprintf(`><ri:attachment ri:filename="%s" /></ac:image>`, html.EscapeString(name))
+	})
+	return body, attachments, err
+}
+
+func (t *tree) attachment(name, file string) (Attachment, error) {
+	data, err := readFile(t.dir, file)
+	if err != nil {
+		return Attachment{}, err
+	}
+	sum := sha256.Sum256(data)
+	return Attachment{
+		Name:      name,
+		File:      filepath.Join(t.dir, filepath.FromSlash(file)),
+		MediaType: mime.TypeByExtension(path.Ext(name)),
+		Hash:      hex.EncodeToString(sum[:]),
+	}, nil
+}
+
+func readFile(dir, rel string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(dir, filepath.FromSlash(rel)))
+}
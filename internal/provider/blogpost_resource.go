@@ -0,0 +1,348 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluence"
+)
+
+// dateLayout is the format of blog post publish dates.
+const dateLayout = "2006-01-02"
+
+// dateRegexp validates dates with the dateLayout format at plan time.
+var dateRegexp = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+
+var (
+	_ resource.Resource                = &BlogPostResource{}
+	_ resource.ResourceWithConfigure   = &BlogPostResource{}
+	_ resource.ResourceWithImportState = &BlogPostResource{}
+	_ resource.ResourceWithModifyPlan  = &BlogPostResource{}
+)
+
+func NewBlogPostResource() resource.Resource {
+	return &BlogPostResource{}
+}
+
+// BlogPostResource manages a blog post, unlike pages blog posts have no
+// parent and their titles are unique per space and posting day.
+type BlogPostResource struct {
+	content   confluence.ContentService
+	blogPosts confluence.BlogPostService
+}
+
+type BlogPostResourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	Space       types.String `tfsdk:"space"`
+	Title       types.String `tfsdk:"title"`
+	Body        types.String `tfsdk:"body"`
+	PublishDate types.String `tfsdk:"publish_date"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *BlogPostResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_blogpost"
+}
+
+func (r *BlogPostResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The resource ```blogpost``` publishes a blog post in a space. Blog posts have no parent page and " +
+			"their titles must be unique per space and publish date, the plan fails when another blog post uses the title on that day.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Blog post identifier.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"space": schema.StringAttribute{
+				MarkdownDescription: "The key of the space the blog post is published in.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"title": schema.StringAttribute{
+				MarkdownDescription: "The title of the blog post.",
+				Required:            true,
+			},
+			"body": schema.StringAttribute{
+				MarkdownDescription: "The body of the blog post in storage format.",
+				Required:            true,
+			},
+			"publish_date": schema.StringAttribute{
+				MarkdownDescription: "The posting date of the blog post with the format yyyy-mm-dd, e.g: 2023-05-01. " +
+					"Defaults to the day the blog post is created, changing it replaces the blog post. " +
+					"The blog post is read back after it is created and deleted again when confluence did not apply the date.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(dateRegexp, "must be a date with the format yyyy-mm-dd"),
+				},
+			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *BlogPostResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.content = data.content
+	r.blogPosts = data.blogPosts
+}
+
+// ModifyPlan fails the plan when another blog post of the space uses the
+// title on the publish date, confluence would reject it on apply.
+func (r *BlogPostResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.blogPosts == nil {
+		return
+	}
+	var plan, state BlogPostResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.Title.IsUnknown() || plan.Space.IsUnknown() {
+		return
+	}
+	// The title is only checked when it would change in confluence.
+	if !req.State.Raw.IsNull() && state.Title.Equal(plan.Title) && state.PublishDate.Equal(plan.PublishDate) {
+		return
+	}
+	day := time.Now().UTC().Format(dateLayout)
+	if !plan.PublishDate.IsUnknown() && !plan.PublishDate.IsNull() {
+		day = plan.PublishDate.ValueString()
+	}
+	blogPosts, err := r.blogPosts.GetBlogPosts(ctx, confluence.ContentQuery{
+		SpaceKey:   plan.Space.ValueString(),
+		Title:      plan.Title.ValueString(),
+		PostingDay: day,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to look up blog posts, got error: %s", err))
+		return
+	}
+	for _, blogPost := range blogPosts {
+		if blogPost.Id != state.Id.ValueString() && blogPost.Title == plan.Title.ValueString() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("title"),
+				"Duplicate Blog Post Title",
+				fmt.Sprintf("Blog post %s already uses the title %q in space %s on %s, blog post titles must be unique per day.",
+					blogPost.Id, plan.Title.ValueString(), plan.Space.ValueString(), day),
+			)
+			return
+		}
+	}
+}
+
+func (r *BlogPostResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data BlogPostResourceModel
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	blogPost := newBlogPost(&data)
+	if !data.PublishDate.IsUnknown() && !data.PublishDate.IsNull() {
+		blogPost.History = &confluence.History{CreatedDate: data.PublishDate.ValueString() + "T00:00:00.000Z"}
+	}
+	err := r.content.CreateContent(ctx, blogPost)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create blog post, got error: %s", err))
+		return
+	}
+	data.Id = types.StringValue(blogPost.Id)
+	// Confluence may ignore the creation date of the history, e.g: when the
+	// user is not allowed to backdate blog posts, the blog post is read back
+	// to verify the publish date.
+	created, err := r.blogPosts.GetBlogPostById(ctx, blogPost.Id)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read blog post publish date, got error: %s", err))
+		return
+	}
+	publishDate := blogPostDate(created)
+	if !data.PublishDate.IsUnknown() && !data.PublishDate.IsNull() && data.PublishDate.ValueString() != publishDate {
+		if err := r.content.DeleteContent(ctx, blogPost.Id); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete blog post %s with a wrong publish date, got error: %s", blogPost.Id, err))
+			return
+		}
+		resp.Diagnostics.AddAttributeError(
+			path.Root("publish_date"),
+			"Publish Date Not Applied",
+			fmt.Sprintf("Confluence published the blog post on %s instead of %s, the blog post was deleted.", publishDate, data.PublishDate.ValueString()),
+		)
+		return
+	}
+	data.PublishDate = types.StringValue(publishDate)
+	tflog.Trace(ctx, "created a blog post")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BlogPostResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data BlogPostResourceModel
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	blogPost, err := r.blogPosts.GetBlogPostById(ctx, data.Id.ValueString())
+	if errors.Is(err, confluence.ErrNotFound) {
+		// The blog post was deleted outside terraform, it is created again
+		// on the next apply.
+		tflog.Warn(ctx, "blog post not found, removing it from the state", map[string]any{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read blog post, got error: %s", err))
+		return
+	}
+	data.Title = types.StringValue(blogPost.Title)
+	data.Body = types.StringValue(blogPost.Body.Storage.Value)
+	if blogPost.Space != nil {
+		data.Space = types.StringValue(blogPost.Space.Key)
+	}
+	data.PublishDate = types.StringValue(blogPostDate(blogPost))
+
+	// Set refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BlogPostResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data BlogPostResourceModel
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	blogPost := newBlogPost(&data)
+	blogPost.Id = data.Id.ValueString()
+	blogPost.Version = &confluence.Version{}
+	err := r.content.UpdateContent(ctx, blogPost)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update blog post, got error: %s", err))
+		return
+	}
+	tflog.Trace(ctx, "updated a blog post")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BlogPostResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data BlogPostResourceModel
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.content.DeleteContent(ctx, data.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete blog post, got error: %s", err))
+		return
+	}
+	tflog.Trace(ctx, "deleted a blog post")
+}
+
+// ImportState accepts the id of the blog post.
+func (r *BlogPostResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func newBlogPost(data *BlogPostResourceModel) *confluence.Content {
+	return &confluence.Content{
+		Type:  "blogpost",
+		Title: data.Title.ValueString(),
+		Space: &confluence.Space{Key: data.Space.ValueString()},
+		Body: confluence.Body{
+			Storage: confluence.Storage{
+				Value:          data.Body.ValueString(),
+				Representation: "storage",
+			},
+		},
+	}
+}
+
+// blogPostDate returns the posting day of a blog post, the creation date
+// starts with it, e.g: 2023-05-01T09:30:00.000Z.
+func blogPostDate(blogPost *confluence.Content) string {
+	if blogPost.History == nil || len(blogPost.History.CreatedDate) < len(dateLayout) {
+		return ""
+	}
+	return blogPost.History.CreatedDate[:len(dateLayout)]
+}
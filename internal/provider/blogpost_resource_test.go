@@ -0,0 +1,233 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluence"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluencefake"
+)
+
+func TestAccBlogPostResourceBasic(t *testing.T) {
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck: func() {
+				testAccPreCheck(t)
+			},
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				// Create and Read testing
+				{
+					Config: testAccBlogPostResourceConfig("Release notes"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("confluence_blogpost.test", "publish_date", "2023-05-01"),
+						resource.TestCheckResourceAttr("confluence_blogpost.test", "title", "Release notes"),
+					),
+				},
+				// ImportState testing
+				{
+					ResourceName:            "confluence_blogpost.test",
+					ImportState:             true,
+					ImportStateVerify:       true,
+					ImportStateVerifyIgnore: []string{"timeouts"},
+				},
+				// Update and Read testing
+				{
+					Config: testAccBlogPostResourceConfig("Release notes 1.1"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("confluence_blogpost.test", "title", "Release notes 1.1"),
+						resource.TestCheckResourceAttr("data.confluence_blogposts.test", "blogposts.#", "1"),
+						resource.TestCheckResourceAttr("data.confluence_blogposts.test", "blogposts.0.publish_date", "2023-05-01"),
+					),
+				},
+				// Delete testing automatically occurs in TestCase
+			},
+		},
+	)
+}
+
+func testAccBlogPostResourceConfig(title string) string {
+	return `
+resource "confluence_blogpost" "test" {
+  space        = "DEVOPS"
+  title        = "` + title + `"
+  body         = "<p>New release</p>"
+  publish_date = "2023-05-01"
+}
+
+data "confluence_blogposts" "test" {
+  space = confluence_blogpost.test.space
+  from  = "2023-05-01"
+  to    = "2023-05-31"
+}
+`
+}
+
+func TestFilterBlogPosts(t *testing.T) {
+	blogPost := func(id, date string) confluence.Content {
+		return confluence.Content{Id: id, Type: "blogpost", History: &confluence.History{CreatedDate: date + "T10:00:00.000Z"}}
+	}
+	blogPosts := []confluence.Content{
+		blogPost("3", "2023-06-01"),
+		blogPost("1", "2023-04-30"),
+		blogPost("2", "2023-05-01"),
+	}
+	testCases := []struct {
+		desc string
+		from string
+		to   string
+		want []string
+	}{
+		{
+			desc: "no dates",
+			want: []string{"1", "2", "3"},
+		},
+		{
+			desc: "from is inclusive",
+			from: "2023-05-01",
+			want: []string{"2", "3"},
+		},
+		{
+			desc: "to is inclusive",
+			to:   "2023-05-01",
+			want: []string{"1", "2"},
+		},
+		{
+			desc: "range",
+			from: "2023-05-01",
+			to:   "2023-05-31",
+			want: []string{"2"},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got := []string{}
+			for _, b := range filterBlogPosts(blogPosts, tC.from, tC.to) {
+				got = append(got, b.Id)
+			}
+			if len(got) != len(tC.want) {
+				t.Fatalf("wants %v, but got %v", tC.want, got)
+			}
+			for i := range got {
+				if got[i] != tC.want[i] {
+					t.Errorf("wants %v, but got %v", tC.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestBlogPostResourceCreatePublishDate(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		fault   *confluencefake.Fault
+		wantErr bool
+	}{
+		{
+			desc: "publish date applied",
+		},
+		{
+			desc: "publish date ignored",
+			fault: &confluencefake.Fault{
+				Method: http.MethodGet,
+				Path:   "/content/",
+				Status: http.StatusOK,
+				Body:   `{"id":"1001","type":"blogpost","status":"current","title":"Release","space":{"key":"DEVOPS"},"history":{"createdDate":"2023-06-01T10:00:00.000Z"}}`,
+				Times:  1,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctx := context.Background()
+			server := confluencefake.NewServer()
+			t.Cleanup(server.Close)
+			server.AddSpace("DEVOPS", "devops")
+			if tC.fault != nil {
+				server.AddFault(*tC.fault)
+			}
+			api, err := confluence.NewAPI("user@example.com", "token", server.SiteURL())
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := &BlogPostResource{}
+			configureResp := &fwresource.ConfigureResponse{}
+			r.Configure(ctx, fwresource.ConfigureRequest{ProviderData: newProviderData(api)}, configureResp)
+			schemaResp := &fwresource.SchemaResponse{}
+			r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+			s := schemaResp.Schema
+			data := BlogPostResourceModel{
+				Id:          types.StringUnknown(),
+				Space:       types.StringValue("DEVOPS"),
+				Title:       types.StringValue("Release"),
+				Body:        types.StringValue("<p>released</p>"),
+				PublishDate: types.StringValue("2023-05-01"),
+				Timeouts:    nullTimeouts(s),
+			}
+
+			plan := tfsdk.Plan{Schema: s}
+			plan.Set(ctx, &data)
+			createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: s}}
+			r.Create(ctx, fwresource.CreateRequest{Plan: plan}, createResp)
+			if createResp.Diagnostics.HasError() != tC.wantErr {
+				t.Fatalf("wants error %v, but got %v", tC.wantErr, createResp.Diagnostics)
+			}
+			deleted := false
+			for _, request := range server.Requests() {
+				deleted = deleted || strings.HasPrefix(request, "DELETE /content/")
+			}
+			if deleted != tC.wantErr {
+				t.Errorf("wants the blog post deleted %v, but got requests %v", tC.wantErr, server.Requests())
+			}
+			if tC.wantErr {
+				return
+			}
+			var got BlogPostResourceModel
+			createResp.State.Get(ctx, &got)
+			if got.PublishDate.ValueString() != "2023-05-01" {
+				t.Errorf("wants publish date 2023-05-01, but got %s", got.PublishDate)
+			}
+		})
+	}
+}
+
+func TestBlogPostResourceDeletedOutsideTerraform(t *testing.T) {
+	ctx := context.Background()
+	server := confluencefake.NewServer()
+	t.Cleanup(server.Close)
+	server.AddSpace("DEVOPS", "devops")
+	id := server.AddContent("DEVOPS", "blogpost", "Release", "<p>released</p>", "")
+	r := &BlogPostResource{}
+	s, api := fakeConfiguredResource(t, r, server)
+	data := BlogPostResourceModel{
+		Id:          types.StringValue(id),
+		Space:       types.StringValue("DEVOPS"),
+		Title:       types.StringValue("Release"),
+		Body:        types.StringValue("<p>released</p>"),
+		PublishDate: types.StringValue("2023-05-01"),
+		Timeouts:    nullTimeouts(s),
+	}
+	state := tfsdk.State{Schema: s}
+	state.Set(ctx, &data)
+
+	// The blog post is deleted in the UI, it is removed from the state.
+	err := api.DeleteContent(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	readResp := &fwresource.ReadResponse{State: state}
+	r.Read(ctx, fwresource.ReadRequest{State: state}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatal(readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.IsNull() {
+		t.Error("wants the blog post removed from the state")
+	}
+}
@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluence"
+)
+
+var (
+	_ datasource.DataSource              = &blogPostsDataSource{}
+	_ datasource.DataSourceWithConfigure = &blogPostsDataSource{}
+)
+
+func NewBlogPostsDataSource() datasource.DataSource {
+	return &blogPostsDataSource{}
+}
+
+type blogPostsDataSource struct {
+	blogPosts confluence.BlogPostService
+}
+
+type BlogPostsDataSourceModel struct {
+	Id        types.String                       `tfsdk:"id"`
+	Space     types.String                       `tfsdk:"space"`
+	From      types.String                       `tfsdk:"from"`
+	To        types.String                       `tfsdk:"to"`
+	BlogPosts []BlogPostsDataSourceBlogPostModel `tfsdk:"blogposts"`
+}
+
+type BlogPostsDataSourceBlogPostModel struct {
+	Id          types.String `tfsdk:"id"`
+	Title       types.String `tfsdk:"title"`
+	PublishDate types.String `tfsdk:"publish_date"`
+	Version     types.Int64  `tfsdk:"version"`
+}
+
+// Metadata returns the data source type name.
+func (d *blogPostsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_blogposts"
+}
+
+// Schema defines the blog posts data source schema.
+func (d *blogPostsDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Returns the current blog posts of a space published between two dates, e.g: to link the release notes of the last quarter.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the query, the space key and the dates separated by slashes.",
+				Computed:            true,
+			},
+			"space": schema.StringAttribute{
+				MarkdownDescription: "The key of the space.",
+				Required:            true,
+			},
+			"from": schema.StringAttribute{
+				MarkdownDescription: "Returns only the blog posts published on or after this date, with the format yyyy-mm-dd.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(dateRegexp, "must be a date with the format yyyy-mm-dd"),
+				},
+			},
+			"to": schema.StringAttribute{
+				MarkdownDescription: "Returns only the blog posts published on or before this date, with the format yyyy-mm-dd.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(dateRegexp, "must be a date with the format yyyy-mm-dd"),
+				},
+			},
+			"blogposts": schema.ListNestedAttribute{
+				MarkdownDescription: "The blog posts published between the dates, oldest first.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Blog post identifier.",
+							Computed:            true,
+						},
+						"title": schema.StringAttribute{
+							MarkdownDescription: "The title of the blog post.",
+							Computed:            true,
+						},
+						"publish_date": schema.StringAttribute{
+							MarkdownDescription: "The posting date of the blog post, with the format yyyy-mm-dd.",
+							Computed:            true,
+						},
+						"version": schema.Int64Attribute{
+							MarkdownDescription: "The version of the blog post.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *blogPostsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data BlogPostsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	blogPosts, err := d.blogPosts.GetBlogPosts(ctx, confluence.ContentQuery{
+		SpaceKey: data.Space.ValueString(),
+		Status:   "current",
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Blog Posts Data Source Client Error", err.Error())
+		return
+	}
+
+	from, to := data.From.ValueString(), data.To.ValueString()
+	data.Id = types.StringValue(data.Space.ValueString() + "/" + from + "/" + to)
+	data.BlogPosts = []BlogPostsDataSourceBlogPostModel{}
+	for _, blogPost := range filterBlogPosts(blogPosts, from, to) {
+		version := types.Int64Null()
+		if blogPost.Version != nil {
+			version = types.Int64Value(int64(blogPost.Version.Number))
+		}
+		data.BlogPosts = append(data.BlogPosts, BlogPostsDataSourceBlogPostModel{
+			Id:          types.StringValue(blogPost.Id),
+			Title:       types.StringValue(blogPost.Title),
+			PublishDate: types.StringValue(blogPostDate(&blogPost)),
+			Version:     version,
+		})
+	}
+
+	// Set State
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *blogPostsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.blogPosts = data.blogPosts
+}
+
+// filterBlogPosts returns the blog posts published between from and to,
+// oldest first. Empty dates do not filter, yyyy-mm-dd dates sort as strings.
+func filterBlogPosts(blogPosts []confluence.Content, from, to string) []confluence.Content {
+	filtered := []confluence.Content{}
+	for _, blogPost := range blogPosts {
+		date := blogPostDate(&blogPost)
+		if (from != "" && date < from) || (to != "" && date > to) {
+			continue
+		}
+		filtered = append(filtered, blogPost)
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return blogPostDate(&filtered[i]) < blogPostDate(&filtered[j])
+	})
+	return filtered
+}
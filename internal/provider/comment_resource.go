@@ -0,0 +1,332 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluence"
+)
+
+// Comment locations, footer comments are shown below the page and inline
+// comments are anchored to a text of the page.
+const (
+	commentLocationFooter = "footer"
+	commentLocationInline = "inline"
+)
+
+var (
+	_ resource.Resource                   = &CommentResource{}
+	_ resource.ResourceWithConfigure      = &CommentResource{}
+	_ resource.ResourceWithImportState    = &CommentResource{}
+	_ resource.ResourceWithValidateConfig = &CommentResource{}
+)
+
+func NewCommentResource() resource.Resource {
+	return &CommentResource{}
+}
+
+// CommentResource manages a comment of a page or blog post.
+type CommentResource struct {
+	content  confluence.ContentService
+	comments confluence.CommentService
+}
+
+type CommentResourceModel struct {
+	Id              types.String `tfsdk:"id"`
+	ContentId       types.String `tfsdk:"content_id"`
+	Body            types.String `tfsdk:"body"`
+	Location        types.String `tfsdk:"location"`
+	InlineSelection types.String `tfsdk:"inline_selection"`
+	Version         types.Int64  `tfsdk:"version"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *CommentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_comment"
+}
+
+func (r *CommentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The resource ```comment``` adds a comment to a page or blog post, e.g: deployment notes under a release page. " +
+			"Destroying it deletes only this comment.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Comment identifier.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"content_id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the page or blog post the comment belongs to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"body": schema.StringAttribute{
+				MarkdownDescription: "The body of the comment in storage format.",
+				Required:            true,
+			},
+			"location": schema.StringAttribute{
+				MarkdownDescription: "Where the comment is shown, `footer` below the page or `inline` next to `inline_selection`. Defaults to `footer`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(commentLocationFooter),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(commentLocationFooter, commentLocationInline),
+				},
+			},
+			"inline_selection": schema.StringAttribute{
+				MarkdownDescription: "The text of the page an inline comment is anchored to, it must appear in the page body. Required when `location` is `inline`.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"version": schema.Int64Attribute{
+				MarkdownDescription: "The version of the comment, it is incremented on every update.",
+				Computed:            true,
+			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *CommentResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.content = data.content
+	r.comments = data.comments
+}
+
+// ValidateConfig requires a selection for inline comments and rejects it for
+// footer comments.
+func (r *CommentResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data CommentResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.Location.IsUnknown() || data.InlineSelection.IsUnknown() {
+		return
+	}
+	inline := data.Location.ValueString() == commentLocationInline
+	switch {
+	case inline && data.InlineSelection.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("inline_selection"),
+			"Missing Inline Selection",
+			"Inline comments are anchored to a text of the page, set inline_selection to that text.",
+		)
+	case !inline && !data.InlineSelection.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("inline_selection"),
+			"Invalid Inline Selection",
+			"inline_selection can only be set on inline comments, set location to inline.",
+		)
+	}
+}
+
+func (r *CommentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data CommentResourceModel
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	// Confluence requires the type of the container, a page or a blog post.
+	container, err := r.content.GetContentById(ctx, data.ContentId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read content %s, got error: %s", data.ContentId.ValueString(), err))
+		return
+	}
+	comment := confluence.Content{
+		Type:      "comment",
+		Container: &confluence.Content{Id: container.Id, Type: container.Type},
+		Body:      commentBody(data.Body),
+		Extensions: &confluence.ContentExtensions{
+			Location: data.Location.ValueString(),
+		},
+	}
+	if data.Location.ValueString() == commentLocationInline {
+		comment.Extensions.InlineProperties = &confluence.InlineProperties{
+			OriginalSelection: data.InlineSelection.ValueString(),
+		}
+	}
+	err = r.content.CreateContent(ctx, &comment)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create comment, got error: %s", err))
+		return
+	}
+	data.Id = types.StringValue(comment.Id)
+	data.Version = contentVersion(&comment)
+	tflog.Trace(ctx, "created a comment")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CommentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data CommentResourceModel
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	comment, err := r.comments.GetCommentById(ctx, data.Id.ValueString())
+	if errors.Is(err, confluence.ErrNotFound) {
+		// The comment or its page was deleted outside terraform, it is
+		// created again on the next apply.
+		tflog.Warn(ctx, "comment not found, removing it from the state", map[string]any{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read comment, got error: %s", err))
+		return
+	}
+	if comment.Container != nil {
+		data.ContentId = types.StringValue(comment.Container.Id)
+	}
+	data.Body = types.StringValue(comment.Body.Storage.Value)
+	data.Location = types.StringValue(commentLocationFooter)
+	data.InlineSelection = types.StringNull()
+	if comment.Extensions != nil && comment.Extensions.Location == commentLocationInline {
+		data.Location = types.StringValue(commentLocationInline)
+		if comment.Extensions.InlineProperties != nil {
+			data.InlineSelection = types.StringValue(comment.Extensions.InlineProperties.OriginalSelection)
+		}
+	}
+	data.Version = contentVersion(comment)
+
+	// Set refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CommentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data CommentResourceModel
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	// The comment is read first, confluence requires its title and container
+	// on update.
+	comment, err := r.comments.GetCommentById(ctx, data.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read comment, got error: %s", err))
+		return
+	}
+	comment.Body = commentBody(data.Body)
+	err = r.content.UpdateContent(ctx, comment)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update comment, got error: %s", err))
+		return
+	}
+	data.Version = contentVersion(comment)
+	tflog.Trace(ctx, "updated a comment")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CommentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data CommentResourceModel
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	// Comments are not moved to the trash, confluence deletes them.
+	err := r.content.DeleteContent(ctx, data.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete comment, got error: %s", err))
+		return
+	}
+	tflog.Trace(ctx, "deleted a comment")
+}
+
+// ImportState accepts the id of the comment.
+func (r *CommentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func commentBody(body types.String) confluence.Body {
+	return confluence.Body{
+		Storage: confluence.Storage{
+			Value:          body.ValueString(),
+			Representation: "storage",
+		},
+	}
+}
+
+func contentVersion(c *confluence.Content) types.Int64 {
+	if c.Version == nil {
+		return types.Int64Null()
+	}
+	return types.Int64Value(int64(c.Version.Number))
+}
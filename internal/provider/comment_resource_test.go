@@ -0,0 +1,170 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluencefake"
+)
+
+func TestAccCommentResourceBasic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccCommentResourceConfigBasic("deployed v1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("confluence_comment.test", "content_id", "confluence_content.test", "id"),
+					resource.TestCheckResourceAttr("confluence_comment.test", "body", "<p>deployed v1</p>"),
+					resource.TestCheckResourceAttr("confluence_comment.test", "location", "footer"),
+					resource.TestCheckResourceAttr("confluence_comment.test", "version", "1"),
+					resource.TestCheckResourceAttr("confluence_comment.inline", "location", "inline"),
+					resource.TestCheckResourceAttr("confluence_comment.inline", "inline_selection", "release notes"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "confluence_comment.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccCommentResourceConfigBasic("deployed v2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("confluence_comment.test", "body", "<p>deployed v2</p>"),
+					resource.TestCheckResourceAttr("confluence_comment.test", "version", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCommentResourceConfigBasic(body string) string {
+	return fmt.Sprintf(`
+resource "confluence_content" "test" {
+  type  = "page"
+  title = "Terraform Acc comments"
+  space = "DEVOPS"
+  body  = "<p>release notes</p>"
+}
+
+resource "confluence_comment" "test" {
+  content_id = confluence_content.test.id
+  body       = "<p>%s</p>"
+}
+
+resource "confluence_comment" "inline" {
+  content_id       = confluence_content.test.id
+  body             = "<p>needs a link</p>"
+  location         = "inline"
+  inline_selection = "release notes"
+}
+`, body)
+}
+
+func TestCommentResourceValidateConfig(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		location  string
+		selection types.String
+		wantErr   bool
+	}{
+		{
+			desc:      "Footer comment",
+			location:  commentLocationFooter,
+			selection: types.StringNull(),
+		},
+		{
+			desc:      "Footer comment with selection",
+			location:  commentLocationFooter,
+			selection: types.StringValue("release notes"),
+			wantErr:   true,
+		},
+		{
+			desc:      "Inline comment",
+			location:  commentLocationInline,
+			selection: types.StringValue("release notes"),
+		},
+		{
+			desc:      "Inline comment without selection",
+			location:  commentLocationInline,
+			selection: types.StringNull(),
+			wantErr:   true,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctx := context.Background()
+			r := &CommentResource{}
+			s := configuredResource(t, r, newMockConfluence())
+			data := CommentResourceModel{
+				Id:              types.StringUnknown(),
+				ContentId:       types.StringValue("1001"),
+				Body:            types.StringValue("<p>test</p>"),
+				Location:        types.StringValue(tC.location),
+				InlineSelection: tC.selection,
+				Version:         types.Int64Unknown(),
+				Timeouts:        nullTimeouts(s),
+			}
+
+			state := tfsdk.State{Schema: s}
+			state.Set(ctx, &data)
+			resp := &fwresource.ValidateConfigResponse{}
+			r.ValidateConfig(ctx, fwresource.ValidateConfigRequest{Config: tfsdk.Config{Schema: s, Raw: state.Raw}}, resp)
+			if resp.Diagnostics.HasError() != tC.wantErr {
+				t.Errorf("wants error %v, but got %v", tC.wantErr, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestCommentResourceDeletedOutsideTerraform(t *testing.T) {
+	ctx := context.Background()
+	server := confluencefake.NewServer()
+	t.Cleanup(server.Close)
+	server.AddSpace("DEVOPS", "devops")
+	contentId := server.AddContent("DEVOPS", "page", "Runbooks", "<p>runbooks</p>", "")
+	r := &CommentResource{}
+	s, api := fakeConfiguredResource(t, r, server)
+	data := CommentResourceModel{
+		Id:              types.StringUnknown(),
+		ContentId:       types.StringValue(contentId),
+		Body:            types.StringValue("<p>reviewed</p>"),
+		Location:        types.StringValue(commentLocationFooter),
+		InlineSelection: types.StringNull(),
+		Version:         types.Int64Unknown(),
+		Timeouts:        nullTimeouts(s),
+	}
+
+	plan := tfsdk.Plan{Schema: s}
+	plan.Set(ctx, &data)
+	createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: s}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatal(createResp.Diagnostics)
+	}
+
+	// The comment is deleted in the UI, it is removed from the state.
+	var created CommentResourceModel
+	createResp.State.Get(ctx, &created)
+	err := api.DeleteContent(ctx, created.Id.ValueString())
+	if err != nil {
+		t.Fatal(err)
+	}
+	readResp := &fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatal(readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.IsNull() {
+		t.Error("wants the comment removed from the state")
+	}
+}
@@ -0,0 +1,376 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluence"
+)
+
+var (
+	_ resource.Resource              = &ContentCopyResource{}
+	_ resource.ResourceWithConfigure = &ContentCopyResource{}
+)
+
+func NewContentCopyResource() resource.Resource {
+	return &ContentCopyResource{}
+}
+
+// ContentCopyResource copies a page, or a page with its descendants, under a
+// destination page.
+type ContentCopyResource struct {
+	content   confluence.ContentService
+	hierarchy confluence.PageHierarchyService
+}
+
+type ContentCopyResourceModel struct {
+	Id              types.String `tfsdk:"id"`
+	SourceId        types.String `tfsdk:"source_id"`
+	ParentId        types.String `tfsdk:"parent_id"`
+	IncludeChildren types.Bool   `tfsdk:"include_children"`
+	TitlePrefix     types.String `tfsdk:"title_prefix"`
+	CopyAttachments types.Bool   `tfsdk:"copy_attachments"`
+	CopyLabels      types.Bool   `tfsdk:"copy_labels"`
+	CopyPermissions types.Bool   `tfsdk:"copy_permissions"`
+	Title           types.String `tfsdk:"title"`
+	Space           types.String `tfsdk:"space"`
+	CopiedIds       types.List   `tfsdk:"copied_ids"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *ContentCopyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_content_copy"
+}
+
+func (r *ContentCopyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The resource ```content_copy``` copies a page, or a page with its descendants, under a destination page, " +
+			"e.g: the runbooks of a team copied into a new space as a starting point. " +
+			"The copy is made once, later changes of the source page are not copied. Changing any argument makes a new copy, " +
+			"destroying the resource moves the copied pages to the trash, pages added under the copy later are moved to its parent.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the copied page.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"source_id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the page to copy.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"parent_id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the page the copy is placed under, e.g: the homepage of another space.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"include_children": schema.BoolAttribute{
+				MarkdownDescription: "Whether the descendants of the page are copied with it. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"title_prefix": schema.StringAttribute{
+				MarkdownDescription: "A prefix added to the title of every copied page, e.g: `Copy of `. Titles must be unique in a space, " +
+					"it is required to copy pages into their own space.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"copy_attachments": schema.BoolAttribute{
+				MarkdownDescription: "Whether the attachments of the pages are copied. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"copy_labels": schema.BoolAttribute{
+				MarkdownDescription: "Whether the labels of the pages are copied. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"copy_permissions": schema.BoolAttribute{
+				MarkdownDescription: "Whether the view and edit restrictions of the pages are copied. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"title": schema.StringAttribute{
+				MarkdownDescription: "The title of the copied page.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"space": schema.StringAttribute{
+				MarkdownDescription: "The key of the space of the copied page.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"copied_ids": schema.ListAttribute{
+				MarkdownDescription: "The identifiers of the pages made by the copy, parents first. Destroying the resource moves only them to the trash.",
+				ElementType:         types.StringType,
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *ContentCopyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.content = data.content
+	r.hierarchy = data.hierarchy
+}
+
+func (r *ContentCopyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ContentCopyResourceModel
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	source, err := r.content.GetContentById(ctx, data.SourceId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read source page %s, got error: %s", data.SourceId.ValueString(), err))
+		return
+	}
+	options := confluence.CopyOptions{
+		CopyAttachments: data.CopyAttachments.ValueBool(),
+		CopyLabels:      data.CopyLabels.ValueBool(),
+		CopyPermissions: data.CopyPermissions.ValueBool(),
+	}
+	title := data.TitlePrefix.ValueString() + source.Title
+	var copied *confluence.Content
+	if data.IncludeChildren.ValueBool() {
+		copied, err = r.copyPageHierarchy(ctx, &data, options, title)
+	} else {
+		copied, err = r.hierarchy.CopyContent(ctx, source.Id, confluence.CopyRequest{
+			CopyOptions: options,
+			Destination: confluence.CopyDestination{Type: "parent_page", Value: data.ParentId.ValueString()},
+			PageTitle:   title,
+		})
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to copy page %s, got error: %s", source.Id, err))
+		return
+	}
+	setContentCopyModel(&data, copied)
+	// The descendants of the copy are the copied pages right after the copy,
+	// they are recorded so only they are trashed on delete.
+	copiedIds := []string{copied.Id}
+	if data.IncludeChildren.ValueBool() {
+		copiedIds, diags = r.descendantIds(ctx, copied.Id)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	data.CopiedIds, diags = types.ListValueFrom(ctx, types.StringType, copiedIds)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "copied a page", map[string]any{"source_id": source.Id, "id": copied.Id})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// copyPageHierarchy copies the source page with its descendants and returns
+// the copy of the source page, the long task does not return it.
+func (r *ContentCopyResource) copyPageHierarchy(ctx context.Context, data *ContentCopyResourceModel, options confluence.CopyOptions, title string) (*confluence.Content, error) {
+	req := confluence.CopyPageHierarchyRequest{
+		CopyOptions:       options,
+		DestinationPageId: data.ParentId.ValueString(),
+	}
+	if prefix := data.TitlePrefix.ValueString(); prefix != "" {
+		req.TitleOptions = &confluence.CopyTitleOptions{Prefix: prefix}
+	}
+	_, err := r.hierarchy.CopyPageHierarchy(ctx, data.SourceId.ValueString(), req)
+	if err != nil {
+		return nil, err
+	}
+	children, err := r.hierarchy.GetChildPages(ctx, data.ParentId.ValueString())
+	if err != nil {
+		return nil, err
+	}
+	for _, child := range children {
+		if child.Title == title {
+			return r.content.GetContentById(ctx, child.Id)
+		}
+	}
+	return nil, fmt.Errorf("no copy titled %q under page %s", title, data.ParentId.ValueString())
+}
+
+func (r *ContentCopyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ContentCopyResourceModel
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	copied, err := r.content.GetContentById(ctx, data.Id.ValueString())
+	if errors.Is(err, confluence.ErrNotFound) {
+		// The copy was deleted outside terraform, it is copied again on the
+		// next apply.
+		tflog.Warn(ctx, "copied page not found, removing it from the state", map[string]any{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read copied page, got error: %s", err))
+		return
+	}
+	setContentCopyModel(&data, copied)
+
+	// Set refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update only saves the timeouts, every other argument makes a new copy.
+func (r *ContentCopyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ContentCopyResourceModel
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ContentCopyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ContentCopyResourceModel
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	ids := []string{data.Id.ValueString()}
+	if !data.CopiedIds.IsNull() {
+		resp.Diagnostics.Append(data.CopiedIds.ElementsAs(ctx, &ids, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	// Children are trashed first, confluence moves the children of a
+	// trashed page to its parent, e.g: the pages added under the copy. Pages
+	// already deleted outside terraform are skipped.
+	for i := len(ids) - 1; i >= 0; i-- {
+		err := r.content.DeleteContent(ctx, ids[i])
+		if errors.Is(err, confluence.ErrNotFound) {
+			tflog.Debug(ctx, "copied page already deleted", map[string]any{"id": ids[i]})
+			continue
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete copied page %s, got error: %s", ids[i], err))
+			return
+		}
+	}
+	tflog.Trace(ctx, "deleted a page copy")
+}
+
+// descendantIds returns the page with its descendants, parents first.
+func (r *ContentCopyResource) descendantIds(ctx context.Context, id string) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	ids := []string{id}
+	for i := 0; i < len(ids); i++ {
+		children, err := r.hierarchy.GetChildPages(ctx, ids[i])
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to read the children of copied page %s, got error: %s", ids[i], err))
+			return nil, diags
+		}
+		for _, child := range children {
+			ids = append(ids, child.Id)
+		}
+	}
+	return ids, diags
+}
+
+func setContentCopyModel(data *ContentCopyResourceModel, copied *confluence.Content) {
+	data.Id = types.StringValue(copied.Id)
+	data.Title = types.StringValue(copied.Title)
+	if copied.Space != nil {
+		data.Space = types.StringValue(copied.Space.Key)
+	}
+}
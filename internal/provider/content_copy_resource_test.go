@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluence"
+)
+
+func TestAccContentCopyResourceBasic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccContentCopyResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("confluence_content_copy.page", "title", "Copy of Terraform Acc runbooks"),
+					resource.TestCheckResourceAttr("confluence_content_copy.page", "space", "DEVOPS"),
+					resource.TestCheckResourceAttr("confluence_content_copy.page", "copy_attachments", "true"),
+					resource.TestCheckResourceAttr("confluence_content_copy.tree", "title", "Tree of Terraform Acc runbooks"),
+					resource.TestCheckResourceAttr("confluence_content_copy.tree", "include_children", "true"),
+					resource.TestCheckResourceAttrSet("confluence_content_copy.tree", "id"),
+				),
+			},
+		},
+	})
+}
+
+const testAccContentCopyResourceConfig = `
+resource "confluence_content" "source" {
+  type  = "page"
+  title = "Terraform Acc runbooks"
+  space = "DEVOPS"
+  body  = "<p>runbooks</p>"
+}
+
+resource "confluence_content" "child" {
+  type      = "page"
+  title     = "Terraform Acc restart"
+  space     = "DEVOPS"
+  body      = "<p>restart</p>"
+  parent_id = confluence_content.source.id
+}
+
+resource "confluence_content" "destination" {
+  type  = "page"
+  title = "Terraform Acc copies"
+  space = "DEVOPS"
+  body  = "<p>copies</p>"
+}
+
+resource "confluence_content_copy" "page" {
+  source_id    = confluence_content.source.id
+  parent_id    = confluence_content.destination.id
+  title_prefix = "Copy of "
+}
+
+resource "confluence_content_copy" "tree" {
+  source_id        = confluence_content.source.id
+  parent_id        = confluence_content.destination.id
+  title_prefix     = "Tree of "
+  include_children = true
+
+  depends_on = [confluence_content.child]
+}
+`
+
+func TestContentCopyResourceCRUD(t *testing.T) {
+	testCases := []struct {
+		desc            string
+		includeChildren bool
+		wantCreate      []string
+		wantDelete      []string
+	}{
+		{
+			desc:       "Page",
+			wantCreate: []string{"GetContentById 1001", "CopyContent 1001 1003 Copy of Runbooks"},
+			wantDelete: []string{"DeleteContent 1004"},
+		},
+		{
+			desc:            "Page with children",
+			includeChildren: true,
+			wantCreate: []string{
+				"GetContentById 1001",
+				"CopyPageHierarchy 1001 1003 Copy of",
+				"GetChildPages 1003",
+				"GetContentById 1004",
+				"GetChildPages 1004",
+				"GetChildPages 1005",
+			},
+			wantDelete: []string{
+				"DeleteContent 1005",
+				"DeleteContent 1004",
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctx := context.Background()
+			m := newMockConfluence()
+			r := &ContentCopyResource{}
+			s := configuredResource(t, r, m)
+			sourceId := m.addContent(confluence.Content{Type: "page", Title: "Runbooks", Space: &confluence.Space{Key: "DEVOPS"}, Status: "current"})
+			m.addContent(confluence.Content{Type: "page", Title: "Restart", Space: &confluence.Space{Key: "DEVOPS"}, Ancestors: []confluence.Content{{Id: sourceId}}, Status: "current"})
+			parentId := m.addContent(confluence.Content{Type: "page", Title: "Team", Space: &confluence.Space{Key: "TEAM"}, Status: "current"})
+			data := ContentCopyResourceModel{
+				Id:              types.StringUnknown(),
+				SourceId:        types.StringValue(sourceId),
+				ParentId:        types.StringValue(parentId),
+				IncludeChildren: types.BoolValue(tC.includeChildren),
+				TitlePrefix:     types.StringValue("Copy of "),
+				CopyAttachments: types.BoolValue(true),
+				CopyLabels:      types.BoolValue(true),
+				CopyPermissions: types.BoolValue(false),
+				Title:           types.StringUnknown(),
+				Space:           types.StringUnknown(),
+				CopiedIds:       types.ListUnknown(types.StringType),
+				Timeouts:        nullTimeouts(s),
+			}
+
+			plan := tfsdk.Plan{Schema: s}
+			plan.Set(ctx, &data)
+			createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: s}}
+			r.Create(ctx, fwresource.CreateRequest{Plan: plan}, createResp)
+			if createResp.Diagnostics.HasError() {
+				t.Fatal(createResp.Diagnostics)
+			}
+			assertCalls(t, m, tC.wantCreate...)
+			var got ContentCopyResourceModel
+			createResp.State.Get(ctx, &got)
+			if got.Id.ValueString() != "1004" || got.Title.ValueString() != "Copy of Runbooks" || got.Space.ValueString() != "TEAM" {
+				t.Errorf("wants copy 1004 titled Copy of Runbooks in TEAM, but got %v %v %v", got.Id, got.Title, got.Space)
+			}
+
+			readResp := &fwresource.ReadResponse{State: createResp.State}
+			r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, readResp)
+			if readResp.Diagnostics.HasError() {
+				t.Fatal(readResp.Diagnostics)
+			}
+			assertCalls(t, m, "GetContentById 1004")
+
+			// A page added under the copy later is not trashed with it.
+			addedId := m.addContent(confluence.Content{Type: "page", Title: "Added", Space: &confluence.Space{Key: "TEAM"}, Ancestors: []confluence.Content{{Id: "1004"}}, Status: "current"})
+
+			deleteResp := &fwresource.DeleteResponse{State: readResp.State}
+			r.Delete(ctx, fwresource.DeleteRequest{State: readResp.State}, deleteResp)
+			if deleteResp.Diagnostics.HasError() {
+				t.Fatal(deleteResp.Diagnostics)
+			}
+			assertCalls(t, m, tC.wantDelete...)
+			if got := m.contents[addedId].Status; got != "current" {
+				t.Errorf("wants the added page current, but got %s", got)
+			}
+		})
+	}
+}
+
+func TestContentCopyResourceDeletedOutsideTerraform(t *testing.T) {
+	ctx := context.Background()
+	m := newMockConfluence()
+	r := &ContentCopyResource{}
+	s := configuredResource(t, r, m)
+	sourceId := m.addContent(confluence.Content{Type: "page", Title: "Runbooks", Space: &confluence.Space{Key: "DEVOPS"}, Status: "current"})
+	m.addContent(confluence.Content{Type: "page", Title: "Restart", Space: &confluence.Space{Key: "DEVOPS"}, Ancestors: []confluence.Content{{Id: sourceId}}, Status: "current"})
+	parentId := m.addContent(confluence.Content{Type: "page", Title: "Team", Space: &confluence.Space{Key: "TEAM"}, Status: "current"})
+	data := ContentCopyResourceModel{
+		Id:              types.StringUnknown(),
+		SourceId:        types.StringValue(sourceId),
+		ParentId:        types.StringValue(parentId),
+		IncludeChildren: types.BoolValue(true),
+		TitlePrefix:     types.StringValue("Copy of "),
+		CopyAttachments: types.BoolValue(true),
+		CopyLabels:      types.BoolValue(true),
+		CopyPermissions: types.BoolValue(false),
+		Title:           types.StringUnknown(),
+		Space:           types.StringUnknown(),
+		CopiedIds:       types.ListUnknown(types.StringType),
+		Timeouts:        nullTimeouts(s),
+	}
+
+	plan := tfsdk.Plan{Schema: s}
+	plan.Set(ctx, &data)
+	createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: s}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatal(createResp.Diagnostics)
+	}
+	m.calls = nil
+
+	// The child of the copy is deleted in the UI, destroying the copy
+	// trashes what is left.
+	m.contents["1005"].Status = "trashed"
+	deleteResp := &fwresource.DeleteResponse{State: createResp.State}
+	r.Delete(ctx, fwresource.DeleteRequest{State: createResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatal(deleteResp.Diagnostics)
+	}
+	assertCalls(t, m, "DeleteContent 1005", "DeleteContent 1004")
+
+	// The whole copy is gone, it is removed from the state.
+	readResp := &fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatal(readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.IsNull() {
+		t.Error("wants the copy removed from the state")
+	}
+}
@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/renemontilva/terraform-provider-confluence/internal/pagetree"
+)
+
+// linkedBody returns the body published for the content, the relative links
+// of the body of a content with a source path are resolved to the current
+// title of the pages of page_links.
+func (r *ContentResource) linkedBody(ctx context.Context, data *ContentResourceModel) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if data.SourcePath.IsNull() {
+		return data.Body.ValueString(), diags
+	}
+	pages, diags := contentPageLinks(ctx, data.PageLinks)
+	if diags.HasError() {
+		return "", diags
+	}
+	titles := map[string]string{}
+	body, _ := pagetree.ResolveLinks(data.Body.ValueString(), data.SourcePath.ValueString(), func(target string) (pagetree.Link, bool) {
+		id, ok := contentLinkTarget(pages, target)
+		if !ok || diags.HasError() {
+			return pagetree.Link{}, false
+		}
+		if _, ok := titles[id]; !ok {
+			page, err := r.content.GetContentById(ctx, id)
+			if err != nil {
+				diags.AddError("Client Error", fmt.Sprintf("Unable to read linked page %s of %s, got error: %s", id, target, err))
+				return pagetree.Link{}, false
+			}
+			titles[id] = page.Title
+		}
+		return pagetree.Link{Title: titles[id]}, true
+	})
+	return body, diags
+}
+
+// sourceBody returns the body of the state when the published body is the
+// body of the state with its links resolved, or the published body when it
+// was changed outside terraform. Bodies are compared once normalised, the
+// body of the state keeps the form it was configured with.
+func (r *ContentResource) sourceBody(ctx context.Context, data *ContentResourceModel, published string) types.String {
+	if data.Body.IsNull() {
+		return types.StringValue(published)
+	}
+	if data.SourcePath.IsNull() {
+		if !storageEqual(data.Body.ValueString(), published) {
+			return types.StringValue(published)
+		}
+		return data.Body
+	}
+	body, diags := r.linkedBody(ctx, data)
+	if diags.HasError() || !storageEqual(body, published) {
+		return types.StringValue(published)
+	}
+	return data.Body
+}
+
+// unresolvedLinks returns the relative links of the planned body that are
+// not a page of page_links, nil when they are not known yet.
+func unresolvedLinks(ctx context.Context, data *ContentResourceModel) ([]string, diag.Diagnostics) {
+	if data.SourcePath.IsNull() || data.SourcePath.IsUnknown() || data.Body.IsUnknown() || data.PageLinks.IsUnknown() {
+		return nil, nil
+	}
+	pages, diags := contentPageLinks(ctx, data.PageLinks)
+	if diags.HasError() {
+		return nil, diags
+	}
+	_, unresolved := pagetree.ResolveLinks(data.Body.ValueString(), data.SourcePath.ValueString(), func(target string) (pagetree.Link, bool) {
+		_, ok := contentLinkTarget(pages, target)
+		return pagetree.Link{}, ok
+	})
+	return unresolved, diags
+}
+
+// contentPageLinks returns the page ids of page_links by clean path, the
+// ids are unknown when the pages are created in the same plan.
+func contentPageLinks(ctx context.Context, pageLinks types.Map) (map[string]types.String, diag.Diagnostics) {
+	links := map[string]types.String{}
+	if pageLinks.IsNull() {
+		return links, nil
+	}
+	diags := pageLinks.ElementsAs(ctx, &links, false)
+	pages := make(map[string]types.String, len(links))
+	for file, id := range links {
+		pages[path.Clean(file)] = id
+	}
+	return pages, diags
+}
+
+// contentLinkTarget returns the id of the page of a relative link. Links to
+// files missing from pages are not resolved, the content does not upload
+// local files as attachments unlike the page_tree resource.
+func contentLinkTarget(pages map[string]types.String, target string) (string, bool) {
+	id, ok := pages[path.Clean(target)]
+	return id.ValueString(), ok
+}
@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluence"
+)
+
+const testLinkedBody = `<p><a href="../ops/oncall.md">On-call</a> <a href="diagram.png">diagram</a></p>`
+
+func TestContentResourceLinks(t *testing.T) {
+	ctx := context.Background()
+	m := newMockConfluence()
+	oncallId := m.addContent(confluence.Content{Type: "page", Title: "On-call", Space: &confluence.Space{Key: "DEVOPS"}, Status: "current"})
+	r := &ContentResource{}
+	s := configuredResource(t, r, m)
+
+	data := testContentModel(t, s)
+	data.Body = types.StringValue(testLinkedBody)
+	data.SourcePath = types.StringValue("docs/index.md")
+	pageLinks, diags := types.MapValueFrom(ctx, types.StringType, map[string]string{"ops/oncall.md": oncallId})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	data.PageLinks = pageLinks
+	plan := tfsdk.Plan{Schema: s}
+	plan.Set(ctx, &data)
+	createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: s}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatal(createResp.Diagnostics)
+	}
+	createResp.State.Get(ctx, &data)
+	// The link to a local file is published as plain text, nothing uploads
+	// the file as an attachment.
+	want := `<p><ac:link><ri:page ri:content-title="On-call" /><ac:link-body>On-call</ac:link-body></ac:link> diagram</p>`
+	if got := m.contents[data.Id.ValueString()].Body.Storage.Value; got != want {
+		t.Errorf("wants published body %q, but got %q", want, got)
+	}
+	if data.Body.ValueString() != testLinkedBody {
+		t.Errorf("wants the source body in the state, but got %v", data.Body)
+	}
+
+	readResp := &fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatal(readResp.Diagnostics)
+	}
+	readResp.State.Get(ctx, &data)
+	if data.Body.ValueString() != testLinkedBody {
+		t.Errorf("wants the source body after read, but got %v", data.Body)
+	}
+
+	// The link is published again when the linked page is renamed.
+	m.contents[oncallId].Title = "On-call rotation"
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatal(readResp.Diagnostics)
+	}
+	readResp.State.Get(ctx, &data)
+	if data.Body.ValueString() != want {
+		t.Errorf("wants the published body after the rename, but got %v", data.Body)
+	}
+}
+
+func TestContentResourceModifyPlanLinks(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		pageLinks types.Map
+		wantWarns int
+	}{
+		{
+			desc:      "Resolved",
+			pageLinks: types.MapValueMust(types.StringType, map[string]attr.Value{"ops/oncall.md": types.StringUnknown()}),
+			wantWarns: 1,
+		},
+		{
+			desc:      "Unresolved",
+			pageLinks: types.MapNull(types.StringType),
+			wantWarns: 2,
+		},
+		{
+			desc:      "Unknown",
+			pageLinks: types.MapUnknown(types.StringType),
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctx := context.Background()
+			m := newMockConfluence()
+			r := &ContentResource{}
+			s := configuredResource(t, r, m)
+
+			data := testContentModel(t, s)
+			data.Body = types.StringValue(testLinkedBody)
+			data.SourcePath = types.StringValue("docs/index.md")
+			data.PageLinks = tC.pageLinks
+			config := tfsdk.Config{Schema: s}
+			configState := tfsdk.State{Schema: s}
+			configState.Set(ctx, &data)
+			config.Raw = configState.Raw
+			plan := tfsdk.Plan{Schema: s, Raw: config.Raw}
+			state := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+
+			resp := &fwresource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, fwresource.ModifyPlanRequest{Config: config, Plan: plan, State: state}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+			if got := resp.Diagnostics.WarningsCount(); got != tC.wantWarns {
+				t.Errorf("wants %d warnings, but got %v", tC.wantWarns, resp.Diagnostics)
+			}
+			assertCalls(t, m)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluence"
+)
+
+var (
+	_ datasource.DataSource              = &contentPropertyDataSource{}
+	_ datasource.DataSourceWithConfigure = &contentPropertyDataSource{}
+)
+
+func NewContentPropertyDataSource() datasource.DataSource {
+	return &contentPropertyDataSource{}
+}
+
+type contentPropertyDataSource struct {
+	properties confluence.ContentPropertyService
+}
+
+type ContentPropertyDataSourceModel struct {
+	Id        types.String `tfsdk:"id"`
+	ContentId types.String `tfsdk:"content_id"`
+	Key       types.String `tfsdk:"key"`
+	Value     jsonValue    `tfsdk:"value"`
+	Version   types.Int64  `tfsdk:"version"`
+}
+
+// Metadata returns the data source type name.
+func (d *contentPropertyDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_content_property"
+}
+
+// Schema defines the content property data source schema.
+func (d *contentPropertyDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Returns a property of a page or blog post, the content does not need to be managed by terraform.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Property identifier, the content id and the property key separated by a slash.",
+				Computed:            true,
+			},
+			"content_id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the content the property belongs to.",
+				Required:            true,
+			},
+			"key": schema.StringAttribute{
+				MarkdownDescription: "The key of the property.",
+				Required:            true,
+			},
+			"value": schema.StringAttribute{
+				MarkdownDescription: "The value of the property as a JSON document, use `jsondecode` to read it.",
+				Computed:            true,
+				CustomType:          jsonType{},
+			},
+			"version": schema.Int64Attribute{
+				MarkdownDescription: "The version of the property.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *contentPropertyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ContentPropertyDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	property, err := d.properties.GetContentProperty(ctx, data.ContentId.ValueString(), data.Key.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Content Property Data Source Client Error", err.Error())
+		return
+	}
+	data.Id = types.StringValue(contentPropertyId(data.ContentId.ValueString(), property.Key))
+	data.Key = types.StringValue(property.Key)
+	data.Value = newJSONValue(string(property.Value))
+	data.Version = propertyVersion(property)
+
+	// Set State
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *contentPropertyDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.properties = data.contentProperties
+}
@@ -0,0 +1,261 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluence"
+)
+
+var (
+	_ resource.Resource                = &ContentPropertyResource{}
+	_ resource.ResourceWithConfigure   = &ContentPropertyResource{}
+	_ resource.ResourceWithImportState = &ContentPropertyResource{}
+)
+
+func NewContentPropertyResource() resource.Resource {
+	return &ContentPropertyResource{}
+}
+
+// ContentPropertyResource manages a property of a content.
+type ContentPropertyResource struct {
+	properties confluence.ContentPropertyService
+}
+
+type ContentPropertyResourceModel struct {
+	Id        types.String `tfsdk:"id"`
+	ContentId types.String `tfsdk:"content_id"`
+	Key       types.String `tfsdk:"key"`
+	Value     jsonValue    `tfsdk:"value"`
+	Version   types.Int64  `tfsdk:"version"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *ContentPropertyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_content_property"
+}
+
+func (r *ContentPropertyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The resource ```content_property``` stores a JSON value under a key on a page or blog post, e.g: the source file or the owning team of the page.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Property identifier, the content id and the property key separated by a slash.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"content_id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the content the property belongs to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key": schema.StringAttribute{
+				MarkdownDescription: "The key of the property.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"value": schema.StringAttribute{
+				MarkdownDescription: "The value of the property as a JSON document, e.g: `jsonencode({ team = \"devops\" })`. Whitespace and key order changes are not a diff.",
+				Required:            true,
+				CustomType:          jsonType{},
+			},
+			"version": schema.Int64Attribute{
+				MarkdownDescription: "The version of the property, it is incremented on every update.",
+				Computed:            true,
+			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *ContentPropertyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.properties = data.contentProperties
+}
+
+func (r *ContentPropertyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ContentPropertyResourceModel
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	property := confluence.Property{
+		Key:   data.Key.ValueString(),
+		Value: json.RawMessage(data.Value.ValueString()),
+	}
+	err := r.properties.CreateContentProperty(ctx, data.ContentId.ValueString(), &property)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create content property, got error: %s", err))
+		return
+	}
+	data.Id = types.StringValue(contentPropertyId(data.ContentId.ValueString(), property.Key))
+	data.Version = propertyVersion(&property)
+	tflog.Trace(ctx, "created a content property")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ContentPropertyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ContentPropertyResourceModel
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	property, err := r.properties.GetContentProperty(ctx, data.ContentId.ValueString(), data.Key.ValueString())
+	if errors.Is(err, confluence.ErrNotFound) {
+		// The property or its content was deleted outside terraform, it is
+		// created again on the next apply.
+		tflog.Warn(ctx, "content property not found, removing it from the state", map[string]any{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read content property, got error: %s", err))
+		return
+	}
+	data.Id = types.StringValue(contentPropertyId(data.ContentId.ValueString(), property.Key))
+	data.Key = types.StringValue(property.Key)
+	data.Value = newJSONValue(string(property.Value))
+	data.Version = propertyVersion(property)
+
+	// Set refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ContentPropertyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ContentPropertyResourceModel
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	property := confluence.Property{
+		Key:   data.Key.ValueString(),
+		Value: json.RawMessage(data.Value.ValueString()),
+	}
+	err := r.properties.UpdateContentProperty(ctx, data.ContentId.ValueString(), &property)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update content property, got error: %s", err))
+		return
+	}
+	data.Version = propertyVersion(&property)
+	tflog.Trace(ctx, "updated a content property")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ContentPropertyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ContentPropertyResourceModel
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.properties.DeleteContentProperty(ctx, data.ContentId.ValueString(), data.Key.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete content property, got error: %s", err))
+		return
+	}
+	tflog.Trace(ctx, "deleted a content property")
+}
+
+// ImportState accepts the content id and the property key separated by a slash.
+func (r *ContentPropertyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	contentId, key, ok := strings.Cut(req.ID, "/")
+	if !ok || contentId == "" || key == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import Identifier",
+			fmt.Sprintf("Expected an identifier with the format content_id/key, e.g: 1146920/team, got: %q", req.ID),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("content_id"), contentId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("key"), key)...)
+}
+
+func contentPropertyId(contentId, key string) string {
+	return contentId + "/" + key
+}
+
+// propertyVersion returns the property version number, 0 when confluence
+// did not return it.
+func propertyVersion(p *confluence.Property) types.Int64 {
+	if p.Version == nil {
+		return types.Int64Value(0)
+	}
+	return types.Int64Value(int64(p.Version.Number))
+}
@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluencefake"
+)
+
+func TestAccContentPropertyResourceBasic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccContentPropertyResourceConfigBasic("devops"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("confluence_content_property.test", "key", "owner"),
+					resource.TestCheckResourceAttr("confluence_content_property.test", "value", `{"review":"2024-01-01","team":"devops"}`),
+					resource.TestCheckResourceAttr("confluence_content_property.test", "version", "1"),
+					resource.TestCheckResourceAttr("data.confluence_content_property.test", "value", `{"review":"2024-01-01","team":"devops"}`),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "confluence_content_property.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccContentPropertyResourceConfigBasic("platform"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("confluence_content_property.test", "value", `{"review":"2024-01-01","team":"platform"}`),
+					resource.TestCheckResourceAttr("confluence_content_property.test", "version", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestJSONSemanticEquals(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		current  string
+		new      string
+		wantSame bool
+	}{
+		{
+			desc:     "Whitespace and key order",
+			current:  `{"team": "devops", "tags": ["a", "b"]}`,
+			new:      `{"tags":["a","b"],"team":"devops"}`,
+			wantSame: true,
+		},
+		{
+			desc:    "Different value",
+			current: `{"team":"devops"}`,
+			new:     `{"team":"platform"}`,
+		},
+		{
+			desc:    "Array order",
+			current: `["a","b"]`,
+			new:     `["b","a"]`,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got, diags := newJSONValue(tC.current).StringSemanticEquals(context.Background(), newJSONValue(tC.new))
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+			if got != tC.wantSame {
+				t.Errorf("wants %v, but got %v", tC.wantSame, got)
+			}
+		})
+	}
+}
+
+func testAccContentPropertyResourceConfigBasic(team string) string {
+	return fmt.Sprintf(`
+resource "confluence_content" "test_property" {
+  type  = "page"
+  title = "test content property"
+  space = "DEVOPS"
+  body  = "<p>properties</p>"
+}
+
+resource "confluence_content_property" "test" {
+  content_id = confluence_content.test_property.id
+  key        = "owner"
+  value      = jsonencode({ team = %[1]q, review = "2024-01-01" })
+}
+
+data "confluence_content_property" "test" {
+  content_id = confluence_content_property.test.content_id
+  key        = confluence_content_property.test.key
+}
+`, team)
+}
+
+func TestContentPropertyResourceDeletedOutsideTerraform(t *testing.T) {
+	ctx := context.Background()
+	server := confluencefake.NewServer()
+	t.Cleanup(server.Close)
+	server.AddSpace("DEVOPS", "devops")
+	contentId := server.AddContent("DEVOPS", "page", "Runbooks", "<p>runbooks</p>", "")
+	r := &ContentPropertyResource{}
+	s, api := fakeConfiguredResource(t, r, server)
+	data := ContentPropertyResourceModel{
+		Id:        types.StringUnknown(),
+		ContentId: types.StringValue(contentId),
+		Key:       types.StringValue("owner"),
+		Value:     newJSONValue(`{"team":"devops"}`),
+		Version:   types.Int64Unknown(),
+		Timeouts:  nullTimeouts(s),
+	}
+
+	plan := tfsdk.Plan{Schema: s}
+	plan.Set(ctx, &data)
+	createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: s}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatal(createResp.Diagnostics)
+	}
+
+	// The property is deleted in the UI, it is removed from the state.
+	err := api.DeleteContentProperty(ctx, contentId, "owner")
+	if err != nil {
+		t.Fatal(err)
+	}
+	readResp := &fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatal(readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.IsNull() {
+		t.Error("wants the content property removed from the state")
+	}
+}
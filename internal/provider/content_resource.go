@@ -3,22 +3,44 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"sort"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/renemontilva/terraform-provider-confluence/internal/confluence"
 )
 
+// Content deletion modes, trash is the default confluence behaviour.
+const (
+	deletionModeTrash   = "trash"
+	deletionModePurge   = "purge"
+	deletionModeArchive = "archive"
+)
+
 // Ensure provider defined types fully satisfy framework interfaces
 var (
-	_ resource.Resource                = &ContentResource{}
-	_ resource.ResourceWithConfigure   = &ContentResource{}
-	_ resource.ResourceWithImportState = &ContentResource{}
+	_ resource.Resource                     = &ContentResource{}
+	_ resource.ResourceWithConfigure        = &ContentResource{}
+	_ resource.ResourceWithImportState      = &ContentResource{}
+	_ resource.ResourceWithValidateConfig   = &ContentResource{}
+	_ resource.ResourceWithModifyPlan       = &ContentResource{}
+	_ resource.ResourceWithConfigValidators = &ContentResource{}
 )
 
 func NewContentResource() resource.Resource {
@@ -27,7 +49,11 @@ func NewContentResource() resource.Resource {
 
 // ContentResource defines the resource implementation.
 type ContentResource struct {
-	client *confluence.API
+	content   confluence.ContentService
+	hierarchy confluence.PageHierarchyService
+	labels    confluence.LabelService
+	spaces    confluence.SpaceService
+	templates confluence.TemplateService
 }
 
 // ContentResourceModel describes the resource data model.
@@ -37,6 +63,24 @@ type ContentResourceModel struct {
 	Title types.String `tfsdk:"title"`
 	Space types.String `tfsdk:"space"`
 	Body  types.String `tfsdk:"body"`
+
+	TemplateId         types.String `tfsdk:"template_id"`
+	BlueprintModuleKey types.String `tfsdk:"blueprint_module_key"`
+	TemplateVariables  types.Map    `tfsdk:"template_variables"`
+	ManageBody         types.Bool   `tfsdk:"manage_body"`
+
+	SourcePath types.String `tfsdk:"source_path"`
+	PageLinks  types.Map    `tfsdk:"page_links"`
+
+	ParentId types.String `tfsdk:"parent_id"`
+	AfterId  types.String `tfsdk:"after_id"`
+	BeforeId types.String `tfsdk:"before_id"`
+	Labels   types.Set    `tfsdk:"labels"`
+
+	DeletionMode  types.String `tfsdk:"deletion_mode"`
+	AdoptExisting types.Bool   `tfsdk:"adopt_existing"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *ContentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -65,13 +109,112 @@ func (r *ContentResource) Schema(ctx context.Context, req resource.SchemaRequest
 				Required:            true,
 			},
 			"space": schema.StringAttribute{
-				MarkdownDescription: "The space that the content is being created in.",
+				MarkdownDescription: "The space that the content is being created in, changing it moves a page with its descendants to the space.",
 				Required:            true,
 			},
 			"body": schema.StringAttribute{
-				MarkdownDescription: "The body of the new content.",
-				Required:            true,
+				MarkdownDescription: "The body of the new content. Exactly one of `body`, `template_id` or `blueprint_module_key` must be set, " +
+					"the body rendered from the template is returned otherwise.",
+				Optional: true,
+				Computed: true,
+			},
+			"template_id": schema.StringAttribute{
+				MarkdownDescription: "The id of the page template the body is rendered from, e.g: from the `confluence_templates` data source.",
+				Optional:            true,
+			},
+			"blueprint_module_key": schema.StringAttribute{
+				MarkdownDescription: "The module key of the blueprint template of the space the body is rendered from, e.g: `meeting-notes-page`.",
+				Optional:            true,
+			},
+			"template_variables": schema.MapAttribute{
+				MarkdownDescription: "The values of the template variables, declared in the template with `<at:var at:name=\"name\" />`. " +
+					"Every variable of the template must be set.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"manage_body": schema.BoolAttribute{
+				MarkdownDescription: "Whether the body rendered from the template is owned by terraform, changes made in confluence are reverted. " +
+					"When `false` the template only scaffolds the content on create and page authors take over the body. Defaults to `true`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"source_path": schema.StringAttribute{
+				MarkdownDescription: "The repository relative path of the file the body is written in, e.g: `docs/ops/index.md`. " +
+					"When it is set the relative links of the body, e.g: `<a href=\"../ops/oncall.md\">`, are resolved from its directory " +
+					"to the pages of `page_links`, the body of the state is the unresolved one.",
+				Optional: true,
+			},
+			"page_links": schema.MapAttribute{
+				MarkdownDescription: "The ids of the other pages managed by terraform in the space by repository relative path, " +
+					"e.g: `{ \"docs/ops/oncall.md\" = confluence_content.oncall.id }`. Links to them are published with their current title, a renamed page is linked again on the next apply, " +
+					"links to other files, e.g: images or Markdown files, are reported as warnings and published as plain text, " +
+					"local files are not uploaded as attachments.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Map{
+					mapvalidator.AlsoRequires(path.MatchRoot("source_path")),
+				},
+			},
+			"parent_id": schema.StringAttribute{
+				MarkdownDescription: "The id of the parent page, confluence places the content under the space homepage when it is not set. " +
+					"Changing it moves a page with its descendants.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"after_id": schema.StringAttribute{
+				MarkdownDescription: "The id of the sibling page this page is placed after in the page tree, the page is moved " +
+					"under the parent of the sibling. The order is refreshed, a page reordered outside terraform is placed back on the next apply.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ConflictsWith(path.MatchRoot("before_id")),
+				},
+			},
+			"before_id": schema.StringAttribute{
+				MarkdownDescription: "The id of the sibling page this page is placed before in the page tree, the page is moved " +
+					"under the parent of the sibling. The order is refreshed, a page reordered outside terraform is placed back on the next apply.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"labels": schema.SetAttribute{
+				MarkdownDescription: "Global labels of the content.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"adopt_existing": schema.BoolAttribute{
+				MarkdownDescription: "When a content with the same title and type already exists in the space, take ownership of it " +
+					"and update it to match the configuration instead of failing. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"deletion_mode": schema.StringAttribute{
+				MarkdownDescription: "How the content is removed on destroy, one of `trash`, `purge` or `archive`. " +
+					"`trash` moves the page to the space trash, `purge` removes it permanently so its title can be reused " +
+					"and `archive` moves it to the space archive. Defaults to `trash`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(deletionModeTrash),
+				Validators: []validator.String{
+					stringvalidator.OneOf(deletionModeTrash, deletionModePurge, deletionModeArchive),
+				},
 			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
@@ -82,18 +225,133 @@ func (r *ContentResource) Configure(ctx context.Context, req resource.ConfigureR
 		return
 	}
 
-	client, ok := req.ProviderData.(*confluence.API)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *confluence.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.content = data.content
+	r.hierarchy = data.hierarchy
+	r.labels = data.labels
+	r.spaces = data.spaces
+	r.templates = data.templates
+}
+
+// ConfigValidators requires the body or the template it is rendered from.
+func (r *ContentResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("body"),
+			path.MatchRoot("template_id"),
+			path.MatchRoot("blueprint_module_key"),
+		),
+	}
+}
+
+// ValidateConfig rejects a parent or a sibling for blog posts, they are
+// always placed at the space level, and the template settings on contents
+// with a body.
+func (r *ContentResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ContentResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if data.Type.ValueString() == "blogpost" && !data.ParentId.IsNull() && !data.ParentId.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("parent_id"),
+			"Invalid Blog Post Parent",
+			"Blog posts have no parent page, remove parent_id or use the confluence_blogpost resource.",
+		)
+	}
+	if data.Type.ValueString() == "blogpost" && (!data.AfterId.IsNull() || !data.BeforeId.IsNull()) {
+		resp.Diagnostics.AddError(
+			"Invalid Blog Post Sibling",
+			"Blog posts are not ordered in the page tree, remove after_id and before_id.",
+		)
+	}
+	if data.Body.IsNull() {
+		return
+	}
+	if !data.TemplateVariables.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("template_variables"),
+			"Invalid Template Variables",
+			"template_variables are only rendered into templates, set template_id or blueprint_module_key instead of body.",
+		)
+	}
+	if !data.ManageBody.IsNull() && !data.ManageBody.IsUnknown() && !data.ManageBody.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("manage_body"),
+			"Invalid Manage Body",
+			"manage_body can only be disabled on contents created from a template, remove body or manage_body.",
 		)
+	}
+}
 
+// ModifyPlan renders the template of contents created from a template, the
+// body of the state is kept when the body is not managed after creation.
+// Relative links that can not be resolved are reported as warnings. A page
+// moved to another space without parent is moved under its homepage, and a
+// page placed next to another sibling without parent takes its parent.
+func (r *ContentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.templates == nil {
+		return
+	}
+	var config, plan, state ContentResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !req.State.Raw.IsNull() && config.ParentId.IsNull() &&
+		(!plan.Space.Equal(state.Space) || !plan.AfterId.Equal(state.AfterId) || !plan.BeforeId.Equal(state.BeforeId)) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("parent_id"), types.StringUnknown())...)
+	}
+	if !config.Body.IsNull() {
+		resp.Diagnostics.Append(warnUnresolvedLinks(ctx, &plan)...)
+		return
+	}
+	if !req.State.Raw.IsNull() && !plan.ManageBody.ValueBool() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("body"), state.Body)...)
 		return
 	}
+	// The template is rendered on apply when its settings are not known yet.
+	if plan.TemplateId.IsUnknown() || plan.BlueprintModuleKey.IsUnknown() || plan.Space.IsUnknown() ||
+		plan.TemplateVariables.IsUnknown() || hasUnknownElement(plan.TemplateVariables) {
+		return
+	}
+	body, diags := r.templateBody(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("body"), types.StringValue(body))...)
+	plan.Body = types.StringValue(body)
+	resp.Diagnostics.Append(warnUnresolvedLinks(ctx, &plan)...)
+}
 
-	r.client = client
+// warnUnresolvedLinks reports the relative links of the planned body that
+// can not be resolved.
+func warnUnresolvedLinks(ctx context.Context, plan *ContentResourceModel) diag.Diagnostics {
+	unresolved, diags := unresolvedLinks(ctx, plan)
+	for _, link := range unresolved {
+		diags.AddAttributeWarning(
+			path.Root("page_links"),
+			"Unresolved Link",
+			fmt.Sprintf("The link to %s of %s is not a page of page_links, it is published as plain text.", link, plan.SourcePath.ValueString()),
+		)
+	}
+	return diags
 }
 
 func (r *ContentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -106,33 +364,118 @@ func (r *ContentResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	if data.Body.IsUnknown() {
+		body, diags := r.templateBody(ctx, &data)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		data.Body = types.StringValue(body)
+	}
+	published, diags := r.linkedBody(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Create confluence content struct
 	space := confluence.Space{
 		Key: data.Space.ValueString(),
 	}
 	body := confluence.Body{
 		Storage: confluence.Storage{
-			Value:          data.Body.ValueString(),
+			Value:          published,
 			Representation: "storage",
 		},
 	}
 
 	content := confluence.Content{
-		Type:  data.Type.ValueString(),
-		Title: data.Title.ValueString(),
-		Space: &space,
-		Body:  body,
+		Type:      data.Type.ValueString(),
+		Title:     data.Title.ValueString(),
+		Space:     &space,
+		Ancestors: contentAncestors(data.ParentId),
+		Body:      body,
 		Version: &confluence.Version{
 			Number: int(0),
 		},
 	}
-	err := r.client.CreateContent(&content)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create confluence content, got error: %s", err))
-		return
+	// A current content with the same title is taken over when adopt_existing is set.
+	if data.AdoptExisting.ValueBool() {
+		existing, err := r.content.GetContents(ctx, confluence.ContentQuery{
+			SpaceKey: content.Space.Key,
+			Title:    content.Title,
+			Type:     content.Type,
+			Status:   "current",
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to look up existing confluence content, got error: %s", err))
+			return
+		}
+		if len(existing) > 0 {
+			content.Id = existing[0].Id
+			content.Version = &confluence.Version{}
+			err = r.content.UpdateContent(ctx, &content)
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to adopt existing confluence content %s, got error: %s", content.Id, err))
+				return
+			}
+			resp.Diagnostics.AddWarning(
+				"Adopted existing confluence content",
+				fmt.Sprintf("A %s titled %q already exists in space %s, page id %s is now managed by this resource and was updated to match the configuration.",
+					content.Type, content.Title, content.Space.Key, content.Id),
+			)
+		}
+	}
+
+	// A trashed content keeps its title in the space, restore it instead of
+	// creating a new one that would collide with it.
+	if content.Id == "" {
+		trashed, err := r.content.GetContents(ctx, confluence.ContentQuery{
+			SpaceKey: content.Space.Key,
+			Title:    content.Title,
+			Type:     content.Type,
+			Status:   "trashed",
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to look up trashed confluence content, got error: %s", err))
+			return
+		}
+		if len(trashed) > 0 {
+			content.Id = trashed[0].Id
+			err = r.content.RestoreContent(ctx, &content)
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to restore trashed confluence content %s, got error: %s", content.Id, err))
+				return
+			}
+			tflog.Info(ctx, "restored trashed content", map[string]any{"id": content.Id})
+		}
+	}
+
+	if content.Id == "" {
+		err := r.content.CreateContent(ctx, &content)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create confluence content, got error: %s", err))
+			return
+		}
 	}
 
 	data.Id = types.StringValue(content.Id)
+	resp.Diagnostics.Append(r.orderContent(ctx, &data, nil)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.applyContentComputed(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
 	tflog.Trace(ctx, "created a resource")
@@ -155,9 +498,17 @@ func (r *ContentResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	// If applicable, this is a great opportunity to initialize any necessary
 	// provider client data and make a call using it.
-	content, err := r.client.GetContentById(data.Id.ValueString())
+	content, err := r.content.GetContentById(ctx, data.Id.ValueString())
 
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read content, got error: %s", err))
@@ -173,19 +524,70 @@ func (r *ContentResource) Read(ctx context.Context, req resource.ReadRequest, re
 	data.Type = types.StringValue(content.Type)
 	data.Title = types.StringValue(content.Title)
 	data.Space = types.StringValue(content.Space.Key)
-	// Confluence reponse does not return the body section
-	//data.Body = types.StringValue(content.Body.Storage.Value)
+	data.Body = r.sourceBody(ctx, &data, content.Body.Storage.Value)
+	data.ParentId = contentParentId(content)
+	resp.Diagnostics.Append(r.readSiblings(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	labels, err := r.labels.GetLabels(ctx, content.Id)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read content labels, got error: %s", err))
+		return
+	}
+	data.Labels, diags = types.SetValueFrom(ctx, types.StringType, labelNames(labels))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// Imported contents do not have a deletion mode nor adopt_existing yet
+	if data.DeletionMode.IsNull() {
+		data.DeletionMode = types.StringValue(deletionModeTrash)
+	}
+	if data.AdoptExisting.IsNull() {
+		data.AdoptExisting = types.BoolValue(false)
+	}
+	if data.ManageBody.IsNull() {
+		data.ManageBody = types.BoolValue(true)
+	}
 
 	// Set refreshed state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *ContentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data ContentResourceModel
+	var data, state ContentResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	if data.Body.IsUnknown() {
+		body, diags := r.templateBody(ctx, &data)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		data.Body = types.StringValue(body)
+	}
+	published, diags := r.linkedBody(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.moveContent(ctx, &data, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -197,20 +599,21 @@ func (r *ContentResource) Update(ctx context.Context, req resource.UpdateRequest
 	}
 	body := confluence.Body{
 		Storage: confluence.Storage{
-			Value:          data.Body.ValueString(),
+			Value:          published,
 			Representation: "storage",
 		},
 	}
 	version := confluence.Version{}
 	content := confluence.Content{
-		Id:      data.Id.ValueString(),
-		Type:    data.Type.ValueString(),
-		Title:   data.Title.ValueString(),
-		Space:   &space,
-		Body:    body,
-		Version: &version,
-	}
-	err := r.client.UpdateContent(&content)
+		Id:        data.Id.ValueString(),
+		Type:      data.Type.ValueString(),
+		Title:     data.Title.ValueString(),
+		Space:     &space,
+		Ancestors: contentAncestors(data.ParentId),
+		Body:      body,
+		Version:   &version,
+	}
+	err := r.content.UpdateContent(ctx, &content)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update content from confluence API, got error: %s", err))
 		return
@@ -222,7 +625,16 @@ func (r *ContentResource) Update(ctx context.Context, req resource.UpdateRequest
 	data.Type = types.StringValue(content.Type)
 	data.Title = types.StringValue(content.Title)
 	data.Space = types.StringValue(content.Space.Key)
-	data.Body = types.StringValue(content.Body.Storage.Value)
+	// The body of the state stays the planned one, the body confluence stores
+	// is compared with it in normalised storage format on read.
+	resp.Diagnostics.Append(r.orderContent(ctx, &data, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.applyContentComputed(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -242,15 +654,398 @@ func (r *ContentResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// If applicable, this is a great opportunity to initialize any necessary
 	// provider client data and make a call using it.
-	err := r.client.DeleteContent(data.Id.ValueString())
+	var err error
+	switch data.DeletionMode.ValueString() {
+	case deletionModeArchive:
+		err = r.content.ArchiveContent(ctx, data.Id.ValueString())
+	case deletionModePurge:
+		err = r.content.DeleteContent(ctx, data.Id.ValueString())
+		if err == nil {
+			err = r.content.PurgeContent(ctx, data.Id.ValueString())
+		}
+	default:
+		err = r.content.DeleteContent(ctx, data.Id.ValueString())
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete content from confluence API, got error: %s", err))
 		return
 	}
 }
 
+// ImportState accepts a content id, SPACEKEY/Page Title, SPACEKEY:Ancestor/Path/Page Title
+// or a page URL and resolves it to the content id.
 func (r *ContentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importId, err := parseContentImportId(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import Identifier", err.Error())
+		return
+	}
+	if importId.Id == "" {
+		importId.Id, err = r.resolveContentImportId(ctx, importId)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to resolve content %q, got error: %s", req.ID, err))
+			return
+		}
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), importId.Id)...)
+}
+
+// applyContentComputed writes the parent and labels into the model, labels
+// set in the plan are applied to the content first.
+func (r *ContentResource) applyContentComputed(ctx context.Context, data *ContentResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	id := data.Id.ValueString()
+	if !data.Labels.IsUnknown() && !data.Labels.IsNull() {
+		var want []string
+		diags.Append(data.Labels.ElementsAs(ctx, &want, false)...)
+		if diags.HasError() {
+			return diags
+		}
+		err := r.setContentLabels(ctx, id, want)
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to set content labels, got error: %s", err))
+			return diags
+		}
+	}
+	if data.Labels.IsUnknown() || data.Labels.IsNull() {
+		labels, err := r.labels.GetLabels(ctx, id)
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to read content labels, got error: %s", err))
+			return diags
+		}
+		var d diag.Diagnostics
+		data.Labels, d = types.SetValueFrom(ctx, types.StringType, labelNames(labels))
+		diags.Append(d...)
+	}
+	if data.ParentId.IsUnknown() {
+		content, err := r.content.GetContentById(ctx, id)
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to read content parent, got error: %s", err))
+			return diags
+		}
+		data.ParentId = contentParentId(content)
+	}
+	return diags
+}
+
+// moveContent moves a page with its descendants when its space or its parent
+// changed, the update can not change them. A page moved to another space
+// without parent is moved under the homepage of the space.
+func (r *ContentResource) moveContent(ctx context.Context, data, state *ContentResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	moved := !data.Space.Equal(state.Space) || (!data.ParentId.IsUnknown() && data.ParentId.ValueString() != state.ParentId.ValueString())
+	if data.Type.ValueString() != "page" || !moved {
+		return diags
+	}
+	targetId := data.ParentId.ValueString()
+	if targetId == "" {
+		// Only the space listing expands the homepage.
+		spaces, err := r.spaces.ListSpaces(ctx, confluence.SpaceQuery{Keys: []string{data.Space.ValueString()}})
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to read the homepage of space %s, got error: %s", data.Space.ValueString(), err))
+			return diags
+		}
+		if len(spaces) == 0 || spaces[0].Homepage == nil {
+			diags.AddError("Client Error", fmt.Sprintf("Space %s has no homepage to move the page under, set parent_id", data.Space.ValueString()))
+			return diags
+		}
+		targetId = spaces[0].Homepage.Id
+		data.ParentId = types.StringValue(targetId)
+	}
+	err := r.hierarchy.MoveContent(ctx, data.Id.ValueString(), confluence.MovePositionAppend, targetId)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to move content %s under %s, got error: %s", data.Id.ValueString(), targetId, err))
+		return diags
+	}
+	tflog.Info(ctx, "moved content", map[string]any{"id": data.Id.ValueString(), "parent_id": targetId})
+	return diags
+}
+
+// orderContent places a page before or after its sibling when the sibling,
+// the parent or the space changed. The sibling must be under parent_id when
+// it is set, confluence moves the page under the parent of the sibling.
+func (r *ContentResource) orderContent(ctx context.Context, data, state *ContentResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	position, targetId := confluence.MovePositionAfter, data.AfterId.ValueString()
+	if !data.BeforeId.IsNull() {
+		position, targetId = confluence.MovePositionBefore, data.BeforeId.ValueString()
+	}
+	if targetId == "" {
+		return diags
+	}
+	if state != nil && data.AfterId.Equal(state.AfterId) && data.BeforeId.Equal(state.BeforeId) &&
+		data.ParentId.Equal(state.ParentId) && data.Space.Equal(state.Space) {
+		return diags
+	}
+	if !data.ParentId.IsUnknown() && data.ParentId.ValueString() != "" {
+		target, err := r.content.GetContentById(ctx, targetId)
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to read sibling page %s, got error: %s", targetId, err))
+			return diags
+		}
+		if parentId := contentParentId(target); !parentId.Equal(data.ParentId) {
+			diags.AddError(
+				"Invalid Sibling",
+				fmt.Sprintf("Page %s is under parent %s, not under parent_id %s, set a sibling under parent_id.", targetId, parentId.ValueString(), data.ParentId.ValueString()),
+			)
+			return diags
+		}
+	}
+	err := r.hierarchy.MoveContent(ctx, data.Id.ValueString(), position, targetId)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to place content %s %s %s, got error: %s", data.Id.ValueString(), position, targetId, err))
+		return diags
+	}
+	tflog.Info(ctx, "ordered content", map[string]any{"id": data.Id.ValueString(), "position": position, "target_id": targetId})
+	return diags
+}
+
+// readSiblings refreshes after_id and before_id from the position of the
+// children of the parent, they are empty when the page is the first or the
+// last child. Pages without parent are not refreshed.
+func (r *ContentResource) readSiblings(ctx context.Context, data *ContentResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if (data.AfterId.IsNull() && data.BeforeId.IsNull()) || data.ParentId.ValueString() == "" {
+		return diags
+	}
+	children, err := r.hierarchy.GetChildPages(ctx, data.ParentId.ValueString())
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read the children of page %s, got error: %s", data.ParentId.ValueString(), err))
+		return diags
+	}
+	sortChildPages(children)
+	for i, child := range children {
+		if child.Id != data.Id.ValueString() {
+			continue
+		}
+		if !data.AfterId.IsNull() {
+			data.AfterId = types.StringValue("")
+			if i > 0 {
+				data.AfterId = types.StringValue(children[i-1].Id)
+			}
+		}
+		if !data.BeforeId.IsNull() {
+			data.BeforeId = types.StringValue("")
+			if i < len(children)-1 {
+				data.BeforeId = types.StringValue(children[i+1].Id)
+			}
+		}
+	}
+	return diags
+}
+
+// sortChildPages orders child pages by their position, the order of the
+// listing is not the order of the page tree. Pages that were never ordered
+// have no position, they come after the ordered ones in the listing order.
+func sortChildPages(children []confluence.Content) {
+	position := func(c confluence.Content) (int, bool) {
+		if c.Extensions == nil || c.Extensions.Position == nil {
+			return 0, false
+		}
+		return *c.Extensions.Position, true
+	}
+	sort.SliceStable(children, func(i, j int) bool {
+		pi, iOk := position(children[i])
+		pj, jOk := position(children[j])
+		if iOk != jOk {
+			return iOk
+		}
+		return pi < pj
+	})
+}
+
+// setContentLabels adds and removes labels until the content has the wanted ones.
+func (r *ContentResource) setContentLabels(ctx context.Context, id string, want []string) error {
+	current, err := r.labels.GetLabels(ctx, id)
+	if err != nil {
+		return err
+	}
+	have := map[string]bool{}
+	for _, name := range labelNames(current) {
+		have[name] = true
+	}
+	add := []confluence.Label{}
+	for _, name := range want {
+		if !have[name] {
+			add = append(add, confluence.Label{Name: name})
+		}
+		delete(have, name)
+	}
+	if len(add) > 0 {
+		err = r.labels.AddLabels(ctx, id, add)
+		if err != nil {
+			return err
+		}
+	}
+	for name := range have {
+		err = r.labels.DeleteLabel(ctx, id, name)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveContentImportId looks up the content by space key and title, when an
+// ancestor path is given the content ancestors titles must end with it.
+func (r *ContentResource) resolveContentImportId(ctx context.Context, importId contentImportId) (string, error) {
+	contents, err := r.content.GetContents(ctx, confluence.ContentQuery{
+		SpaceKey: importId.SpaceKey,
+		Title:    importId.Title,
+		Status:   "current",
+		Expand:   []string{"ancestors"},
+	})
+	if err != nil {
+		return "", err
+	}
+	matches := []string{}
+	for _, content := range contents {
+		if hasAncestorTitles(content.Ancestors, importId.Ancestors) {
+			matches = append(matches, content.Id)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no content titled %q found in space %s", importId.Title, importId.SpaceKey)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("%d contents titled %q found in space %s, import it by id instead: %s",
+			len(matches), importId.Title, importId.SpaceKey, strings.Join(matches, ", "))
+	}
+}
+
+// contentImportId is an import identifier split into its parts, either Id is
+// set or the content must be looked up by SpaceKey, Title and Ancestors titles.
+type contentImportId struct {
+	Id        string
+	SpaceKey  string
+	Title     string
+	Ancestors []string
+}
+
+func parseContentImportId(id string) (contentImportId, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return contentImportId{}, fmt.Errorf("import identifier is empty")
+	}
+	if isContentId(id) {
+		return contentImportId{Id: id}, nil
+	}
+	if strings.HasPrefix(id, "https://") || strings.HasPrefix(id, "http://") {
+		return parseContentURL(id)
+	}
+	key, rest, found := strings.Cut(id, "/")
+	if !found || rest == "" {
+		return contentImportId{}, fmt.Errorf("expected a content id, SPACEKEY/Page Title, SPACEKEY:Ancestor/Page Title or a page URL, got: %q", id)
+	}
+	// SPACEKEY:Ancestor/Path/Page Title
+	if spaceKey, ancestor, ok := strings.Cut(key, ":"); ok {
+		segments := strings.Split(rest, "/")
+		return contentImportId{
+			SpaceKey:  spaceKey,
+			Title:     segments[len(segments)-1],
+			Ancestors: append([]string{ancestor}, segments[:len(segments)-1]...),
+		}, nil
+	}
+	return contentImportId{SpaceKey: key, Title: rest}, nil
+}
+
+// parseContentURL understands cloud page URLs (/wiki/spaces/KEY/pages/ID/Title),
+// viewpage URLs (?pageId=ID) and display URLs (/display/KEY/Title).
+func parseContentURL(raw string) (contentImportId, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return contentImportId{}, fmt.Errorf("invalid page URL %q: %w", raw, err)
+	}
+	if pageId := u.Query().Get("pageId"); isContentId(pageId) {
+		return contentImportId{Id: pageId}, nil
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i, segment := range segments {
+		switch segment {
+		case "pages":
+			if i+1 < len(segments) && isContentId(segments[i+1]) {
+				return contentImportId{Id: segments[i+1]}, nil
+			}
+		case "display":
+			if i+2 < len(segments) {
+				title, err := url.QueryUnescape(segments[i+2])
+				if err != nil {
+					return contentImportId{}, fmt.Errorf("invalid page title in URL %q: %w", raw, err)
+				}
+				return contentImportId{SpaceKey: segments[i+1], Title: title}, nil
+			}
+		}
+	}
+	return contentImportId{}, fmt.Errorf("page URL %q does not contain a page id nor a space key and title", raw)
+}
+
+func isContentId(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// hasAncestorTitles reports whether the closest ancestors titles match titles.
+func hasAncestorTitles(ancestors []confluence.Content, titles []string) bool {
+	if len(titles) > len(ancestors) {
+		return false
+	}
+	offset := len(ancestors) - len(titles)
+	for i, title := range titles {
+		if ancestors[offset+i].Title != title {
+			return false
+		}
+	}
+	return true
+}
+
+func contentAncestors(parentId types.String) []confluence.Content {
+	if parentId.IsNull() || parentId.IsUnknown() || parentId.ValueString() == "" {
+		return nil
+	}
+	return []confluence.Content{{Id: parentId.ValueString()}}
+}
+
+// contentParentId returns the closest ancestor, which is the parent page.
+func contentParentId(content *confluence.Content) types.String {
+	if len(content.Ancestors) == 0 {
+		return types.StringNull()
+	}
+	return types.StringValue(content.Ancestors[len(content.Ancestors)-1].Id)
+}
+
+func labelNames(labels []confluence.Label) []string {
+	names := make([]string, 0, len(labels))
+	for _, label := range labels {
+		names = append(names, label.Name)
+	}
+	return names
+}
+
+// hasUnknownElement reports whether a known map holds an unknown value.
+func hasUnknownElement(m types.Map) bool {
+	for _, v := range m.Elements() {
+		if v.IsUnknown() {
+			return true
+		}
+	}
+	return false
 }
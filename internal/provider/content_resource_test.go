@@ -1,10 +1,17 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"os"
 	"testing"
 
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluence"
 )
 
 func TestAccContentResourceBasic(t *testing.T) {
@@ -19,14 +26,23 @@ func TestAccContentResourceBasic(t *testing.T) {
 					resource.TestCheckResourceAttr("confluence_content.test", "body", "<h1>Terraform Acc test create</h1><p>paragraph</p>"),
 					resource.TestCheckResourceAttr("confluence_content.test", "space", "DEVOPS"),
 					resource.TestCheckResourceAttr("confluence_content.test", "title", "test create"),
+					resource.TestCheckResourceAttr("confluence_content.test", "deletion_mode", "trash"),
+					resource.TestCheckResourceAttr("confluence_content.test", "labels.#", "1"),
+					resource.TestCheckResourceAttrSet("confluence_content.test", "parent_id"),
 				),
 			},
 			// ImportState testing
 			{
-				ResourceName: "confluence_content.test",
-				ImportState:  true,
-				// FIXME: Because the GetContentById does not retrieve body field, rease why is set to false
-				ImportStateVerify: false,
+				ResourceName:      "confluence_content.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// ImportState by space key and title
+			{
+				ResourceName:      "confluence_content.test",
+				ImportState:       true,
+				ImportStateId:     "DEVOPS/test create",
+				ImportStateVerify: true,
 			},
 			// Update and Read testing
 			{
@@ -80,6 +96,260 @@ func TestAccContentResourceTemplate(t *testing.T) {
 
 }
 
+func TestAccContentResourceFromTemplate(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccContentResourceConfigFromTemplate("billing"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("confluence_content.runbook", "body", "<h1>billing runbook</h1>"),
+					resource.TestCheckResourceAttr("confluence_content.meeting", "body", "<h2>Date</h2><p>2023-05-01</p><h2>Attendees</h2><p>devops</p><h2>Action items</h2>"),
+				),
+			},
+			// Update and Read testing, the unmanaged body is not rendered again
+			{
+				Config: testAccContentResourceConfigFromTemplate("payments"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("confluence_content.runbook", "body", "<h1>payments runbook</h1>"),
+					resource.TestCheckResourceAttr("confluence_content.meeting", "body", "<h2>Date</h2><p>2023-05-01</p><h2>Attendees</h2><p>devops</p><h2>Action items</h2>"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccContentResourceLinks(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing, the state keeps the relative link
+			{
+				Config: testAccContentResourceConfigLinks("On-call"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("confluence_content.index", "body", `<p><a href="ops/oncall.md#paging">On-call</a></p>`),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccContentResourceConfigLinks("Paging"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("confluence_content.index", "body", `<p><a href="ops/oncall.md#paging">Paging</a></p>`),
+				),
+			},
+		},
+	})
+}
+
+func testAccContentResourceConfigLinks(text string) string {
+	return fmt.Sprintf(`
+	resource "confluence_content" "oncall" {
+		type  = "page"
+		space = "DEVOPS"
+		title = "On-call"
+		body  = "<h2>Paging</h2>"
+	}
+
+	resource "confluence_content" "index" {
+		type        = "page"
+		space       = "DEVOPS"
+		title       = "Operations links"
+		body        = "<p><a href=\"ops/oncall.md#paging\">%s</a></p>"
+		source_path = "index.md"
+		page_links = {
+			"ops/oncall.md" = confluence_content.oncall.id
+		}
+	}
+	`, text)
+}
+
+func TestAccContentResourceOrder(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing, the page is placed between its siblings
+			{
+				Config: testAccContentResourceConfigOrder("after_id"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("confluence_content.order", "after_id", "confluence_content.deploy", "id"),
+					resource.TestCheckResourceAttrPair("confluence_content.order", "parent_id", "confluence_content.runbooks", "id"),
+				),
+			},
+			// Update and Read testing, the page is moved before its sibling
+			{
+				Config: testAccContentResourceConfigOrder("before_id"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("confluence_content.order", "before_id", "confluence_content.deploy", "id"),
+					resource.TestCheckNoResourceAttr("confluence_content.order", "after_id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccContentResourceConfigOrder(position string) string {
+	return fmt.Sprintf(`
+	resource "confluence_content" "runbooks" {
+		type  = "page"
+		space = "DEVOPS"
+		title = "Terraform Acc ordered runbooks"
+		body  = "<p>runbooks</p>"
+	}
+
+	resource "confluence_content" "deploy" {
+		type      = "page"
+		space     = "DEVOPS"
+		title     = "Terraform Acc deploy"
+		body      = "<p>deploy</p>"
+		parent_id = confluence_content.runbooks.id
+	}
+
+	resource "confluence_content" "rollback" {
+		type      = "page"
+		space     = "DEVOPS"
+		title     = "Terraform Acc rollback"
+		body      = "<p>rollback</p>"
+		parent_id = confluence_content.runbooks.id
+
+		depends_on = [confluence_content.deploy]
+	}
+
+	resource "confluence_content" "order" {
+		type      = "page"
+		space     = "DEVOPS"
+		title     = "Terraform Acc order"
+		body      = "<p>order</p>"
+		parent_id = confluence_content.runbooks.id
+		%s  = confluence_content.deploy.id
+
+		depends_on = [confluence_content.rollback]
+	}
+	`, position)
+}
+
+func TestAccContentResourceDeletionMode(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create a purged page, the title must be free again once it is destroyed
+			{
+				Config: testAccContentResourceConfigDeletionMode("test purge", "purge"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("confluence_content.test_deletion", "title", "test purge"),
+					resource.TestCheckResourceAttr("confluence_content.test_deletion", "deletion_mode", "purge"),
+				),
+			},
+			{
+				Config:  testAccContentResourceConfigDeletionMode("test purge", "purge"),
+				Destroy: true,
+			},
+			// Re-create a page with the same title
+			{
+				Config: testAccContentResourceConfigDeletionMode("test purge", "trash"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("confluence_content.test_deletion", "title", "test purge"),
+					resource.TestCheckResourceAttr("confluence_content.test_deletion", "deletion_mode", "trash"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccContentResourceAdoptExisting(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Adopt a page created outside of terraform
+			{
+				PreConfig: func() {
+					testAccCreateContent(t, "test adopt")
+				},
+				Config: testAccContentResourceConfigAdoptExisting("test adopt"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("confluence_content.test_adopt", "title", "test adopt"),
+					resource.TestCheckResourceAttr("confluence_content.test_adopt", "adopt_existing", "true"),
+					resource.TestCheckResourceAttrSet("confluence_content.test_adopt", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestParseContentImportId(t *testing.T) {
+	testCases := []struct {
+		desc string
+		id   string
+		want contentImportId
+	}{
+		{
+			desc: "Content id",
+			id:   "1146920",
+			want: contentImportId{Id: "1146920"},
+		},
+		{
+			desc: "Space key and title",
+			id:   "DEVOPS/Runbooks / On call",
+			want: contentImportId{SpaceKey: "DEVOPS", Title: "Runbooks / On call"},
+		},
+		{
+			desc: "Space key and ancestor path",
+			id:   "DEVOPS:Runbooks/Databases/Failover",
+			want: contentImportId{SpaceKey: "DEVOPS", Title: "Failover", Ancestors: []string{"Runbooks", "Databases"}},
+		},
+		{
+			desc: "Cloud page URL",
+			id:   "https://example.atlassian.net/wiki/spaces/DEVOPS/pages/1146920/Failover",
+			want: contentImportId{Id: "1146920"},
+		},
+		{
+			desc: "Viewpage URL",
+			id:   "https://confluence.example.com/pages/viewpage.action?pageId=1146920",
+			want: contentImportId{Id: "1146920"},
+		},
+		{
+			desc: "Display URL",
+			id:   "https://confluence.example.com/display/DEVOPS/On+call",
+			want: contentImportId{SpaceKey: "DEVOPS", Title: "On call"},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got, err := parseContentImportId(tC.id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tC.want) {
+				t.Errorf("wants %+v, but got %+v", tC.want, got)
+			}
+		})
+	}
+}
+
+// testAccCreateContent creates a page outside of terraform.
+func testAccCreateContent(t *testing.T, title string) {
+	client, err := confluence.NewAPI(os.Getenv("CONFLUENCE_USER"), os.Getenv("CONFLUENCE_TOKEN"), os.Getenv("CONFLUENCE_HOST"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = client.CreateContent(context.Background(), &confluence.Content{
+		Type:  "page",
+		Title: title,
+		Space: &confluence.Space{Key: "DEVOPS"},
+		Body: confluence.Body{
+			Storage: confluence.Storage{Value: "<p>created outside of terraform</p>", Representation: "storage"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
 // Test resource content with a template file
 
 // The following functions return a resource config
@@ -90,6 +360,7 @@ resource "confluence_content" "test" {
   title = "%s"
   space = "DEVOPS"
   body = "<h1>Terraform Acc %s</h1><p>paragraph</p>"
+  labels = ["terraform"]
 }`, title, title)
 }
 
@@ -102,3 +373,489 @@ resource "confluence_content" "test_template" {
   body = templatefile("testfiles/test_content.tftpl", {name="%v"}) 
 }`, title, variable)
 }
+
+func testAccContentResourceConfigFromTemplate(service string) string {
+	return fmt.Sprintf(`
+resource "confluence_template" "runbook" {
+  name  = "Terraform Acc runbook"
+  body  = "<h1><at:var at:name=\"service\" /> runbook</h1>"
+  space = "DEVOPS"
+}
+
+resource "confluence_content" "runbook" {
+  type               = "page"
+  title              = "Terraform Acc runbook"
+  space              = "DEVOPS"
+  template_id        = confluence_template.runbook.id
+  template_variables = { service = %[1]q }
+}
+
+resource "confluence_content" "meeting" {
+  type                 = "page"
+  title                = "Terraform Acc meeting"
+  space                = "DEVOPS"
+  blueprint_module_key = "meeting-notes-page"
+  template_variables   = { date = "2023-05-01", attendees = "devops" }
+  manage_body          = false
+}`, service)
+}
+
+func testAccContentResourceConfigDeletionMode(title, mode string) string {
+	return fmt.Sprintf(`
+resource "confluence_content" "test_deletion" {
+  type = "page"
+  title = "%s"
+  space = "DEVOPS"
+  body = "<p>Terraform Acc %s</p>"
+  deletion_mode = "%s"
+}`, title, title, mode)
+}
+
+func testAccContentResourceConfigAdoptExisting(title string) string {
+	return fmt.Sprintf(`
+resource "confluence_content" "test_adopt" {
+  type = "page"
+  title = "%s"
+  space = "DEVOPS"
+  body = "<p>Terraform Acc %s</p>"
+  adopt_existing = true
+}`, title, title)
+}
+
+func TestContentResourceCreate(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		adoptExisting bool
+		// existing is the status of a content with the same title, empty for none.
+		existing  string
+		errs      map[string]error
+		wantCalls []string
+		wantErr   bool
+	}{
+		{
+			desc: "Create a new content",
+			wantCalls: []string{
+				"GetContents DEVOPS test create trashed",
+				"CreateContent DEVOPS test create",
+				"GetLabels 1001",
+				"AddLabels 1001 terraform",
+				"GetContentById 1001",
+			},
+		},
+		{
+			desc:     "Restore a trashed content",
+			existing: "trashed",
+			wantCalls: []string{
+				"GetContents DEVOPS test create trashed",
+				"RestoreContent 1001",
+				"GetLabels 1001",
+				"AddLabels 1001 terraform",
+				"GetContentById 1001",
+			},
+		},
+		{
+			desc:          "Adopt an existing content",
+			adoptExisting: true,
+			existing:      "current",
+			wantCalls: []string{
+				"GetContents DEVOPS test create current",
+				"UpdateContent 1001 test create",
+				"GetLabels 1001",
+				"AddLabels 1001 terraform",
+				"GetContentById 1001",
+			},
+		},
+		{
+			desc: "Create error",
+			errs: map[string]error{"CreateContent": fmt.Errorf("boom")},
+			wantCalls: []string{
+				"GetContents DEVOPS test create trashed",
+				"CreateContent DEVOPS test create",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctx := context.Background()
+			m := newMockConfluence()
+			m.errs = tC.errs
+			if tC.existing != "" {
+				m.addContent(confluence.Content{Type: "page", Title: "test create", Space: &confluence.Space{Key: "DEVOPS"}, Status: tC.existing})
+			}
+			r := &ContentResource{}
+			s := configuredResource(t, r, m)
+			data := testContentModel(t, s)
+			data.AdoptExisting = types.BoolValue(tC.adoptExisting)
+
+			req := fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: s}}
+			req.Plan.Set(ctx, &data)
+			resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: s}}
+			r.Create(ctx, req, resp)
+
+			assertCalls(t, m, tC.wantCalls...)
+			if resp.Diagnostics.HasError() != tC.wantErr {
+				t.Fatalf("wants error %v, but got %v", tC.wantErr, resp.Diagnostics)
+			}
+			if tC.wantErr {
+				return
+			}
+			var got ContentResourceModel
+			resp.State.Get(ctx, &got)
+			if got.Id.ValueString() != "1001" {
+				t.Errorf("wants id 1001, but got %v", got.Id)
+			}
+			if got.ParentId.ValueString() != "" {
+				t.Errorf("wants no parent_id, but got %v", got.ParentId)
+			}
+			if tC.adoptExisting && resp.Diagnostics.WarningsCount() != 1 {
+				t.Errorf("wants an adopted content warning, but got %v", resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestContentResourceReadUpdateDelete(t *testing.T) {
+	ctx := context.Background()
+	m := newMockConfluence()
+	r := &ContentResource{}
+	s := configuredResource(t, r, m)
+	id := m.addContent(confluence.Content{Type: "page", Title: "test create", Space: &confluence.Space{Key: "DEVOPS"}, Status: "current"})
+	m.labels[id] = []confluence.Label{{Name: "obsolete"}}
+
+	data := testContentModel(t, s)
+	data.Id = types.StringValue(id)
+	data.ParentId = types.StringValue("")
+	state := tfsdk.State{Schema: s}
+	state.Set(ctx, &data)
+
+	readResp := &fwresource.ReadResponse{State: state}
+	r.Read(ctx, fwresource.ReadRequest{State: state}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatal(readResp.Diagnostics)
+	}
+	assertCalls(t, m, "GetContentById 1001", "GetLabels 1001")
+	var got ContentResourceModel
+	readResp.State.Get(ctx, &got)
+	if fmt.Sprint(got.Labels) != `["obsolete"]` {
+		t.Errorf("wants labels [obsolete], but got %v", got.Labels)
+	}
+
+	data.Title = types.StringValue("test update")
+	plan := tfsdk.Plan{Schema: s}
+	plan.Set(ctx, &data)
+	updateResp := &fwresource.UpdateResponse{State: readResp.State}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: readResp.State}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatal(updateResp.Diagnostics)
+	}
+	assertCalls(t, m, "UpdateContent 1001 test update", "GetLabels 1001", "AddLabels 1001 terraform", "DeleteLabel 1001 obsolete")
+
+	testCases := []struct {
+		desc      string
+		mode      string
+		wantCalls []string
+	}{
+		{
+			desc:      "Trash",
+			mode:      deletionModeTrash,
+			wantCalls: []string{"DeleteContent 1001"},
+		},
+		{
+			desc:      "Purge",
+			mode:      deletionModePurge,
+			wantCalls: []string{"DeleteContent 1001", "PurgeContent 1001"},
+		},
+		{
+			desc:      "Archive",
+			mode:      deletionModeArchive,
+			wantCalls: []string{"ArchiveContent 1001"},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			m.contents[id] = &confluence.Content{Id: id, Status: "current"}
+			data.DeletionMode = types.StringValue(tC.mode)
+			state := tfsdk.State{Schema: s}
+			state.Set(ctx, &data)
+			resp := &fwresource.DeleteResponse{State: state}
+			r.Delete(ctx, fwresource.DeleteRequest{State: state}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+			assertCalls(t, m, tC.wantCalls...)
+		})
+	}
+}
+
+func TestContentResourceReadNormalisedBody(t *testing.T) {
+	ctx := context.Background()
+	m := newMockConfluence()
+	r := &ContentResource{}
+	s := configuredResource(t, r, m)
+	id := m.addContent(confluence.Content{Type: "page", Title: "test create", Space: &confluence.Space{Key: "DEVOPS"}, Status: "current"})
+	data := testContentModel(t, s)
+	data.Id = types.StringValue(id)
+	data.Body = types.StringValue(`<p class='intro'>first<br>second&nbsp;line</p>`)
+
+	testCases := []struct {
+		desc   string
+		stored string
+		want   string
+	}{
+		{
+			desc:   "Normalised by confluence",
+			stored: "<p class=\"intro\">first<br />second\u00a0line</p>",
+			want:   `<p class='intro'>first<br>second&nbsp;line</p>`,
+		},
+		{
+			desc:   "Changed outside terraform",
+			stored: "<p class=\"intro\">edited</p>",
+			want:   "<p class=\"intro\">edited</p>",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			m.contents[id].Body = confluence.Body{Storage: confluence.Storage{Value: tC.stored, Representation: "storage"}}
+			state := tfsdk.State{Schema: s}
+			state.Set(ctx, &data)
+			readResp := &fwresource.ReadResponse{State: state}
+			r.Read(ctx, fwresource.ReadRequest{State: state}, readResp)
+			if readResp.Diagnostics.HasError() {
+				t.Fatal(readResp.Diagnostics)
+			}
+			var got ContentResourceModel
+			readResp.State.Get(ctx, &got)
+			if got.Body.ValueString() != tC.want {
+				t.Errorf("wants body %q, but got %q", tC.want, got.Body.ValueString())
+			}
+		})
+	}
+}
+
+func TestContentResourceMove(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		space     string
+		parentId  types.String
+		wantCalls []string
+		wantSpace string
+	}{
+		{
+			desc:      "Same parent",
+			space:     "DEVOPS",
+			parentId:  types.StringValue("1001"),
+			wantCalls: []string{"UpdateContent 1003 test create"},
+			wantSpace: "DEVOPS",
+		},
+		{
+			desc:      "Another parent",
+			space:     "DEVOPS",
+			parentId:  types.StringValue("1002"),
+			wantCalls: []string{"MoveContent 1003 append 1002", "UpdateContent 1003 test create"},
+			wantSpace: "DEVOPS",
+		},
+		{
+			desc:      "Another space homepage",
+			space:     "ARCHIVE",
+			parentId:  types.StringUnknown(),
+			wantCalls: []string{"ListSpaces ARCHIVE", "MoveContent 1003 append 1002", "UpdateContent 1003 test create"},
+			wantSpace: "ARCHIVE",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctx := context.Background()
+			m := newMockConfluence()
+			r := &ContentResource{}
+			s := configuredResource(t, r, m)
+			m.addContent(confluence.Content{Type: "page", Title: "DevOps", Space: &confluence.Space{Key: "DEVOPS"}, Status: "current"})
+			homepageId := m.addContent(confluence.Content{Type: "page", Title: "Archive", Space: &confluence.Space{Key: "ARCHIVE"}, Status: "current"})
+			m.spaces["ARCHIVE"] = &confluence.Space{Key: "ARCHIVE", Homepage: &confluence.Content{Id: homepageId}}
+			id := m.addContent(confluence.Content{Type: "page", Title: "test create", Space: &confluence.Space{Key: "DEVOPS"}, Ancestors: []confluence.Content{{Id: "1001"}}, Status: "current"})
+			m.labels[id] = []confluence.Label{{Name: "terraform"}}
+
+			data := testContentModel(t, s)
+			data.Id = types.StringValue(id)
+			data.ParentId = types.StringValue("1001")
+			state := tfsdk.State{Schema: s}
+			state.Set(ctx, &data)
+
+			data.Space = types.StringValue(tC.space)
+			data.ParentId = tC.parentId
+			plan := tfsdk.Plan{Schema: s}
+			plan.Set(ctx, &data)
+			resp := &fwresource.UpdateResponse{State: state}
+			r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: state}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+			assertCalls(t, m, append(tC.wantCalls, "GetLabels 1003")...)
+			if got := m.contents[id].Space.Key; got != tC.wantSpace {
+				t.Errorf("wants space %s, but got %s", tC.wantSpace, got)
+			}
+			var got ContentResourceModel
+			resp.State.Get(ctx, &got)
+			if got.ParentId.IsUnknown() {
+				t.Errorf("wants a known parent_id, but got %v", got.ParentId)
+			}
+		})
+	}
+}
+
+// testContentModel returns the model of a planned page with a terraform label.
+func TestContentResourceOrder(t *testing.T) {
+	ctx := context.Background()
+	m := newMockConfluence()
+	r := &ContentResource{}
+	s := configuredResource(t, r, m)
+	parentId := m.addContent(confluence.Content{Type: "page", Title: "Runbooks", Space: &confluence.Space{Key: "DEVOPS"}, Status: "current"})
+	for _, title := range []string{"Deploy", "Rollback"} {
+		m.addContent(confluence.Content{Type: "page", Title: title, Space: &confluence.Space{Key: "DEVOPS"}, Ancestors: []confluence.Content{{Id: parentId}}, Status: "current"})
+	}
+	id := m.addContent(confluence.Content{Type: "page", Title: "test create", Space: &confluence.Space{Key: "DEVOPS"}, Ancestors: []confluence.Content{{Id: parentId}}, Status: "current"})
+	m.labels[id] = []confluence.Label{{Name: "terraform"}}
+
+	data := testContentModel(t, s)
+	data.Id = types.StringValue(id)
+	data.ParentId = types.StringValue(parentId)
+	data.AfterId = types.StringValue("1002")
+	state := tfsdk.State{Schema: s}
+	state.Set(ctx, &data)
+
+	// The page was appended after the last sibling.
+	readResp := &fwresource.ReadResponse{State: state}
+	r.Read(ctx, fwresource.ReadRequest{State: state}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatal(readResp.Diagnostics)
+	}
+	assertCalls(t, m, "GetContentById 1004", "GetChildPages 1001", "GetLabels 1004")
+	var got ContentResourceModel
+	readResp.State.Get(ctx, &got)
+	if got.AfterId.ValueString() != "1003" {
+		t.Errorf("wants after_id 1003, but got %v", got.AfterId)
+	}
+
+	plan := tfsdk.Plan{Schema: s}
+	plan.Set(ctx, &data)
+	updateResp := &fwresource.UpdateResponse{State: readResp.State}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: readResp.State}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatal(updateResp.Diagnostics)
+	}
+	assertCalls(t, m, "UpdateContent 1004 test create", "GetContentById 1002", "MoveContent 1004 after 1002", "GetLabels 1004")
+	children, _ := m.GetChildPages(ctx, parentId)
+	m.calls = nil
+	sortChildPages(children)
+	if len(children) != 3 || children[1].Id != id {
+		t.Errorf("wants the page second, but got %v", children)
+	}
+
+	// after_id is read from the positions, the child pages are listed by id.
+	r.Read(ctx, fwresource.ReadRequest{State: updateResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatal(readResp.Diagnostics)
+	}
+	m.calls = nil
+	readResp.State.Get(ctx, &got)
+	if got.AfterId.ValueString() != "1002" {
+		t.Errorf("wants after_id 1002, but got %v", got.AfterId)
+	}
+
+	// A sibling under another parent is rejected.
+	otherId := m.addContent(confluence.Content{Type: "page", Title: "Archive", Space: &confluence.Space{Key: "DEVOPS"}, Status: "current"})
+	data.AfterId = types.StringNull()
+	data.BeforeId = types.StringValue(otherId)
+	plan.Set(ctx, &data)
+	invalidResp := &fwresource.UpdateResponse{State: updateResp.State}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: updateResp.State}, invalidResp)
+	if !invalidResp.Diagnostics.HasError() {
+		t.Errorf("wants an invalid sibling error, but got %v", invalidResp.Diagnostics)
+	}
+}
+
+func TestContentResourceValidateConfig(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		contentType string
+		parentId    types.String
+		afterId     types.String
+		wantErr     bool
+	}{
+		{
+			desc:        "Page with parent",
+			contentType: "page",
+			parentId:    types.StringValue("1001"),
+		},
+		{
+			desc:        "Blog post without parent",
+			contentType: "blogpost",
+			parentId:    types.StringNull(),
+		},
+		{
+			desc:        "Blog post with parent",
+			contentType: "blogpost",
+			parentId:    types.StringValue("1001"),
+			wantErr:     true,
+		},
+		{
+			desc:        "Page with sibling",
+			contentType: "page",
+			parentId:    types.StringNull(),
+			afterId:     types.StringValue("1002"),
+		},
+		{
+			desc:        "Blog post with sibling",
+			contentType: "blogpost",
+			parentId:    types.StringNull(),
+			afterId:     types.StringValue("1002"),
+			wantErr:     true,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctx := context.Background()
+			r := &ContentResource{}
+			s := configuredResource(t, r, newMockConfluence())
+			data := testContentModel(t, s)
+			data.Type = types.StringValue(tC.contentType)
+			data.ParentId = tC.parentId
+			data.AfterId = tC.afterId
+
+			state := tfsdk.State{Schema: s}
+			state.Set(ctx, &data)
+			resp := &fwresource.ValidateConfigResponse{}
+			r.ValidateConfig(ctx, fwresource.ValidateConfigRequest{Config: tfsdk.Config{Schema: s, Raw: state.Raw}}, resp)
+			if resp.Diagnostics.HasError() != tC.wantErr {
+				t.Errorf("wants error %v, but got %v", tC.wantErr, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func testContentModel(t *testing.T, s schema.Schema) ContentResourceModel {
+	labels, diags := types.SetValueFrom(context.Background(), types.StringType, []string{"terraform"})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	return ContentResourceModel{
+		Id:                 types.StringUnknown(),
+		Type:               types.StringValue("page"),
+		Title:              types.StringValue("test create"),
+		Space:              types.StringValue("DEVOPS"),
+		Body:               types.StringValue("<p>test</p>"),
+		TemplateId:         types.StringNull(),
+		BlueprintModuleKey: types.StringNull(),
+		TemplateVariables:  types.MapNull(types.StringType),
+		ManageBody:         types.BoolValue(true),
+		SourcePath:         types.StringNull(),
+		PageLinks:          types.MapNull(types.StringType),
+		ParentId:           types.StringUnknown(),
+		AfterId:            types.StringNull(),
+		BeforeId:           types.StringNull(),
+		Labels:             labels,
+		DeletionMode:       types.StringValue(deletionModeTrash),
+		AdoptExisting:      types.BoolValue(false),
+		Timeouts:           nullTimeouts(s),
+	}
+}
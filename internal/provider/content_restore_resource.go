@@ -0,0 +1,190 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluence"
+)
+
+var (
+	_ resource.Resource              = &ContentRestoreResource{}
+	_ resource.ResourceWithConfigure = &ContentRestoreResource{}
+)
+
+func NewContentRestoreResource() resource.Resource {
+	return &ContentRestoreResource{}
+}
+
+// ContentRestoreResource restores a previous version of a content once, when
+// it is created.
+type ContentRestoreResource struct {
+	versions confluence.VersionService
+}
+
+type ContentRestoreResourceModel struct {
+	Id              types.String `tfsdk:"id"`
+	ContentId       types.String `tfsdk:"content_id"`
+	Version         types.Int64  `tfsdk:"version"`
+	Message         types.String `tfsdk:"message"`
+	RestoreTitle    types.Bool   `tfsdk:"restore_title"`
+	RestoredVersion types.Int64  `tfsdk:"restored_version"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *ContentRestoreResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_content_restore"
+}
+
+func (r *ContentRestoreResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The resource ```content_restore``` rolls a page or blog post back to a previous version, the restored version becomes " +
+			"the newest version of the content. The restore runs once when the resource is created, changing any argument restores again " +
+			"and destroying the resource keeps the content as it is. A content managed by `confluence_content` is updated back to its " +
+			"configuration on the next apply of that resource.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Restore identifier, the content id and the version restored separated by a slash.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"content_id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the content to restore.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"version": schema.Int64Attribute{
+				MarkdownDescription: "The number of the version to restore, e.g: from the `confluence_content_versions` data source.",
+				Required:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"message": schema.StringAttribute{
+				MarkdownDescription: "The message of the version made by the restore.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"restore_title": schema.BoolAttribute{
+				MarkdownDescription: "Whether the title of the version is restored with its body. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"restored_version": schema.Int64Attribute{
+				MarkdownDescription: "The number of the version made by the restore.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+			}),
+		},
+	}
+}
+
+func (r *ContentRestoreResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.versions = data.versions
+}
+
+func (r *ContentRestoreResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ContentRestoreResourceModel
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	contentId, number := data.ContentId.ValueString(), int(data.Version.ValueInt64())
+	restored, err := r.versions.RestoreContentVersion(ctx, contentId, number, data.Message.ValueString(), data.RestoreTitle.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to restore version %d of content %s, got error: %s", number, contentId, err))
+		return
+	}
+	data.Id = types.StringValue(contentId + "/" + strconv.Itoa(number))
+	data.RestoredVersion = types.Int64Value(int64(restored.Number))
+	tflog.Info(ctx, "restored a content version", map[string]any{"content_id": contentId, "version": number, "restored_version": restored.Number})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read keeps the state, the restore is not undone by later versions of the
+// content.
+func (r *ContentRestoreResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ContentRestoreResourceModel
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update only saves the timeouts, every other argument restores again.
+func (r *ContentRestoreResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ContentRestoreResourceModel
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete only removes the restore from the state, the content keeps the
+// restored version.
+func (r *ContentRestoreResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Trace(ctx, "removed a content restore from the state")
+}
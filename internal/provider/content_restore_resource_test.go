@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluence"
+)
+
+func TestAccContentRestoreResourceBasic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccContentRestoreContentConfig("<p>first</p>", ""),
+			},
+			{
+				Config: testAccContentRestoreContentConfig("<p>second</p>", ""),
+			},
+			// Create and Read testing, the content ignores the body restored.
+			{
+				Config: testAccContentRestoreContentConfig("<p>second</p>", "ignore_changes = [body]") + testAccContentRestoreResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("confluence_content_restore.test", "version", "1"),
+					resource.TestCheckResourceAttr("confluence_content_restore.test", "restored_version", "3"),
+					resource.TestCheckResourceAttr("confluence_content_restore.test", "restore_title", "true"),
+					resource.TestCheckResourceAttrPair("data.confluence_content_versions.test", "versions.1.number", "confluence_content_restore.test", "version"),
+				),
+			},
+		},
+	})
+}
+
+func testAccContentRestoreContentConfig(body, lifecycle string) string {
+	return fmt.Sprintf(`
+resource "confluence_content" "test" {
+  type  = "page"
+  title = "Terraform Acc restore"
+  space = "DEVOPS"
+  body  = %q
+
+  lifecycle {
+    %s
+  }
+}
+`, body, lifecycle)
+}
+
+const testAccContentRestoreResourceConfig = `
+data "confluence_content_versions" "test" {
+  content_id = confluence_content.test.id
+}
+
+resource "confluence_content_restore" "test" {
+  content_id = confluence_content.test.id
+  version    = data.confluence_content_versions.test.versions[1].number
+  message    = "Rolled back by terraform"
+}
+`
+
+func TestContentRestoreResourceCRUD(t *testing.T) {
+	ctx := context.Background()
+	m := newMockConfluence()
+	r := &ContentRestoreResource{}
+	s := configuredResource(t, r, m)
+	id := m.addContent(confluence.Content{Type: "page", Title: "runbook", Space: &confluence.Space{Key: "DEVOPS"}})
+	m.contents[id].Version = &confluence.Version{Number: 4}
+	data := ContentRestoreResourceModel{
+		Id:              types.StringUnknown(),
+		ContentId:       types.StringValue("1001"),
+		Version:         types.Int64Value(2),
+		Message:         types.StringValue("rollback"),
+		RestoreTitle:    types.BoolValue(true),
+		RestoredVersion: types.Int64Unknown(),
+		Timeouts:        nullTimeouts(s),
+	}
+
+	plan := tfsdk.Plan{Schema: s}
+	plan.Set(ctx, &data)
+	createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: s}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatal(createResp.Diagnostics)
+	}
+	assertCalls(t, m, "RestoreContentVersion 1001 2 rollback true")
+	var got ContentRestoreResourceModel
+	createResp.State.Get(ctx, &got)
+	if got.Id.ValueString() != "1001/2" || got.RestoredVersion.ValueInt64() != 5 {
+		t.Errorf("wants id 1001/2 and restored version 5, but got %s and %d", got.Id, got.RestoredVersion.ValueInt64())
+	}
+
+	// Later versions of the content do not restore again.
+	m.contents["1001"].Version = &confluence.Version{Number: 6}
+	readResp := &fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatal(readResp.Diagnostics)
+	}
+	readResp.State.Get(ctx, &got)
+	if got.RestoredVersion.ValueInt64() != 5 {
+		t.Errorf("wants the restored version kept, but got %d", got.RestoredVersion.ValueInt64())
+	}
+
+	deleteResp := &fwresource.DeleteResponse{State: readResp.State}
+	r.Delete(ctx, fwresource.DeleteRequest{State: readResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatal(deleteResp.Diagnostics)
+	}
+	assertCalls(t, m)
+	if m.contents["1001"].Version.Number != 6 {
+		t.Errorf("wants the content untouched on delete, but got version %d", m.contents["1001"].Version.Number)
+	}
+
+	m.errs["RestoreContentVersion"] = fmt.Errorf("version not found")
+	createResp = &fwresource.CreateResponse{State: tfsdk.State{Schema: s}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, createResp)
+	if !createResp.Diagnostics.HasError() {
+		t.Error("wants an error when the restore fails")
+	}
+}
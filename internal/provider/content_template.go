@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluence"
+)
+
+var (
+	// templateDeclarations matches the variable declarations of a template,
+	// they are only used by the confluence editor and are not rendered.
+	templateDeclarations = regexp.MustCompile(`(?s)<at:declarations>.*?</at:declarations>`)
+	// templateVariable matches a variable of a template body, e.g:
+	// <at:var at:name="service" />, the second group holds the other attributes.
+	templateVariable = regexp.MustCompile(`<at:var\s+at:name="([^"]+)"([^>]*?)\s*(?:/>|>\s*</at:var>)`)
+)
+
+// templateBody returns the body of the template or blueprint of the content
+// rendered with its template variables.
+func (r *ContentResource) templateBody(ctx context.Context, data *ContentResourceModel) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var template *confluence.Template
+	var err error
+	if !data.TemplateId.IsNull() {
+		template, err = r.templates.GetTemplate(ctx, data.TemplateId.ValueString())
+	} else {
+		template, err = r.blueprintTemplate(ctx, data.Space.ValueString(), data.BlueprintModuleKey.ValueString())
+	}
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read content template, got error: %s", err))
+		return "", diags
+	}
+	if template.Body == nil {
+		diags.AddError("Client Error", fmt.Sprintf("Template %s has no body", template.TemplateId))
+		return "", diags
+	}
+	variables := map[string]string{}
+	if !data.TemplateVariables.IsNull() {
+		diags.Append(data.TemplateVariables.ElementsAs(ctx, &variables, false)...)
+		if diags.HasError() {
+			return "", diags
+		}
+	}
+	body, err := renderTemplate(template.Body.Storage.Value, variables)
+	if err != nil {
+		diags.AddError("Invalid Template Variables", fmt.Sprintf("Unable to render template %s, got error: %s", template.TemplateId, err))
+		return "", diags
+	}
+	return body, diags
+}
+
+// blueprintTemplate returns the blueprint template of the space with the
+// module key, with its body.
+func (r *ContentResource) blueprintTemplate(ctx context.Context, spaceKey, moduleKey string) (*confluence.Template, error) {
+	blueprints, err := r.templates.ListTemplates(ctx, confluence.TemplateQuery{SpaceKey: spaceKey, Blueprints: true})
+	if err != nil {
+		return nil, err
+	}
+	for _, blueprint := range blueprints {
+		if blueprint.OriginalTemplate != nil && blueprint.OriginalTemplate.ModuleKey == moduleKey {
+			return r.templates.GetTemplate(ctx, blueprint.TemplateId)
+		}
+	}
+	return nil, fmt.Errorf("no blueprint with module key %s in space %s", moduleKey, spaceKey)
+}
+
+// renderTemplate replaces the variables of a template body with their
+// values, escaped unless the variable is declared with at:rawxhtml="true".
+// Every variable of the body must have a value and every value must be used.
+func renderTemplate(body string, variables map[string]string) (string, error) {
+	body = templateDeclarations.ReplaceAllString(body, "")
+	used := map[string]bool{}
+	missing := map[string]bool{}
+	body = templateVariable.ReplaceAllStringFunc(body, func(match string) string {
+		groups := templateVariable.FindStringSubmatch(match)
+		name := groups[1]
+		value, ok := variables[name]
+		if !ok {
+			missing[name] = true
+			return match
+		}
+		used[name] = true
+		if strings.Contains(groups[2], `at:rawxhtml="true"`) {
+			return value
+		}
+		return html.EscapeString(value)
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("template variables %s are not set", strings.Join(sortedNames(missing), ", "))
+	}
+	unused := map[string]bool{}
+	for name := range variables {
+		if !used[name] {
+			unused[name] = true
+		}
+	}
+	if len(unused) > 0 {
+		return "", fmt.Errorf("the template has no variables %s", strings.Join(sortedNames(unused), ", "))
+	}
+	return body, nil
+}
+
+func sortedNames(names map[string]bool) []string {
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
@@ -0,0 +1,237 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluence"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		body      string
+		variables map[string]string
+		want      string
+		wantErr   bool
+	}{
+		{
+			desc:      "Variables are escaped",
+			body:      `<h1><at:var at:name="service" /></h1>`,
+			variables: map[string]string{"service": "billing & payments"},
+			want:      `<h1>billing &amp; payments</h1>`,
+		},
+		{
+			desc:      "Declarations are removed",
+			body:      `<at:declarations><at:string at:name="service" /></at:declarations><p><at:var at:name="service"/></p>`,
+			variables: map[string]string{"service": "billing"},
+			want:      `<p>billing</p>`,
+		},
+		{
+			desc:      "Raw variables are not escaped",
+			body:      `<at:var at:name="owners" at:rawxhtml="true"></at:var>`,
+			variables: map[string]string{"owners": "<ul><li>devops</li></ul>"},
+			want:      `<ul><li>devops</li></ul>`,
+		},
+		{
+			desc:      "Missing variable",
+			body:      `<p><at:var at:name="service" /> <at:var at:name="owner" /></p>`,
+			variables: map[string]string{"service": "billing"},
+			wantErr:   true,
+		},
+		{
+			desc:      "Unused variable",
+			body:      `<p>static</p>`,
+			variables: map[string]string{"service": "billing"},
+			wantErr:   true,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got, err := renderTemplate(tC.body, tC.variables)
+			if (err != nil) != tC.wantErr {
+				t.Fatalf("wants error %v, but got %v", tC.wantErr, err)
+			}
+			if got != tC.want {
+				t.Errorf("wants %q, but got %q", tC.want, got)
+			}
+		})
+	}
+}
+
+func TestContentResourceModifyPlan(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		templateId  string
+		blueprint   string
+		manageBody  bool
+		stateBody   string
+		variables   map[string]string
+		wantBody    string
+		wantCalls   []string
+		wantUnknown bool
+		wantErr     bool
+	}{
+		{
+			desc:       "Create from a template",
+			templateId: "2001",
+			manageBody: true,
+			variables:  map[string]string{"service": "billing"},
+			wantBody:   "<h1>billing</h1>",
+			wantCalls:  []string{"GetTemplate 2001"},
+		},
+		{
+			desc:       "Create from a blueprint",
+			blueprint:  "meeting-notes-page",
+			manageBody: true,
+			variables:  map[string]string{"date": "2023-05-01"},
+			wantBody:   "<p>2023-05-01</p>",
+			wantCalls:  []string{"ListTemplates DEVOPS true", "GetTemplate 2002"},
+		},
+		{
+			desc:       "Managed body is rendered again",
+			templateId: "2001",
+			manageBody: true,
+			stateBody:  "<h1>edited</h1>",
+			variables:  map[string]string{"service": "billing"},
+			wantBody:   "<h1>billing</h1>",
+			wantCalls:  []string{"GetTemplate 2001"},
+		},
+		{
+			desc:       "Unmanaged body keeps the state",
+			templateId: "2001",
+			stateBody:  "<h1>edited</h1>",
+			variables:  map[string]string{"service": "billing"},
+			wantBody:   "<h1>edited</h1>",
+		},
+		{
+			desc:       "Missing variable",
+			templateId: "2001",
+			manageBody: true,
+			wantCalls:  []string{"GetTemplate 2001"},
+			wantErr:    true,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctx := context.Background()
+			m := newMockConfluence()
+			m.templates["2001"] = &confluence.Template{
+				TemplateId: "2001",
+				Body:       &confluence.Body{Storage: confluence.Storage{Value: `<h1><at:var at:name="service" /></h1>`}},
+			}
+			m.templates["2002"] = &confluence.Template{
+				TemplateId:       "2002",
+				Body:             &confluence.Body{Storage: confluence.Storage{Value: `<p><at:var at:name="date" /></p>`}},
+				OriginalTemplate: &confluence.OriginalTemplate{ModuleKey: "meeting-notes-page"},
+			}
+			r := &ContentResource{}
+			s := configuredResource(t, r, m)
+
+			data := testContentModel(t, s)
+			data.Body = types.StringNull()
+			data.ManageBody = types.BoolValue(tC.manageBody)
+			if tC.templateId != "" {
+				data.TemplateId = types.StringValue(tC.templateId)
+			}
+			if tC.blueprint != "" {
+				data.BlueprintModuleKey = types.StringValue(tC.blueprint)
+			}
+			if tC.variables != nil {
+				variables, diags := types.MapValueFrom(ctx, types.StringType, tC.variables)
+				if diags.HasError() {
+					t.Fatal(diags)
+				}
+				data.TemplateVariables = variables
+			}
+			config := tfsdk.Config{Schema: s}
+			configState := tfsdk.State{Schema: s}
+			configState.Set(ctx, &data)
+			config.Raw = configState.Raw
+
+			data.Body = types.StringUnknown()
+			plan := tfsdk.Plan{Schema: s}
+			plan.Set(ctx, &data)
+			// The state is null when the content is created.
+			state := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+			if tC.stateBody != "" {
+				data.Id = types.StringValue("1001")
+				data.Body = types.StringValue(tC.stateBody)
+				state.Set(ctx, &data)
+			}
+
+			resp := &fwresource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, fwresource.ModifyPlanRequest{Config: config, Plan: plan, State: state}, resp)
+
+			assertCalls(t, m, tC.wantCalls...)
+			if resp.Diagnostics.HasError() != tC.wantErr {
+				t.Fatalf("wants error %v, but got %v", tC.wantErr, resp.Diagnostics)
+			}
+			if tC.wantErr {
+				return
+			}
+			var got ContentResourceModel
+			resp.Plan.Get(ctx, &got)
+			if got.Body.ValueString() != tC.wantBody {
+				t.Errorf("wants body %q, but got %v", tC.wantBody, got.Body)
+			}
+		})
+	}
+}
+
+func TestContentResourceValidateTemplateConfig(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		body       types.String
+		variables  bool
+		manageBody bool
+		wantErr    bool
+	}{
+		{
+			desc:       "Template with variables",
+			body:       types.StringNull(),
+			variables:  true,
+			manageBody: false,
+		},
+		{
+			desc:       "Body with variables",
+			body:       types.StringValue("<p>test</p>"),
+			variables:  true,
+			manageBody: true,
+			wantErr:    true,
+		},
+		{
+			desc:       "Unmanaged body",
+			body:       types.StringValue("<p>test</p>"),
+			manageBody: false,
+			wantErr:    true,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctx := context.Background()
+			r := &ContentResource{}
+			s := configuredResource(t, r, newMockConfluence())
+			data := testContentModel(t, s)
+			data.Body = tC.body
+			data.TemplateId = types.StringValue("2001")
+			data.ManageBody = types.BoolValue(tC.manageBody)
+			if tC.variables {
+				data.TemplateVariables = types.MapValueMust(types.StringType, map[string]attr.Value{"service": types.StringValue("billing")})
+			}
+
+			state := tfsdk.State{Schema: s}
+			state.Set(ctx, &data)
+			resp := &fwresource.ValidateConfigResponse{}
+			r.ValidateConfig(ctx, fwresource.ValidateConfigRequest{Config: tfsdk.Config{Schema: s, Raw: state.Raw}}, resp)
+			if resp.Diagnostics.HasError() != tC.wantErr {
+				t.Errorf("wants error %v, but got %v", tC.wantErr, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluence"
+)
+
+var (
+	_ datasource.DataSource              = &contentVersionsDataSource{}
+	_ datasource.DataSourceWithConfigure = &contentVersionsDataSource{}
+)
+
+func NewContentVersionsDataSource() datasource.DataSource {
+	return &contentVersionsDataSource{}
+}
+
+type contentVersionsDataSource struct {
+	versions confluence.VersionService
+}
+
+type ContentVersionsDataSourceModel struct {
+	Id        types.String                            `tfsdk:"id"`
+	ContentId types.String                            `tfsdk:"content_id"`
+	Versions  []ContentVersionsDataSourceVersionModel `tfsdk:"versions"`
+}
+
+type ContentVersionsDataSourceVersionModel struct {
+	Number          types.Int64  `tfsdk:"number"`
+	Author          types.String `tfsdk:"author"`
+	AuthorAccountId types.String `tfsdk:"author_account_id"`
+	Message         types.String `tfsdk:"message"`
+	When            types.String `tfsdk:"when"`
+	MinorEdit       types.Bool   `tfsdk:"minor_edit"`
+}
+
+// Metadata returns the data source type name.
+func (d *contentVersionsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_content_versions"
+}
+
+// Schema defines the content versions data source schema.
+func (d *contentVersionsDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Returns the history of a page or blog post, e.g: to find the last version before an automated change and restore it with `confluence_content_restore`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the history, the content id.",
+				Computed:            true,
+			},
+			"content_id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the content.",
+				Required:            true,
+			},
+			"versions": schema.ListNestedAttribute{
+				MarkdownDescription: "The versions of the content, newest first.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"number": schema.Int64Attribute{
+							MarkdownDescription: "The version number.",
+							Computed:            true,
+						},
+						"author": schema.StringAttribute{
+							MarkdownDescription: "The display name of the user who made the version.",
+							Computed:            true,
+						},
+						"author_account_id": schema.StringAttribute{
+							MarkdownDescription: "The account id of the user who made the version.",
+							Computed:            true,
+						},
+						"message": schema.StringAttribute{
+							MarkdownDescription: "The message of the version, empty when none was given.",
+							Computed:            true,
+						},
+						"when": schema.StringAttribute{
+							MarkdownDescription: "The date the version was made, e.g: `2023-05-01T09:30:00.000Z`.",
+							Computed:            true,
+						},
+						"minor_edit": schema.BoolAttribute{
+							MarkdownDescription: "Whether the version was a minor edit, watchers are not notified of minor edits.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *contentVersionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ContentVersionsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	versions, err := d.versions.GetContentVersions(ctx, data.ContentId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Content Versions Data Source Client Error", err.Error())
+		return
+	}
+
+	data.Id = data.ContentId
+	data.Versions = []ContentVersionsDataSourceVersionModel{}
+	for _, version := range versions {
+		author := confluence.User{}
+		if version.By != nil {
+			author = *version.By
+		}
+		data.Versions = append(data.Versions, ContentVersionsDataSourceVersionModel{
+			Number:          types.Int64Value(int64(version.Number)),
+			Author:          types.StringValue(author.DisplayName),
+			AuthorAccountId: types.StringValue(author.AccountId),
+			Message:         types.StringValue(version.Message),
+			When:            types.StringValue(version.When),
+			MinorEdit:       types.BoolValue(version.MinorEdit),
+		})
+	}
+
+	// Set State
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *contentVersionsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.versions = data.versions
+}
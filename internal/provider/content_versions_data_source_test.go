@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluencefake"
+)
+
+func TestAccContentVersionsDataSourceBasic(t *testing.T) {
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck: func() {
+				testAccPreCheck(t)
+			},
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccContentVersionsDataSourceConfigBasic,
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttrPair("data.confluence_content_versions.test", "id", "confluence_content.test", "id"),
+						resource.TestCheckResourceAttr("data.confluence_content_versions.test", "versions.#", "1"),
+						resource.TestCheckResourceAttr("data.confluence_content_versions.test", "versions.0.number", "1"),
+						resource.TestCheckResourceAttr("data.confluence_content_versions.test", "versions.0.author_account_id", confluencefake.CurrentAccountId),
+						resource.TestCheckResourceAttrSet("data.confluence_content_versions.test", "versions.0.when"),
+					),
+				},
+			},
+		},
+	)
+}
+
+const testAccContentVersionsDataSourceConfigBasic = `
+resource "confluence_content" "test" {
+  type  = "page"
+  title = "Terraform Acc versions"
+  space = "DEVOPS"
+  body  = "<p>first</p>"
+}
+
+data "confluence_content_versions" "test" {
+  content_id = confluence_content.test.id
+}
+`
@@ -0,0 +1,284 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluence"
+)
+
+var (
+	_ resource.Resource                = &ContentWatchersResource{}
+	_ resource.ResourceWithConfigure   = &ContentWatchersResource{}
+	_ resource.ResourceWithImportState = &ContentWatchersResource{}
+)
+
+func NewContentWatchersResource() resource.Resource {
+	return &ContentWatchersResource{}
+}
+
+// ContentWatchersResource manages every user watching a content.
+type ContentWatchersResource struct {
+	watchers confluence.WatcherService
+}
+
+type ContentWatchersResourceModel struct {
+	Id         types.String `tfsdk:"id"`
+	ContentId  types.String `tfsdk:"content_id"`
+	AccountIds types.Set    `tfsdk:"account_ids"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *ContentWatchersResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_content_watchers"
+}
+
+func (r *ContentWatchersResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The resource ```content_watchers``` manages the users watching a page or blog post, they are notified when it is edited. " +
+			"The watchers are authoritative, users watching the content outside terraform are removed on the next apply, " +
+			"including the authors confluence adds when their autowatch setting is enabled.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Watchers identifier, the content id.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"content_id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the watched content.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"account_ids": schema.SetAttribute{
+				MarkdownDescription: "The account ids of the users watching the content, e.g: from the `confluence_user` data source.",
+				ElementType:         types.StringType,
+				Required:            true,
+			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *ContentWatchersResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.watchers = data.watchers
+}
+
+func (r *ContentWatchersResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ContentWatchersResourceModel
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(r.setWatchers(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = data.ContentId
+	tflog.Trace(ctx, "set the content watchers")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ContentWatchersResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ContentWatchersResourceModel
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	watchers, err := r.watchers.GetContentWatchers(ctx, data.ContentId.ValueString())
+	if errors.Is(err, confluence.ErrNotFound) {
+		// The page or blog post was deleted outside terraform, the watchers are
+		// set again on the next apply.
+		tflog.Warn(ctx, "page or blog post not found, removing its watchers from the state", map[string]any{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read content watchers, got error: %s", err))
+		return
+	}
+	data.AccountIds, diags = types.SetValueFrom(ctx, types.StringType, watcherAccountIds(watchers))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ContentWatchersResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ContentWatchersResourceModel
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(r.setWatchers(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "updated the content watchers")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ContentWatchersResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ContentWatchersResourceModel
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	var accountIds []string
+	resp.Diagnostics.Append(data.AccountIds.ElementsAs(ctx, &accountIds, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for _, accountId := range accountIds {
+		err := r.watchers.UnwatchContent(ctx, data.ContentId.ValueString(), accountId)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove content watcher %s, got error: %s", accountId, err))
+			return
+		}
+	}
+	tflog.Trace(ctx, "removed the content watchers")
+}
+
+// ImportState accepts the content id.
+func (r *ContentWatchersResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("content_id"), req.ID)...)
+}
+
+// setWatchers adds and removes watchers until the content is watched by the
+// account ids of the model.
+func (r *ContentWatchersResource) setWatchers(ctx context.Context, data *ContentWatchersResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	id := data.ContentId.ValueString()
+	var want []string
+	diags.Append(data.AccountIds.ElementsAs(ctx, &want, false)...)
+	if diags.HasError() {
+		return diags
+	}
+	current, err := r.watchers.GetContentWatchers(ctx, id)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read content watchers, got error: %s", err))
+		return diags
+	}
+	err = reconcileWatchers(ctx, watcherAccountIds(current), want,
+		func(accountId string) error { return r.watchers.WatchContent(ctx, id, accountId) },
+		func(accountId string) error { return r.watchers.UnwatchContent(ctx, id, accountId) },
+	)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to set content watchers, got error: %s", err))
+	}
+	return diags
+}
+
+// reconcileWatchers watches with the wanted account ids missing from the
+// current ones, and unwatches with the current ones that are not wanted.
+func reconcileWatchers(ctx context.Context, current, want []string, watch, unwatch func(accountId string) error) error {
+	have := map[string]bool{}
+	for _, accountId := range current {
+		have[accountId] = true
+	}
+	for _, accountId := range want {
+		if !have[accountId] {
+			if err := watch(accountId); err != nil {
+				return err
+			}
+			tflog.Debug(ctx, "added a watcher", map[string]any{"account_id": accountId})
+		}
+		delete(have, accountId)
+	}
+	for _, accountId := range current {
+		if have[accountId] {
+			if err := unwatch(accountId); err != nil {
+				return err
+			}
+			tflog.Debug(ctx, "removed a watcher", map[string]any{"account_id": accountId})
+		}
+	}
+	return nil
+}
+
+// watcherAccountIds returns the account ids of the watchers.
+func watcherAccountIds(watchers []confluence.User) []string {
+	accountIds := make([]string, 0, len(watchers))
+	for _, watcher := range watchers {
+		accountIds = append(accountIds, watcher.AccountId)
+	}
+	return accountIds
+}
@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluence"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluencefake"
+)
+
+func TestAccContentWatchersResourceBasic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccContentWatchersResourceConfig("[data.confluence_current_user.me.account_id]"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("confluence_content_watchers.test", "id", "confluence_content.test", "id"),
+					resource.TestCheckResourceAttr("confluence_content_watchers.test", "account_ids.#", "1"),
+					resource.TestCheckTypeSetElemAttr("confluence_content_watchers.test", "account_ids.*", confluencefake.CurrentAccountId),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "confluence_content_watchers.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccContentWatchersResourceConfig("[]"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("confluence_content_watchers.test", "account_ids.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccContentWatchersResourceConfig(accountIds string) string {
+	return fmt.Sprintf(`
+data "confluence_current_user" "me" {}
+
+resource "confluence_content" "test" {
+  type  = "page"
+  title = "Terraform Acc watchers"
+  space = "DEVOPS"
+  body  = "<p>critical runbook</p>"
+}
+
+resource "confluence_content_watchers" "test" {
+  content_id  = confluence_content.test.id
+  account_ids = %s
+}
+`, accountIds)
+}
+
+func TestContentWatchersResourceCRUD(t *testing.T) {
+	ctx := context.Background()
+	m := newMockConfluence()
+	r := &ContentWatchersResource{}
+	s := configuredResource(t, r, m)
+	m.watchers["content/1001"] = []string{"author", "alice"}
+	accountIds, _ := types.SetValueFrom(ctx, types.StringType, []string{"alice", "bob"})
+	data := ContentWatchersResourceModel{
+		Id:         types.StringUnknown(),
+		ContentId:  types.StringValue("1001"),
+		AccountIds: accountIds,
+		Timeouts:   nullTimeouts(s),
+	}
+
+	// The author watching the page outside terraform is removed.
+	plan := tfsdk.Plan{Schema: s}
+	plan.Set(ctx, &data)
+	createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: s}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatal(createResp.Diagnostics)
+	}
+	assertCalls(t, m,
+		"GetContentWatchers content/1001",
+		"WatchContent content/1001 bob",
+		"UnwatchContent content/1001 author",
+	)
+
+	m.watchers["content/1001"] = append(m.watchers["content/1001"], "carol")
+	readResp := &fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatal(readResp.Diagnostics)
+	}
+	var got ContentWatchersResourceModel
+	readResp.State.Get(ctx, &got)
+	if fmt.Sprint(got.AccountIds) != `["alice","bob","carol"]` {
+		t.Errorf("wants the watcher added outside terraform, but got %v", got.AccountIds)
+	}
+	m.calls = nil
+
+	updateResp := &fwresource.UpdateResponse{State: readResp.State}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: readResp.State}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatal(updateResp.Diagnostics)
+	}
+	assertCalls(t, m,
+		"GetContentWatchers content/1001",
+		"UnwatchContent content/1001 carol",
+	)
+
+	deleteResp := &fwresource.DeleteResponse{State: updateResp.State}
+	r.Delete(ctx, fwresource.DeleteRequest{State: updateResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatal(deleteResp.Diagnostics)
+	}
+	assertCalls(t, m,
+		"UnwatchContent content/1001 alice",
+		"UnwatchContent content/1001 bob",
+	)
+	if len(m.watchers["content/1001"]) != 0 {
+		t.Errorf("wants no watchers, but got %v", m.watchers["content/1001"])
+	}
+}
+
+func TestContentWatchersResourceDeletedOutsideTerraform(t *testing.T) {
+	ctx := context.Background()
+	m := newMockConfluence()
+	r := &ContentWatchersResource{}
+	s := configuredResource(t, r, m)
+	accountIds, _ := types.SetValueFrom(ctx, types.StringType, []string{"alice"})
+	data := ContentWatchersResourceModel{
+		Id:         types.StringValue("1001"),
+		ContentId:  types.StringValue("1001"),
+		AccountIds: accountIds,
+		Timeouts:   nullTimeouts(s),
+	}
+	state := tfsdk.State{Schema: s}
+	state.Set(ctx, &data)
+
+	// The page is deleted in the UI, its watchers are removed from the state.
+	m.errs["GetContentWatchers"] = fmt.Errorf("no content with id 1001: %w", confluence.ErrNotFound)
+	readResp := &fwresource.ReadResponse{State: state}
+	r.Read(ctx, fwresource.ReadRequest{State: state}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatal(readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.IsNull() {
+		t.Error("wants the content watchers removed from the state")
+	}
+}
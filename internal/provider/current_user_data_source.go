@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluence"
+)
+
+var (
+	_ datasource.DataSource              = &currentUserDataSource{}
+	_ datasource.DataSourceWithConfigure = &currentUserDataSource{}
+)
+
+func NewCurrentUserDataSource() datasource.DataSource {
+	return &currentUserDataSource{}
+}
+
+type currentUserDataSource struct {
+	users confluence.UserService
+}
+
+// Metadata returns the data source type name.
+func (d *currentUserDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_current_user"
+}
+
+// Schema defines the current user data source schema.
+func (d *currentUserDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Returns the user the provider is authenticated as.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The account id of the user.",
+				Computed:            true,
+			},
+			"account_id": schema.StringAttribute{
+				MarkdownDescription: "The Atlassian account id of the user.",
+				Computed:            true,
+			},
+			"email": schema.StringAttribute{
+				MarkdownDescription: "The email of the user.",
+				Computed:            true,
+			},
+			"display_name": schema.StringAttribute{
+				MarkdownDescription: "The display name of the user.",
+				Computed:            true,
+			},
+			"public_name": schema.StringAttribute{
+				MarkdownDescription: "The public name of the user.",
+				Computed:            true,
+			},
+			"account_type": schema.StringAttribute{
+				MarkdownDescription: "Type of the account, e.g: atlassian, app.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *currentUserDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data UserDataSourceModel
+	user, err := d.users.GetCurrentUser(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Current User Data Source Client Error", err.Error())
+		return
+	}
+	data.Id = types.StringValue(user.AccountId)
+	setUserModel(&data, user)
+
+	// Set State
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *currentUserDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.users = data.users
+}
@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluence"
+)
+
+var (
+	_ datasource.DataSource              = &groupDataSource{}
+	_ datasource.DataSourceWithConfigure = &groupDataSource{}
+)
+
+func NewGroupDataSource() datasource.DataSource {
+	return &groupDataSource{}
+}
+
+type groupDataSource struct {
+	groups confluence.GroupService
+}
+
+type GroupDataSourceModel struct {
+	Id      types.String                 `tfsdk:"id"`
+	Name    types.String                 `tfsdk:"name"`
+	Members []GroupDataSourceMemberModel `tfsdk:"members"`
+}
+
+type GroupDataSourceMemberModel struct {
+	AccountId   types.String `tfsdk:"account_id"`
+	Email       types.String `tfsdk:"email"`
+	DisplayName types.String `tfsdk:"display_name"`
+}
+
+// Metadata returns the data source type name.
+func (d *groupDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group"
+}
+
+// Schema defines the group data source schema.
+func (d *groupDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Returns a group and its members, e.g: to grant every member of a team a restriction.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Group identifier, the group name when confluence does not return an id.",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the group.",
+				Required:            true,
+			},
+			"members": schema.ListNestedAttribute{
+				MarkdownDescription: "The members of the group.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"account_id": schema.StringAttribute{
+							MarkdownDescription: "The Atlassian account id of the member.",
+							Computed:            true,
+						},
+						"email": schema.StringAttribute{
+							MarkdownDescription: "The email of the member, it is only returned when the user privacy settings allow it.",
+							Computed:            true,
+						},
+						"display_name": schema.StringAttribute{
+							MarkdownDescription: "The display name of the member.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *groupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data GroupDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group, err := d.groups.GetGroup(ctx, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Group Data Source Client Error", err.Error())
+		return
+	}
+	members, err := d.groups.GetGroupMembers(ctx, group.Name)
+	if err != nil {
+		resp.Diagnostics.AddError("Group Data Source Client Error", err.Error())
+		return
+	}
+
+	data.Id = types.StringValue(group.Id)
+	if group.Id == "" {
+		data.Id = types.StringValue(group.Name)
+	}
+	data.Name = types.StringValue(group.Name)
+	data.Members = []GroupDataSourceMemberModel{}
+	for _, member := range members {
+		data.Members = append(data.Members, GroupDataSourceMemberModel{
+			AccountId:   types.StringValue(member.AccountId),
+			Email:       types.StringValue(member.Email),
+			DisplayName: types.StringValue(member.DisplayName),
+		})
+	}
+
+	// Set State
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *groupDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.groups = data.groups
+}
@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccGroupDataSourceBasic(t *testing.T) {
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck: func() {
+				testAccPreCheck(t)
+			},
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccGroupDataSourceConfigBasic,
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.confluence_group.test", "name", "confluence-users"),
+						resource.TestCheckResourceAttrSet("data.confluence_group.test", "id"),
+						resource.TestCheckResourceAttrSet("data.confluence_group.test", "members.0.account_id"),
+					),
+				},
+			},
+		},
+	)
+}
+
+const testAccGroupDataSourceConfigBasic = `
+data "confluence_group" "test" {
+  name = "confluence-users"
+}
+`
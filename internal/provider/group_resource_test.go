@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluencefake"
+)
+
+// The group acceptance tests need confluence Data Center, when they run
+// against a real instance it must have a terraform user, the fake server
+// serves requests as it.
+func TestAccGroupResourceBasic(t *testing.T) {
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck: func() {
+				testAccPreCheck(t)
+			},
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				// Create and Read testing
+				{
+					Config: testAccGroupResourceConfigBasic,
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("confluence_group.test", "id", "terraform-acc-group"),
+						resource.TestCheckResourceAttr("confluence_group_membership.test", "id", "terraform-acc-group/terraform"),
+					),
+				},
+				// ImportState testing
+				{
+					ResourceName:            "confluence_group.test",
+					ImportState:             true,
+					ImportStateVerify:       true,
+					ImportStateVerifyIgnore: []string{"timeouts"},
+				},
+				{
+					ResourceName:            "confluence_group_membership.test",
+					ImportState:             true,
+					ImportStateVerify:       true,
+					ImportStateVerifyIgnore: []string{"timeouts"},
+				},
+				// Delete testing automatically occurs in TestCase
+			},
+		},
+	)
+}
+
+const testAccGroupResourceConfigBasic = `
+resource "confluence_group" "test" {
+  name = "terraform-acc-group"
+}
+
+resource "confluence_group_membership" "test" {
+  group_name = confluence_group.test.name
+  username   = "terraform"
+}
+`
+
+func TestDataCenterOnly(t *testing.T) {
+	s := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{Required: true},
+		},
+	}
+	objectType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"name": tftypes.String}}
+	plan := tfsdk.Plan{
+		Schema: s,
+		Raw:    tftypes.NewValue(objectType, map[string]tftypes.Value{"name": tftypes.NewValue(tftypes.String, "devops-team")}),
+	}
+	destroyPlan := tfsdk.Plan{
+		Schema: s,
+		Raw:    tftypes.NewValue(objectType, nil),
+	}
+
+	testCases := []struct {
+		desc    string
+		cloud   bool
+		plan    tfsdk.Plan
+		wantErr bool
+	}{
+		{
+			desc:  "data center",
+			cloud: false,
+			plan:  plan,
+		},
+		{
+			desc:    "cloud",
+			cloud:   true,
+			plan:    plan,
+			wantErr: true,
+		},
+		{
+			desc:  "cloud destroy",
+			cloud: true,
+			plan:  destroyPlan,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			diags := dataCenterOnly(tC.cloud, tC.plan, "confluence_group", cloudGroupsHint)
+			if diags.HasError() != tC.wantErr {
+				t.Errorf("wants error %v, but got %v", tC.wantErr, diags)
+			}
+		})
+	}
+}
+
+func TestGroupResourceDeletedOutsideTerraform(t *testing.T) {
+	ctx := context.Background()
+	server := confluencefake.NewServer()
+	t.Cleanup(server.Close)
+	r := &GroupResource{}
+	s, api := fakeConfiguredResource(t, r, server)
+	data := GroupResourceModel{
+		Id:       types.StringUnknown(),
+		Name:     types.StringValue("release-managers"),
+		Timeouts: nullTimeouts(s),
+	}
+
+	plan := tfsdk.Plan{Schema: s}
+	plan.Set(ctx, &data)
+	createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: s}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatal(createResp.Diagnostics)
+	}
+
+	// The group is deleted in the administration, it is removed from the
+	// state.
+	err := api.DeleteGroup(ctx, "release-managers")
+	if err != nil {
+		t.Fatal(err)
+	}
+	readResp := &fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatal(readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.IsNull() {
+		t.Error("wants the group removed from the state")
+	}
+}
@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the JSON type and value satisfy the framework interfaces.
+var (
+	_ basetypes.StringTypable                    = jsonType{}
+	_ xattr.TypeWithValidate                     = jsonType{}
+	_ basetypes.StringValuableWithSemanticEquals = jsonValue{}
+)
+
+// jsonType is a string attribute holding a JSON document, values that only
+// differ in whitespace or object key order are semantically equal, so
+// confluence reformatting a value does not show a diff.
+type jsonType struct {
+	basetypes.StringType
+}
+
+func (t jsonType) Equal(o attr.Type) bool {
+	other, ok := o.(jsonType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t jsonType) String() string {
+	return "provider.jsonType"
+}
+
+func (t jsonType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return jsonValue{StringValue: in}, nil
+}
+
+func (t jsonType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+	return jsonValue{StringValue: stringValue}, nil
+}
+
+func (t jsonType) ValueType(ctx context.Context) attr.Value {
+	return jsonValue{}
+}
+
+// Validate rejects strings that are not a JSON document.
+func (t jsonType) Validate(ctx context.Context, in tftypes.Value, p path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if !in.IsKnown() || in.IsNull() {
+		return diags
+	}
+	var s string
+	err := in.As(&s)
+	if err != nil {
+		diags.AddAttributeError(p, "Invalid JSON String Value", fmt.Sprintf("Unable to read the value as a string: %s", err))
+		return diags
+	}
+	if !json.Valid([]byte(s)) {
+		diags.AddAttributeError(p, "Invalid JSON String Value", fmt.Sprintf("A string value was provided that is not valid JSON: %s", s))
+	}
+	return diags
+}
+
+type jsonValue struct {
+	basetypes.StringValue
+}
+
+func newJSONValue(s string) jsonValue {
+	return jsonValue{StringValue: basetypes.NewStringValue(s)}
+}
+
+func (v jsonValue) Type(ctx context.Context) attr.Type {
+	return jsonType{}
+}
+
+func (v jsonValue) Equal(o attr.Value) bool {
+	other, ok := o.(jsonValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals compares the decoded JSON documents.
+func (v jsonValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	newValue, ok := newValuable.(jsonValue)
+	if !ok {
+		diags.AddError("Semantic Equality Check Error", fmt.Sprintf("Expected value type %T, got: %T. Please report this issue to the provider developers.", v, newValuable))
+		return false, diags
+	}
+	var a, b any
+	if json.Unmarshal([]byte(v.ValueString()), &a) != nil || json.Unmarshal([]byte(newValue.ValueString()), &b) != nil {
+		return false, diags
+	}
+	return reflect.DeepEqual(a, b), diags
+}
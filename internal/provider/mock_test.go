@@ -0,0 +1,639 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluence"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluencefake"
+)
+
+// mockConfluence implements the confluence services in memory and records
+// every call, so unit tests can assert the calls made by a resource.
+type mockConfluence struct {
+	calls    []string
+	nextId   int
+	contents map[string]*confluence.Content
+	labels   map[string][]confluence.Label
+	spaces   map[string]*confluence.Space
+	// positions orders the child pages of a parent, pages without
+	// position come first by id.
+	positions map[string]int
+	// watchers are the account ids watching a content/{id} or a space/{key}.
+	watchers map[string][]string
+	// templates are stored by id, blueprints have an original template.
+	templates map[string]*confluence.Template
+	// errs makes the named method return the error.
+	errs map[string]error
+}
+
+var (
+	_ confluence.ContentService       = &mockConfluence{}
+	_ confluence.PageHierarchyService = &mockConfluence{}
+	_ confluence.LabelService         = &mockConfluence{}
+	_ confluence.SpaceService         = &mockConfluence{}
+	_ confluence.TemplateService      = &mockConfluence{}
+	_ confluence.AttachmentService    = &mockConfluence{}
+	_ confluence.WatcherService       = &mockConfluence{}
+	_ confluence.VersionService       = &mockConfluence{}
+	_ confluence.SpaceExportService   = &mockConfluence{}
+)
+
+func newMockConfluence() *mockConfluence {
+	return &mockConfluence{
+		nextId:    1000,
+		contents:  map[string]*confluence.Content{},
+		labels:    map[string][]confluence.Label{},
+		spaces:    map[string]*confluence.Space{},
+		positions: map[string]int{},
+		watchers:  map[string][]string{},
+		templates: map[string]*confluence.Template{},
+		errs:      map[string]error{},
+	}
+}
+
+// providerData returns the provider data resources are configured with.
+func (m *mockConfluence) providerData() *providerData {
+	return &providerData{
+		content:     m,
+		hierarchy:   m,
+		labels:      m,
+		spaces:      m,
+		templates:   m,
+		attachments: m,
+		watchers:    m,
+		versions:    m,
+		exports:     m,
+	}
+}
+
+// call records the method and its arguments, and returns the error set for it.
+func (m *mockConfluence) call(method string, args ...string) error {
+	m.calls = append(m.calls, strings.TrimSpace(method+" "+strings.Join(args, " ")))
+	return m.errs[method]
+}
+
+func (m *mockConfluence) newId() string {
+	m.nextId++
+	return strconv.Itoa(m.nextId)
+}
+
+// addContent stores a content as if it had been created outside terraform.
+func (m *mockConfluence) addContent(c confluence.Content) string {
+	c.Id = m.newId()
+	c.Version = &confluence.Version{Number: 1}
+	m.contents[c.Id] = &c
+	return c.Id
+}
+
+func (m *mockConfluence) GetContents(ctx context.Context, query confluence.ContentQuery) ([]confluence.Content, error) {
+	if err := m.call("GetContents", query.SpaceKey, query.Title, query.Status); err != nil {
+		return nil, err
+	}
+	contents := []confluence.Content{}
+	for _, id := range sortedIds(m.contents) {
+		c := m.contents[id]
+		if c.Space.Key == query.SpaceKey && c.Title == query.Title && c.Status == query.Status {
+			contents = append(contents, *c)
+		}
+	}
+	return contents, nil
+}
+
+func (m *mockConfluence) GetContentById(ctx context.Context, id string) (*confluence.Content, error) {
+	if err := m.call("GetContentById", id); err != nil {
+		return nil, err
+	}
+	c, ok := m.contents[id]
+	if !ok || c.Status != "current" {
+		return nil, fmt.Errorf("no content with id %s: %w", id, confluence.ErrNotFound)
+	}
+	content := *c
+	return &content, nil
+}
+
+func (m *mockConfluence) CreateContent(ctx context.Context, c *confluence.Content) error {
+	if err := m.call("CreateContent", c.Space.Key, c.Title); err != nil {
+		return err
+	}
+	c.Id = m.newId()
+	c.Status = "current"
+	c.Version = &confluence.Version{Number: 1}
+	content := *c
+	m.contents[c.Id] = &content
+	return nil
+}
+
+func (m *mockConfluence) UpdateContent(ctx context.Context, c *confluence.Content) error {
+	if err := m.call("UpdateContent", c.Id, c.Title); err != nil {
+		return err
+	}
+	stored, ok := m.contents[c.Id]
+	if !ok {
+		return fmt.Errorf("no content with id %s", c.Id)
+	}
+	c.Status = "current"
+	c.Version = &confluence.Version{Number: stored.Version.Number + 1}
+	content := *c
+	m.contents[c.Id] = &content
+	return nil
+}
+
+func (m *mockConfluence) DeleteContent(ctx context.Context, id string) error {
+	if err := m.call("DeleteContent", id); err != nil {
+		return err
+	}
+	c, ok := m.contents[id]
+	if !ok || c.Status != "current" {
+		return fmt.Errorf("no content with id %s: %w", id, confluence.ErrNotFound)
+	}
+	c.Status = "trashed"
+	return nil
+}
+
+func (m *mockConfluence) PurgeContent(ctx context.Context, id string) error {
+	if err := m.call("PurgeContent", id); err != nil {
+		return err
+	}
+	delete(m.contents, id)
+	return nil
+}
+
+func (m *mockConfluence) ArchiveContent(ctx context.Context, id string) error {
+	if err := m.call("ArchiveContent", id); err != nil {
+		return err
+	}
+	m.contents[id].Status = "archived"
+	return nil
+}
+
+func (m *mockConfluence) RestoreContent(ctx context.Context, c *confluence.Content) error {
+	if err := m.call("RestoreContent", c.Id); err != nil {
+		return err
+	}
+	stored := m.contents[c.Id]
+	c.Status = "current"
+	c.Version = &confluence.Version{Number: stored.Version.Number + 1}
+	content := *c
+	m.contents[c.Id] = &content
+	return nil
+}
+
+func (m *mockConfluence) GetChildPages(ctx context.Context, id string) ([]confluence.Content, error) {
+	if err := m.call("GetChildPages", id); err != nil {
+		return nil, err
+	}
+	// Child pages are listed by id with their position, like confluence
+	// the listing is not in the order of the page tree.
+	children := []confluence.Content{}
+	for _, c := range m.children(id) {
+		child := *c
+		if position, ok := m.positions[c.Id]; ok {
+			child.Extensions = &confluence.ContentExtensions{Position: &position}
+		}
+		children = append(children, child)
+	}
+	sort.SliceStable(children, func(i, j int) bool { return children[i].Id < children[j].Id })
+	return children, nil
+}
+
+// children returns the current child pages of a page in their order.
+func (m *mockConfluence) children(id string) []*confluence.Content {
+	children := []*confluence.Content{}
+	for _, key := range sortedIds(m.contents) {
+		c := m.contents[key]
+		if c.Status == "current" && len(c.Ancestors) > 0 && c.Ancestors[len(c.Ancestors)-1].Id == id {
+			children = append(children, c)
+		}
+	}
+	sort.SliceStable(children, func(i, j int) bool { return m.positions[children[i].Id] < m.positions[children[j].Id] })
+	return children
+}
+
+// MoveContent places the page under the target, or next to it for the before
+// and after positions.
+func (m *mockConfluence) MoveContent(ctx context.Context, id, position, targetId string) error {
+	if err := m.call("MoveContent", id, position, targetId); err != nil {
+		return err
+	}
+	c, ok := m.contents[id]
+	target, targetOk := m.contents[targetId]
+	if !ok || !targetOk {
+		return fmt.Errorf("no content with id %s or %s", id, targetId)
+	}
+	c.Space = target.Space
+	if position == confluence.MovePositionAppend {
+		last := 0
+		for _, sibling := range m.children(targetId) {
+			last = m.positions[sibling.Id] + 1
+		}
+		c.Ancestors = []confluence.Content{{Id: targetId}}
+		m.positions[id] = last
+		return nil
+	}
+	c.Ancestors = append([]confluence.Content{}, target.Ancestors...)
+	siblings := []*confluence.Content{}
+	for _, sibling := range m.children(target.Ancestors[len(target.Ancestors)-1].Id) {
+		switch {
+		case sibling == c:
+		case sibling == target && position == confluence.MovePositionBefore:
+			siblings = append(siblings, c, target)
+		case sibling == target:
+			siblings = append(siblings, target, c)
+		default:
+			siblings = append(siblings, sibling)
+		}
+	}
+	for i, sibling := range siblings {
+		m.positions[sibling.Id] = i
+	}
+	return nil
+}
+
+func (m *mockConfluence) CopyContent(ctx context.Context, id string, req confluence.CopyRequest) (*confluence.Content, error) {
+	if err := m.call("CopyContent", id, req.Destination.Value, req.PageTitle); err != nil {
+		return nil, err
+	}
+	content := *m.copyPage(id, req.Destination.Value, req.PageTitle)
+	return &content, nil
+}
+
+func (m *mockConfluence) CopyPageHierarchy(ctx context.Context, id string, req confluence.CopyPageHierarchyRequest) (*confluence.LongTask, error) {
+	prefix := ""
+	if req.TitleOptions != nil {
+		prefix = req.TitleOptions.Prefix
+	}
+	if err := m.call("CopyPageHierarchy", id, req.DestinationPageId, prefix); err != nil {
+		return nil, err
+	}
+	var copyTree func(id, parentId string)
+	copyTree = func(id, parentId string) {
+		c := m.copyPage(id, parentId, prefix+m.contents[id].Title)
+		for _, key := range sortedIds(m.contents) {
+			child := m.contents[key]
+			if key != c.Id && child.Status == "current" && len(child.Ancestors) > 0 && child.Ancestors[len(child.Ancestors)-1].Id == id {
+				copyTree(child.Id, c.Id)
+			}
+		}
+	}
+	copyTree(id, req.DestinationPageId)
+	return &confluence.LongTask{Finished: true, Successful: true}, nil
+}
+
+// copyPage stores a copy of the page under the parent, the title of the page
+// is kept when title is empty.
+func (m *mockConfluence) copyPage(id, parentId, title string) *confluence.Content {
+	c := *m.contents[id]
+	if title != "" {
+		c.Title = title
+	}
+	c.Id = m.newId()
+	c.Ancestors = []confluence.Content{{Id: parentId}}
+	c.Space = m.contents[parentId].Space
+	c.Version = &confluence.Version{Number: 1}
+	m.contents[c.Id] = &c
+	return &c
+}
+
+func (m *mockConfluence) GetLabels(ctx context.Context, id string) ([]confluence.Label, error) {
+	if err := m.call("GetLabels", id); err != nil {
+		return nil, err
+	}
+	return append([]confluence.Label{}, m.labels[id]...), nil
+}
+
+func (m *mockConfluence) AddLabels(ctx context.Context, id string, labels []confluence.Label) error {
+	if err := m.call("AddLabels", id, strings.Join(labelNames(labels), ",")); err != nil {
+		return err
+	}
+	m.labels[id] = append(m.labels[id], labels...)
+	return nil
+}
+
+func (m *mockConfluence) DeleteLabel(ctx context.Context, id, name string) error {
+	if err := m.call("DeleteLabel", id, name); err != nil {
+		return err
+	}
+	labels := []confluence.Label{}
+	for _, l := range m.labels[id] {
+		if l.Name != name {
+			labels = append(labels, l)
+		}
+	}
+	m.labels[id] = labels
+	return nil
+}
+
+func (m *mockConfluence) ListSpaces(ctx context.Context, query confluence.SpaceQuery) ([]confluence.Space, error) {
+	if err := m.call("ListSpaces", query.Keys...); err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(m.spaces))
+	for key := range m.spaces {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	spaces := []confluence.Space{}
+	for _, key := range keys {
+		if len(query.Keys) > 0 && !containsString(query.Keys, key) {
+			continue
+		}
+		spaces = append(spaces, *m.spaces[key])
+	}
+	return spaces, nil
+}
+
+func (m *mockConfluence) GetSpace(ctx context.Context, key string) (*confluence.Space, error) {
+	if err := m.call("GetSpace", key); err != nil {
+		return nil, err
+	}
+	s, ok := m.spaces[key]
+	if !ok {
+		return nil, fmt.Errorf("no space with key %s", key)
+	}
+	space := *s
+	return &space, nil
+}
+
+func (m *mockConfluence) CreateSpace(ctx context.Context, space *confluence.Space) error {
+	if err := m.call("CreateSpace", space.Key); err != nil {
+		return err
+	}
+	id, _ := strconv.Atoi(m.newId())
+	space.Id = uint(id)
+	s := *space
+	m.spaces[space.Key] = &s
+	return nil
+}
+
+func (m *mockConfluence) UpdateSpace(ctx context.Context, space *confluence.Space) error {
+	if err := m.call("UpdateSpace", space.Key, space.Name); err != nil {
+		return err
+	}
+	s := *space
+	m.spaces[space.Key] = &s
+	return nil
+}
+
+func (m *mockConfluence) DeleteSpace(ctx context.Context, key string) error {
+	if err := m.call("DeleteSpace", key); err != nil {
+		return err
+	}
+	delete(m.spaces, key)
+	return nil
+}
+
+func (m *mockConfluence) GetTemplate(ctx context.Context, id string) (*confluence.Template, error) {
+	if err := m.call("GetTemplate", id); err != nil {
+		return nil, err
+	}
+	t, ok := m.templates[id]
+	if !ok {
+		return nil, fmt.Errorf("no template with id %s: %w", id, confluence.ErrNotFound)
+	}
+	template := *t
+	return &template, nil
+}
+
+func (m *mockConfluence) CreateTemplate(ctx context.Context, t *confluence.Template) error {
+	if err := m.call("CreateTemplate", t.Name); err != nil {
+		return err
+	}
+	t.TemplateId = m.newId()
+	template := *t
+	m.templates[t.TemplateId] = &template
+	return nil
+}
+
+func (m *mockConfluence) UpdateTemplate(ctx context.Context, t *confluence.Template) error {
+	if err := m.call("UpdateTemplate", t.TemplateId, t.Name); err != nil {
+		return err
+	}
+	template := *t
+	m.templates[t.TemplateId] = &template
+	return nil
+}
+
+func (m *mockConfluence) DeleteTemplate(ctx context.Context, id string) error {
+	if err := m.call("DeleteTemplate", id); err != nil {
+		return err
+	}
+	delete(m.templates, id)
+	return nil
+}
+
+func (m *mockConfluence) ListTemplates(ctx context.Context, query confluence.TemplateQuery) ([]confluence.Template, error) {
+	if err := m.call("ListTemplates", query.SpaceKey, strconv.FormatBool(query.Blueprints)); err != nil {
+		return nil, err
+	}
+	templates := []confluence.Template{}
+	ids := make([]string, 0, len(m.templates))
+	for id := range m.templates {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		t := m.templates[id]
+		if (t.OriginalTemplate != nil) == query.Blueprints {
+			templates = append(templates, *t)
+		}
+	}
+	return templates, nil
+}
+
+func (m *mockConfluence) UploadAttachment(ctx context.Context, contentId, filename, mediaType string, data []byte) (*confluence.Content, error) {
+	if err := m.call("UploadAttachment", contentId, filename, mediaType); err != nil {
+		return nil, err
+	}
+	return &confluence.Content{Id: m.newId(), Type: "attachment", Title: filename}, nil
+}
+
+func (m *mockConfluence) GetContentWatchers(ctx context.Context, id string) ([]confluence.User, error) {
+	return m.getWatchers("GetContentWatchers", "content/"+id)
+}
+
+func (m *mockConfluence) WatchContent(ctx context.Context, id, accountId string) error {
+	return m.setWatch("WatchContent", "content/"+id, accountId, true)
+}
+
+func (m *mockConfluence) UnwatchContent(ctx context.Context, id, accountId string) error {
+	return m.setWatch("UnwatchContent", "content/"+id, accountId, false)
+}
+
+func (m *mockConfluence) GetSpaceWatchers(ctx context.Context, key string) ([]confluence.User, error) {
+	return m.getWatchers("GetSpaceWatchers", "space/"+key)
+}
+
+func (m *mockConfluence) WatchSpace(ctx context.Context, key, accountId string) error {
+	return m.setWatch("WatchSpace", "space/"+key, accountId, true)
+}
+
+func (m *mockConfluence) UnwatchSpace(ctx context.Context, key, accountId string) error {
+	return m.setWatch("UnwatchSpace", "space/"+key, accountId, false)
+}
+
+func (m *mockConfluence) getWatchers(method, key string) ([]confluence.User, error) {
+	if err := m.call(method, key); err != nil {
+		return nil, err
+	}
+	users := []confluence.User{}
+	for _, accountId := range m.watchers[key] {
+		users = append(users, confluence.User{AccountId: accountId})
+	}
+	return users, nil
+}
+
+// setWatch adds or removes the account id from the watchers of the key.
+func (m *mockConfluence) setWatch(method, key, accountId string, watch bool) error {
+	if err := m.call(method, key, accountId); err != nil {
+		return err
+	}
+	watchers := []string{}
+	for _, watcher := range m.watchers[key] {
+		if watcher != accountId {
+			watchers = append(watchers, watcher)
+		}
+	}
+	if watch {
+		watchers = append(watchers, accountId)
+	}
+	m.watchers[key] = watchers
+	return nil
+}
+
+// GetContentVersions returns a version per version number of the content,
+// made by the mock user.
+func (m *mockConfluence) GetContentVersions(ctx context.Context, id string) ([]confluence.Version, error) {
+	if err := m.call("GetContentVersions", id); err != nil {
+		return nil, err
+	}
+	content, ok := m.contents[id]
+	if !ok {
+		return nil, fmt.Errorf("no content with id %s", id)
+	}
+	versions := []confluence.Version{}
+	for number := content.Version.Number; number > 0; number-- {
+		versions = append(versions, confluence.Version{
+			Number: number,
+			By:     &confluence.User{AccountId: "mock-account", DisplayName: "Mock User"},
+			When:   fmt.Sprintf("2023-05-%02dT09:30:00.000Z", number),
+		})
+	}
+	return versions, nil
+}
+
+func (m *mockConfluence) GetContentVersion(ctx context.Context, id string, number int) (*confluence.Version, error) {
+	if err := m.call("GetContentVersion", id, strconv.Itoa(number)); err != nil {
+		return nil, err
+	}
+	content, ok := m.contents[id]
+	if !ok || number > content.Version.Number {
+		return nil, fmt.Errorf("no version %d of content %s", number, id)
+	}
+	return &confluence.Version{Number: number, Content: content}, nil
+}
+
+// RestoreContentVersion makes a new version of the content, the body is kept.
+func (m *mockConfluence) RestoreContentVersion(ctx context.Context, id string, number int, message string, restoreTitle bool) (*confluence.Version, error) {
+	if err := m.call("RestoreContentVersion", id, strconv.Itoa(number), message, strconv.FormatBool(restoreTitle)); err != nil {
+		return nil, err
+	}
+	content, ok := m.contents[id]
+	if !ok || number >= content.Version.Number {
+		return nil, fmt.Errorf("no previous version %d of content %s", number, id)
+	}
+	content.Version = &confluence.Version{Number: content.Version.Number + 1, Message: message}
+	return content.Version, nil
+}
+
+func (m *mockConfluence) ExportSpace(ctx context.Context, key, exportType string) (*confluence.SpaceExport, error) {
+	if err := m.call("ExportSpace", key, exportType); err != nil {
+		return nil, err
+	}
+	extension := strings.ToLower(strings.TrimPrefix(exportType, "TYPE_"))
+	return &confluence.SpaceExport{DownloadLink: "http://confluence.example.com/wiki/download/temp/" + key + "-" + m.newId() + "." + extension}, nil
+}
+
+// DownloadSpaceExport writes the link as the archive.
+func (m *mockConfluence) DownloadSpaceExport(ctx context.Context, link string, w io.Writer) (int64, error) {
+	if err := m.call("DownloadSpaceExport", link); err != nil {
+		return 0, err
+	}
+	n, err := io.WriteString(w, "archive "+link)
+	return int64(n), err
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedIds(contents map[string]*confluence.Content) []string {
+	ids := make([]string, 0, len(contents))
+	for id := range contents {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// configuredResource returns the resource configured with the mock services
+// and its schema.
+func configuredResource(t *testing.T, r resource.Resource, m *mockConfluence) schema.Schema {
+	ctx := context.Background()
+	configureResp := &resource.ConfigureResponse{}
+	r.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{ProviderData: m.providerData()}, configureResp)
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("Configure: %v", configureResp.Diagnostics)
+	}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	return schemaResp.Schema
+}
+
+// fakeConfiguredResource configures the resource with a client of the fake
+// server and returns its schema and the client.
+func fakeConfiguredResource(t *testing.T, r resource.Resource, server *confluencefake.Server) (schema.Schema, *confluence.API) {
+	api, err := confluence.NewAPI("user@example.com", "token", server.SiteURL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	configureResp := &resource.ConfigureResponse{}
+	r.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{ProviderData: newProviderData(api)}, configureResp)
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("Configure: %v", configureResp.Diagnostics)
+	}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	return schemaResp.Schema, api
+}
+
+// nullTimeouts returns an unset timeouts attribute of the schema.
+func nullTimeouts(s schema.Schema) timeouts.Value {
+	return timeouts.Value{
+		Object: types.ObjectNull(s.Attributes["timeouts"].GetType().(timeouts.Type).AttrTypes),
+	}
+}
+
+// assertCalls compares the recorded calls with the wanted ones and resets them.
+func assertCalls(t *testing.T, m *mockConfluence, want ...string) {
+	t.Helper()
+	if strings.Join(m.calls, "\n") != strings.Join(want, "\n") {
+		t.Errorf("calls, wants:\n%s\nbut got:\n%s", strings.Join(want, "\n"), strings.Join(m.calls, "\n"))
+	}
+	m.calls = nil
+}
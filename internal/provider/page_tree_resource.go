@@ -0,0 +1,548 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluence"
+	"github.com/renemontilva/terraform-provider-confluence/internal/pagetree"
+)
+
+var (
+	_ resource.Resource               = &PageTreeResource{}
+	_ resource.ResourceWithConfigure  = &PageTreeResource{}
+	_ resource.ResourceWithModifyPlan = &PageTreeResource{}
+)
+
+// pageTreePageType is the type of the pages attribute values.
+var pageTreePageType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"id":        types.StringType,
+	"parent_id": types.StringType,
+	"hash":      types.StringType,
+}}
+
+func NewPageTreeResource() resource.Resource {
+	return &PageTreeResource{}
+}
+
+// PageTreeResource publishes a directory of Markdown and XHTML files as a
+// tree of pages.
+type PageTreeResource struct {
+	content     confluence.ContentService
+	attachments confluence.AttachmentService
+}
+
+type PageTreeResourceModel struct {
+	Id        types.String `tfsdk:"id"`
+	Space     types.String `tfsdk:"space"`
+	ParentId  types.String `tfsdk:"parent_id"`
+	SourceDir types.String `tfsdk:"source_dir"`
+	Pattern   types.String `tfsdk:"pattern"`
+	Pages     types.Map    `tfsdk:"pages"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// PageTreePageModel is a published page, the hash tells whether its source
+// changed since it was published and the parent id whether it was moved in
+// confluence, e.g: when its parent page was deleted.
+type PageTreePageModel struct {
+	Id       types.String `tfsdk:"id"`
+	ParentId types.String `tfsdk:"parent_id"`
+	Hash     types.String `tfsdk:"hash"`
+}
+
+func (r *PageTreeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_page_tree"
+}
+
+func (r *PageTreeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The resource ```page_tree``` publishes a directory of Markdown and XHTML files as a tree of pages under a parent page, e.g: the documentation folder of a repository. " +
+			"Every file is a page, the files of a directory are the children of its `index` file, or of a page listing them named after the directory when it has no `index` file. " +
+			"Titles are taken from the first level one heading of Markdown files or from the file name, and must be unique in the space. " +
+			"Images and files referenced with a relative path are uploaded as attachments of their page, and relative links between the files are links between the pages. " +
+			"Pages are created, updated and moved parents first, and deleted children first when their file is removed. " +
+			"The page of a removed file is moved to a new file with the same title, e.g: when the file is renamed. Pages deleted outside terraform are restored from the trash, or created again, on the next apply, " +
+			"and pages moved outside terraform are moved back under their parent.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Page tree identifier, the id of the parent page.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"space": schema.StringAttribute{
+				MarkdownDescription: "The key of the space the pages are published in. Changing it replaces every page.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"parent_id": schema.StringAttribute{
+				MarkdownDescription: "The id of the page the top level pages are published under, changing it moves them.",
+				Required:            true,
+			},
+			"source_dir": schema.StringAttribute{
+				MarkdownDescription: "The directory with the page files, relative to the working directory.",
+				Required:            true,
+			},
+			"pattern": schema.StringAttribute{
+				MarkdownDescription: "Only the files whose path relative to `source_dir` matches the glob are published, `**` matches any number of directories, e.g: `runbooks/**/*.md`. Defaults to `**`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("**"),
+			},
+			"pages": schema.MapNestedAttribute{
+				MarkdownDescription: "The published pages by file path relative to `source_dir`, the pages of directories without an `index` file end with a slash.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Page identifier.",
+							Computed:            true,
+						},
+						"parent_id": schema.StringAttribute{
+							MarkdownDescription: "The id of the parent page.",
+							Computed:            true,
+						},
+						"hash": schema.StringAttribute{
+							MarkdownDescription: "The hash of the title, parent, body and attachments of the page.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *PageTreeResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.content = data.content
+	r.attachments = data.attachments
+}
+
+// ModifyPlan reads the source directory and plans the hash of every page,
+// the id of the pages to create is unknown. Pages whose hash did not change
+// are left as they are. Unresolved relative links are reported as warnings.
+func (r *PageTreeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var plan, state PageTreeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+	if resp.Diagnostics.HasError() || plan.SourceDir.IsUnknown() || plan.Pattern.IsUnknown() {
+		return
+	}
+	pages, err := pagetree.Read(plan.SourceDir.ValueString(), plan.Pattern.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("source_dir"), "Invalid Page Tree", err.Error())
+		return
+	}
+	// Every page is created again when the tree is replaced.
+	prior := map[string]PageTreePageModel{}
+	if !req.State.Raw.IsNull() && state.Space.Equal(plan.Space) {
+		resp.Diagnostics.Append(state.Pages.ElementsAs(ctx, &prior, false)...)
+	}
+	planned := map[string]PageTreePageModel{}
+	for _, page := range pages {
+		for _, link := range page.Unresolved {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("source_dir"),
+				"Unresolved Link",
+				fmt.Sprintf("The link to %s of %s is neither a page of the tree nor a local file, it is published as plain text.", link, page.Path),
+			)
+		}
+		id := types.StringUnknown()
+		if p, ok := prior[page.Path]; ok {
+			id = p.Id
+		}
+		// The parent id is known when the parent page is already published.
+		parentId := plan.ParentId
+		if page.Parent != "" {
+			parentId = types.StringUnknown()
+			if p, ok := prior[page.Parent]; ok {
+				parentId = p.Id
+			}
+		}
+		planned[page.Path] = PageTreePageModel{Id: id, ParentId: parentId, Hash: types.StringValue(page.Hash)}
+	}
+	plannedPages, diags := types.MapValueFrom(ctx, pageTreePageType, planned)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("pages"), plannedPages)...)
+}
+
+func (r *PageTreeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data PageTreeResourceModel
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	data.Id = types.StringValue(data.ParentId.ValueString())
+	resp.Diagnostics.Append(r.publish(ctx, &data, map[string]PageTreePageModel{})...)
+	tflog.Trace(ctx, "created a page tree")
+
+	// Save data into Terraform state, the published pages are saved on
+	// error as well.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PageTreeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data PageTreeResourceModel
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	pages := map[string]PageTreePageModel{}
+	resp.Diagnostics.Append(data.Pages.ElementsAs(ctx, &pages, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for file, page := range pages {
+		content, err := r.content.GetContentById(ctx, page.Id.ValueString())
+		if errors.Is(err, confluence.ErrNotFound) {
+			// The page was deleted outside terraform, it is created again on
+			// the next apply.
+			tflog.Warn(ctx, "page not found, removing it from the state", map[string]any{
+				"file": file,
+				"id":   page.Id.ValueString(),
+			})
+			delete(pages, file)
+			continue
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read page %s of %s, got error: %s", page.Id.ValueString(), file, err))
+			return
+		}
+		// The page is moved back under its parent when it was moved, e.g:
+		// confluence moves the children of a deleted page to its parent.
+		if len(content.Ancestors) > 0 {
+			page.ParentId = types.StringValue(content.Ancestors[len(content.Ancestors)-1].Id)
+			pages[file] = page
+		}
+	}
+	data.Pages, diags = types.MapValueFrom(ctx, pageTreePageType, pages)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PageTreeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state PageTreeResourceModel
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	prior := map[string]PageTreePageModel{}
+	resp.Diagnostics.Append(state.Pages.ElementsAs(ctx, &prior, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = types.StringValue(data.ParentId.ValueString())
+	resp.Diagnostics.Append(r.publish(ctx, &data, prior)...)
+	tflog.Trace(ctx, "updated a page tree")
+
+	// Save updated data into Terraform state, the published pages are saved
+	// on error as well.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PageTreeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data PageTreeResourceModel
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	pages := map[string]PageTreePageModel{}
+	resp.Diagnostics.Append(data.Pages.ElementsAs(ctx, &pages, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.deletePages(ctx, pages, pageTreePaths(pages))...)
+	if resp.Diagnostics.HasError() {
+		// Keep the pages that were not deleted in the state.
+		data.Pages, diags = types.MapValueFrom(ctx, pageTreePageType, pages)
+		resp.Diagnostics.Append(diags...)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+	tflog.Trace(ctx, "deleted a page tree")
+}
+
+// publish creates, updates and moves the pages of the source directory
+// parents first, then deletes the prior pages whose file was removed
+// children first. The page of a removed file is reused by a new file with
+// its title, e.g: when the file is renamed or moved, titles are unique in a
+// space and creating the new page first would collide with it. For the same
+// reason a page deleted outside terraform is restored from the trash. Pages
+// whose parent id changed are moved. The pages of the model are the
+// published ones, also when an error stops the publication.
+func (r *PageTreeResource) publish(ctx context.Context, data *PageTreeResourceModel, prior map[string]PageTreePageModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	published := map[string]PageTreePageModel{}
+	for file, page := range prior {
+		published[file] = page
+	}
+	defer func() {
+		pages, d := types.MapValueFrom(ctx, pageTreePageType, published)
+		diags.Append(d...)
+		data.Pages = pages
+	}()
+
+	pages, err := pagetree.Read(data.SourceDir.ValueString(), data.Pattern.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("source_dir"), "Invalid Page Tree", err.Error())
+		return diags
+	}
+	files := map[string]bool{}
+	for _, page := range pages {
+		files[page.Path] = true
+	}
+	// removedTitles are the files of the removed pages by title.
+	removedTitles := map[string]string{}
+	for _, file := range pageTreePaths(prior) {
+		if files[file] {
+			continue
+		}
+		content, err := r.content.GetContentById(ctx, prior[file].Id.ValueString())
+		if errors.Is(err, confluence.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to read removed page %s, got error: %s", file, err))
+			return diags
+		}
+		removedTitles[content.Title] = file
+	}
+	ids := map[string]string{}
+	for _, page := range pages {
+		parentId := data.ParentId.ValueString()
+		if page.Parent != "" {
+			parentId = ids[page.Parent]
+		}
+		content := confluence.Content{
+			Type:      "page",
+			Title:     page.Title,
+			Space:     &confluence.Space{Key: data.Space.ValueString()},
+			Ancestors: []confluence.Content{{Id: parentId}},
+			Body: confluence.Body{
+				Storage: confluence.Storage{
+					Value:          page.Body,
+					Representation: "storage",
+				},
+			},
+		}
+		current, ok := prior[page.Path]
+		removed, reused := "", false
+		if !ok {
+			removed, reused = removedTitles[page.Title]
+		}
+		switch {
+		case reused:
+			content.Id = prior[removed].Id.ValueString()
+			err = r.content.UpdateContent(ctx, &content)
+			if err != nil {
+				diags.AddError("Client Error", fmt.Sprintf("Unable to move page %s to %s, got error: %s", removed, page.Path, err))
+				return diags
+			}
+			delete(published, removed)
+			delete(removedTitles, page.Title)
+			tflog.Debug(ctx, "moved page", map[string]any{"from": removed, "file": page.Path, "id": content.Id})
+		case !ok:
+			diags.Append(r.createPage(ctx, &content, page.Path)...)
+			if diags.HasError() {
+				return diags
+			}
+		case current.Hash.ValueString() != page.Hash || current.ParentId.ValueString() != parentId:
+			content.Id = current.Id.ValueString()
+			err = r.content.UpdateContent(ctx, &content)
+			if err != nil {
+				diags.AddError("Client Error", fmt.Sprintf("Unable to update page %s, got error: %s", page.Path, err))
+				return diags
+			}
+			tflog.Debug(ctx, "updated page", map[string]any{"file": page.Path, "id": content.Id})
+		default:
+			ids[page.Path] = current.Id.ValueString()
+			continue
+		}
+		ids[page.Path] = content.Id
+		if ok && current.Hash.ValueString() == page.Hash {
+			// The page was only moved.
+			current.ParentId = types.StringValue(parentId)
+			published[page.Path] = current
+			continue
+		}
+		// The page is published again when its attachments fail to upload.
+		published[page.Path] = PageTreePageModel{
+			Id:       types.StringValue(content.Id),
+			ParentId: types.StringValue(parentId),
+			Hash:     types.StringValue(""),
+		}
+		for _, attachment := range page.Attachments {
+			b, err := os.ReadFile(attachment.File)
+			if err == nil {
+				_, err = r.attachments.UploadAttachment(ctx, content.Id, attachment.Name, attachment.MediaType, b)
+			}
+			if err != nil {
+				diags.AddError("Client Error", fmt.Sprintf("Unable to upload attachment %s of page %s, got error: %s", attachment.Name, page.Path, err))
+				return diags
+			}
+		}
+		published[page.Path] = PageTreePageModel{
+			Id:       types.StringValue(content.Id),
+			ParentId: types.StringValue(parentId),
+			Hash:     types.StringValue(page.Hash),
+		}
+	}
+
+	removed := []string{}
+	for file := range published {
+		if _, ok := ids[file]; !ok {
+			removed = append(removed, file)
+		}
+	}
+	pagetree.SortChildrenFirst(removed)
+	diags.Append(r.deletePages(ctx, published, removed)...)
+	return diags
+}
+
+// createPage creates the page of a file, or restores the trashed page with its
+// title, confluence keeps the titles of trashed pages in the space.
+func (r *PageTreeResource) createPage(ctx context.Context, content *confluence.Content, file string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	trashed, err := r.content.GetContents(ctx, confluence.ContentQuery{
+		SpaceKey: content.Space.Key,
+		Title:    content.Title,
+		Type:     content.Type,
+		Status:   "trashed",
+	})
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to look up trashed page %s, got error: %s", file, err))
+		return diags
+	}
+	if len(trashed) > 0 {
+		content.Id = trashed[0].Id
+		err = r.content.RestoreContent(ctx, content)
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to restore trashed page %s, got error: %s", file, err))
+			return diags
+		}
+		tflog.Debug(ctx, "restored page", map[string]any{"file": file, "id": content.Id})
+		return diags
+	}
+	err = r.content.CreateContent(ctx, content)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to create page %s, got error: %s", file, err))
+		return diags
+	}
+	tflog.Debug(ctx, "created page", map[string]any{"file": file, "id": content.Id})
+	return diags
+}
+
+// deletePages moves the pages of the files to the trash in order, and
+// removes them from pages.
+func (r *PageTreeResource) deletePages(ctx context.Context, pages map[string]PageTreePageModel, files []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, file := range files {
+		err := r.content.DeleteContent(ctx, pages[file].Id.ValueString())
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to delete page %s, got error: %s", file, err))
+			return diags
+		}
+		delete(pages, file)
+		tflog.Debug(ctx, "deleted page", map[string]any{"file": file})
+	}
+	return diags
+}
+
+// pageTreePaths returns the files of the pages, children first.
+func pageTreePaths(pages map[string]PageTreePageModel) []string {
+	files := make([]string, 0, len(pages))
+	for file := range pages {
+		files = append(files, file)
+	}
+	pagetree.SortChildrenFirst(files)
+	return files
+}
@@ -0,0 +1,455 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluence"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluencefake"
+)
+
+func TestAccPageTreeResourceBasic(t *testing.T) {
+	dir := t.TempDir()
+	writePageTreeFiles(t, dir, map[string]string{
+		"index.md":        "# Terraform page tree\n\nThe docs.\n",
+		"ops/index.md":    "# Terraform page tree operations\n\n![diagram](diagram.svg)\n",
+		"ops/diagram.svg": `<svg xmlns="http://www.w3.org/2000/svg"/>`,
+	})
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccPageTreeResourceConfig(dir),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("confluence_page_tree.test", "pattern", "**"),
+					resource.TestCheckResourceAttr("confluence_page_tree.test", "pages.%", "2"),
+					resource.TestCheckResourceAttrSet("confluence_page_tree.test", "pages.ops/index.md.id"),
+				),
+			},
+			// Update and Read testing
+			{
+				PreConfig: func() {
+					writePageTreeFiles(t, dir, map[string]string{
+						"ops/oncall.md": "# Terraform page tree on-call\n",
+					})
+				},
+				Config: testAccPageTreeResourceConfig(dir),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("confluence_page_tree.test", "pages.%", "3"),
+					resource.TestCheckResourceAttrSet("confluence_page_tree.test", "pages.ops/oncall.md.id"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccPageTreeResourceConfig(dir string) string {
+	return fmt.Sprintf(`
+	resource "confluence_content" "parent" {
+		type  = "page"
+		space = "TERRAFORM"
+		title = "Terraform page tree parent"
+		body  = "<p>Published docs</p>"
+	}
+
+	resource "confluence_page_tree" "test" {
+		space      = "TERRAFORM"
+		parent_id  = confluence_content.parent.id
+		source_dir = %q
+	}
+	`, dir)
+}
+
+// writePageTreeFiles writes the files by slash separated path in dir.
+func writePageTreeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		name = filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestPageTreeResourceCRUD(t *testing.T) {
+	ctx := context.Background()
+	m := newMockConfluence()
+	r := &PageTreeResource{}
+	s := configuredResource(t, r, m)
+	dir := t.TempDir()
+	writePageTreeFiles(t, dir, map[string]string{
+		"intro.md":        "# Intro\n",
+		"ops/index.md":    "# Operations\n\n![diagram](diagram.svg)\n",
+		"ops/diagram.svg": "<svg/>",
+		"ops/oncall.md":   "# On-call\n",
+	})
+	parentId := m.addContent(confluence.Content{Type: "page", Title: "Docs", Space: &confluence.Space{Key: "TERRAFORM"}, Status: "current"})
+	data := PageTreeResourceModel{
+		Id:        types.StringUnknown(),
+		Space:     types.StringValue("TERRAFORM"),
+		ParentId:  types.StringValue(parentId),
+		SourceDir: types.StringValue(dir),
+		Pattern:   types.StringValue("**"),
+		Pages:     types.MapUnknown(pageTreePageType),
+		Timeouts:  nullTimeouts(s),
+	}
+
+	plan := tfsdk.Plan{Schema: s}
+	plan.Set(ctx, &data)
+	createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: s}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatal(createResp.Diagnostics)
+	}
+	assertCalls(t, m,
+		"GetContents TERRAFORM Intro trashed",
+		"CreateContent TERRAFORM Intro",
+		"GetContents TERRAFORM Operations trashed",
+		"CreateContent TERRAFORM Operations",
+		"UploadAttachment 1003 diagram.svg image/svg+xml",
+		"GetContents TERRAFORM On-call trashed",
+		"CreateContent TERRAFORM On-call",
+	)
+	pages := pageTreePages(t, createResp.State)
+	if len(pages) != 3 || pages["ops/oncall.md"].Id.ValueString() != "1005" {
+		t.Fatalf("wants 3 pages with ops/oncall.md published as 1005, but got %v", pages)
+	}
+	if got := m.contents["1005"].Ancestors[0].Id; got != "1003" {
+		t.Errorf("wants ops/oncall.md under ops/index.md, but got parent %s", got)
+	}
+
+	readResp := &fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatal(readResp.Diagnostics)
+	}
+	if len(m.calls) != 3 {
+		t.Errorf("wants a GetContentById call by page, but got %v", m.calls)
+	}
+	m.calls = nil
+
+	// Only the changed page is updated, and the removed page is deleted.
+	writePageTreeFiles(t, dir, map[string]string{"intro.md": "# Introduction\n"})
+	if err := os.Remove(filepath.Join(dir, "ops", "oncall.md")); err != nil {
+		t.Fatal(err)
+	}
+	readResp.State.Get(ctx, &data)
+	data.Pages = types.MapUnknown(pageTreePageType)
+	plan.Set(ctx, &data)
+	updateResp := &fwresource.UpdateResponse{State: readResp.State}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: readResp.State}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatal(updateResp.Diagnostics)
+	}
+	assertCalls(t, m,
+		"GetContentById 1005",
+		"UpdateContent 1002 Introduction",
+		"DeleteContent 1005",
+	)
+	if pages := pageTreePages(t, updateResp.State); len(pages) != 2 {
+		t.Errorf("wants 2 pages, but got %v", pages)
+	}
+
+	// Changing the parent moves the top level pages only.
+	newParentId := m.addContent(confluence.Content{Type: "page", Title: "Archive", Space: &confluence.Space{Key: "TERRAFORM"}, Status: "current"})
+	updateResp.State.Get(ctx, &data)
+	data.ParentId = types.StringValue(newParentId)
+	plan.Set(ctx, &data)
+	moveResp := &fwresource.UpdateResponse{State: updateResp.State}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: updateResp.State}, moveResp)
+	if moveResp.Diagnostics.HasError() {
+		t.Fatal(moveResp.Diagnostics)
+	}
+	assertCalls(t, m,
+		"UpdateContent 1002 Introduction",
+		"UpdateContent 1003 Operations",
+	)
+	if got := m.contents["1003"].Ancestors[0].Id; got != newParentId {
+		t.Errorf("wants ops/index.md moved under %s, but got parent %s", newParentId, got)
+	}
+
+	deleteResp := &fwresource.DeleteResponse{State: moveResp.State}
+	r.Delete(ctx, fwresource.DeleteRequest{State: moveResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatal(deleteResp.Diagnostics)
+	}
+	assertCalls(t, m,
+		"DeleteContent 1003",
+		"DeleteContent 1002",
+	)
+}
+
+func TestPageTreeResourceMovePages(t *testing.T) {
+	ctx := context.Background()
+	m := newMockConfluence()
+	r := &PageTreeResource{}
+	s := configuredResource(t, r, m)
+	dir := t.TempDir()
+	writePageTreeFiles(t, dir, map[string]string{
+		"ops/oncall.md":  "# On-call\n",
+		"ops/restart.md": "# Restart\n",
+	})
+	data := PageTreeResourceModel{
+		Id:        types.StringUnknown(),
+		Space:     types.StringValue("TERRAFORM"),
+		ParentId:  types.StringValue("1"),
+		SourceDir: types.StringValue(dir),
+		Pattern:   types.StringValue("**"),
+		Pages:     types.MapUnknown(pageTreePageType),
+		Timeouts:  nullTimeouts(s),
+	}
+
+	plan := tfsdk.Plan{Schema: s}
+	plan.Set(ctx, &data)
+	createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: s}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatal(createResp.Diagnostics)
+	}
+	assertCalls(t, m,
+		"GetContents TERRAFORM ops trashed",
+		"CreateContent TERRAFORM ops",
+		"GetContents TERRAFORM On-call trashed",
+		"CreateContent TERRAFORM On-call",
+		"GetContents TERRAFORM Restart trashed",
+		"CreateContent TERRAFORM Restart",
+	)
+
+	// The pages of a moved file, a renamed file and a directory given an
+	// index file keep their title, they are moved instead of created again.
+	for _, name := range []string{"ops/oncall.md", "ops/restart.md"} {
+		if err := os.Remove(filepath.Join(dir, filepath.FromSlash(name))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writePageTreeFiles(t, dir, map[string]string{
+		"ops/index.md":           "# ops\n\nThe operations.\n",
+		"ops/restart-service.md": "# Restart\n",
+		"runbooks/oncall.md":     "# On-call\n",
+	})
+	createResp.State.Get(ctx, &data)
+	data.Pages = types.MapUnknown(pageTreePageType)
+	plan.Set(ctx, &data)
+	updateResp := &fwresource.UpdateResponse{State: createResp.State}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: createResp.State}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatal(updateResp.Diagnostics)
+	}
+	assertCalls(t, m,
+		"GetContentById 1002",
+		"GetContentById 1003",
+		"GetContentById 1001",
+		"UpdateContent 1001 ops",
+		"GetContents TERRAFORM runbooks trashed",
+		"CreateContent TERRAFORM runbooks",
+		"UpdateContent 1003 Restart",
+		"UpdateContent 1002 On-call",
+	)
+	pages := pageTreePages(t, updateResp.State)
+	want := map[string]string{
+		"ops/index.md":           "1001",
+		"ops/restart-service.md": "1003",
+		"runbooks/":              "1004",
+		"runbooks/oncall.md":     "1002",
+	}
+	if len(pages) != len(want) {
+		t.Fatalf("wants pages %v, but got %v", want, pages)
+	}
+	for file, id := range want {
+		if got := pages[file].Id.ValueString(); got != id {
+			t.Errorf("wants %s published as %s, but got %s", file, id, got)
+		}
+	}
+	if got := m.contents["1002"].Ancestors[0].Id; got != "1004" {
+		t.Errorf("wants runbooks/oncall.md under runbooks/, but got parent %s", got)
+	}
+}
+
+func TestPageTreeResourceReadDeletedPage(t *testing.T) {
+	ctx := context.Background()
+	m := newMockConfluence()
+	r := &PageTreeResource{}
+	s := configuredResource(t, r, m)
+	dir := t.TempDir()
+	writePageTreeFiles(t, dir, map[string]string{
+		"oncall.md":  "# On-call\n",
+		"runbook.md": "# Runbook\n",
+	})
+	data := PageTreeResourceModel{
+		Id:        types.StringUnknown(),
+		Space:     types.StringValue("TERRAFORM"),
+		ParentId:  types.StringValue("1"),
+		SourceDir: types.StringValue(dir),
+		Pattern:   types.StringValue("**"),
+		Pages:     types.MapUnknown(pageTreePageType),
+		Timeouts:  nullTimeouts(s),
+	}
+
+	plan := tfsdk.Plan{Schema: s}
+	plan.Set(ctx, &data)
+	createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: s}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatal(createResp.Diagnostics)
+	}
+	// The page is deleted outside terraform, it is removed from the pages
+	// so the next apply restores it.
+	m.contents["1001"].Status = "trashed"
+
+	readResp := &fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatal(readResp.Diagnostics)
+	}
+	pages := pageTreePages(t, readResp.State)
+	if _, ok := pages["oncall.md"]; ok || len(pages) != 1 {
+		t.Errorf("wants only runbook.md left, but got %v", pages)
+	}
+}
+
+func TestPageTreeResourceRestoreDeletedPage(t *testing.T) {
+	ctx := context.Background()
+	server := confluencefake.NewServer()
+	t.Cleanup(server.Close)
+	server.AddSpace("TERRAFORM", "terraform")
+	parentId := server.AddContent("TERRAFORM", "page", "Docs", "<p>docs</p>", "")
+	api, err := confluence.NewAPI("user@example.com", "token", server.SiteURL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &PageTreeResource{}
+	configureResp := &fwresource.ConfigureResponse{}
+	r.Configure(ctx, fwresource.ConfigureRequest{ProviderData: newProviderData(api)}, configureResp)
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	s := schemaResp.Schema
+	dir := t.TempDir()
+	writePageTreeFiles(t, dir, map[string]string{
+		"ops/index.md":  "# Operations\n",
+		"ops/oncall.md": "# On-call\n",
+	})
+	data := PageTreeResourceModel{
+		Id:        types.StringUnknown(),
+		Space:     types.StringValue("TERRAFORM"),
+		ParentId:  types.StringValue(parentId),
+		SourceDir: types.StringValue(dir),
+		Pattern:   types.StringValue("**"),
+		Pages:     types.MapUnknown(pageTreePageType),
+		Timeouts:  nullTimeouts(s),
+	}
+
+	plan := tfsdk.Plan{Schema: s}
+	plan.Set(ctx, &data)
+	createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: s}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatal(createResp.Diagnostics)
+	}
+	created := pageTreePages(t, createResp.State)
+	opsId, oncallId := created["ops/index.md"].Id.ValueString(), created["ops/oncall.md"].Id.ValueString()
+
+	// The parent page is deleted outside terraform, confluence keeps its
+	// title in the trash and moves its children to the parent of the tree.
+	if err := api.DeleteContent(ctx, opsId); err != nil {
+		t.Fatal(err)
+	}
+	readResp := &fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatal(readResp.Diagnostics)
+	}
+	pages := pageTreePages(t, readResp.State)
+	if _, ok := pages["ops/index.md"]; ok || pages["ops/oncall.md"].ParentId.ValueString() != parentId {
+		t.Fatalf("wants ops/index.md removed and ops/oncall.md moved under %s, but got %v", parentId, pages)
+	}
+
+	readResp.State.Get(ctx, &data)
+	data.Pages = types.MapUnknown(pageTreePageType)
+	plan.Set(ctx, &data)
+	updateResp := &fwresource.UpdateResponse{State: readResp.State}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: readResp.State}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatal(updateResp.Diagnostics)
+	}
+	pages = pageTreePages(t, updateResp.State)
+	if pages["ops/index.md"].Id.ValueString() != opsId {
+		t.Errorf("wants ops/index.md restored as %s, but got %v", opsId, pages)
+	}
+	oncall, err := api.GetContentById(ctx, oncallId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := oncall.Ancestors[len(oncall.Ancestors)-1].Id; got != opsId || pages["ops/oncall.md"].ParentId.ValueString() != opsId {
+		t.Errorf("wants ops/oncall.md moved back under %s, but got parent %s and %v", opsId, got, pages)
+	}
+}
+
+func TestPageTreeResourcePartialCreate(t *testing.T) {
+	ctx := context.Background()
+	m := newMockConfluence()
+	r := &PageTreeResource{}
+	s := configuredResource(t, r, m)
+	dir := t.TempDir()
+	writePageTreeFiles(t, dir, map[string]string{
+		"oncall.md":   "# On-call\n\n![diagram](diagram.svg)\n",
+		"diagram.svg": "<svg/>",
+		"runbook.md":  "# Runbook\n",
+	})
+	m.errs["UploadAttachment"] = fmt.Errorf("attachment too large")
+	data := PageTreeResourceModel{
+		Id:        types.StringUnknown(),
+		Space:     types.StringValue("TERRAFORM"),
+		ParentId:  types.StringValue("1"),
+		SourceDir: types.StringValue(dir),
+		Pattern:   types.StringValue("**"),
+		Pages:     types.MapUnknown(pageTreePageType),
+		Timeouts:  nullTimeouts(s),
+	}
+
+	plan := tfsdk.Plan{Schema: s}
+	plan.Set(ctx, &data)
+	createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: s}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, createResp)
+	if !createResp.Diagnostics.HasError() {
+		t.Fatal("wants an error")
+	}
+	assertCalls(t, m,
+		"GetContents TERRAFORM On-call trashed",
+		"CreateContent TERRAFORM On-call",
+		"UploadAttachment 1001 diagram.svg image/svg+xml",
+	)
+	// The created page is kept so it is updated, not created again.
+	pages := pageTreePages(t, createResp.State)
+	if len(pages) != 1 || pages["oncall.md"].Id.ValueString() != "1001" || pages["oncall.md"].Hash.ValueString() != "" {
+		t.Errorf("wants oncall.md published as 1001 without hash, but got %v", pages)
+	}
+}
+
+// pageTreePages returns the pages of the page tree state.
+func pageTreePages(t *testing.T, state tfsdk.State) map[string]PageTreePageModel {
+	t.Helper()
+	var data PageTreeResourceModel
+	if diags := state.Get(context.Background(), &data); diags.HasError() {
+		t.Fatal(diags)
+	}
+	pages := map[string]PageTreePageModel{}
+	if diags := data.Pages.ElementsAs(context.Background(), &pages, false); diags.HasError() {
+		t.Fatal(diags)
+	}
+	return pages
+}
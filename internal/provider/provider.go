@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"os"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -14,6 +15,15 @@ import (
 	"github.com/renemontilva/terraform-provider-confluence/internal/confluence"
 )
 
+// Default operation timeouts, used when a resource does not set them in its
+// timeouts block.
+const (
+	defaultCreateTimeout = 20 * time.Minute
+	defaultReadTimeout   = 5 * time.Minute
+	defaultUpdateTimeout = 20 * time.Minute
+	defaultDeleteTimeout = 20 * time.Minute
+)
+
 // Ensure ConfluenceProvider satisfies various provider interfaces.
 var _ provider.Provider = &ConfluenceProvider{}
 
@@ -30,9 +40,10 @@ type ConfluenceProvider struct {
 
 // ConfluenceProviderModel describes the provider data model.
 type ConfluenceProviderModel struct {
-	Host  types.String `tfsdk:"host"`
-	User  types.String `tfsdk:"user"`
-	Token types.String `tfsdk:"token"`
+	Host        types.String `tfsdk:"host"`
+	User        types.String `tfsdk:"user"`
+	Token       types.String `tfsdk:"token"`
+	ContextPath types.String `tfsdk:"context_path"`
 }
 
 // Metadata returns the provider type name.
@@ -43,7 +54,7 @@ func (p *ConfluenceProvider) Metadata(ctx context.Context, req provider.Metadata
 // Schema defines the provider-level schema for configuration data.
 func (p *ConfluenceProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: `Confluence provider interacts with atlassian confluence cloud service.
+		Description: `Confluence provider interacts with atlassian confluence cloud and confluence Data Center.
 		You must configured the provider with the proper credentials before you can use it.`,
 		Attributes: map[string]schema.Attribute{
 			"host": schema.StringAttribute{
@@ -51,14 +62,19 @@ func (p *ConfluenceProvider) Schema(_ context.Context, _ provider.SchemaRequest,
 				Optional:            true,
 			},
 			"user": schema.StringAttribute{
-				MarkdownDescription: "Confluence's service username",
+				MarkdownDescription: "Confluence's service username, required on confluence cloud. Without user the token is sent as a Data Center personal access token.",
 				Optional:            true,
 			},
 			"token": schema.StringAttribute{
-				MarkdownDescription: "Confluence's username token",
+				MarkdownDescription: "Confluence's username token, the API token of the user or a Data Center personal access token.",
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"context_path": schema.StringAttribute{
+				MarkdownDescription: "Path the confluence site is served under, the REST API is served from `<context_path>/rest/api`. " +
+					"Defaults to the path of `host`, `/wiki` on confluence cloud and the root path on Data Center otherwise.",
+				Optional: true,
+			},
 		},
 	}
 }
@@ -100,6 +116,14 @@ func (p *ConfluenceProvider) Configure(ctx context.Context, req provider.Configu
 		)
 	}
 
+	if config.ContextPath.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("context_path"),
+			"Unknown confluence context path value",
+			"The provider cannot create the Confluence API client as there is an unknown configuration value for the Confluence API context path",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -109,6 +133,7 @@ func (p *ConfluenceProvider) Configure(ctx context.Context, req provider.Configu
 	host := os.Getenv("CONFLUENCE_HOST")
 	user := os.Getenv("CONFLUENCE_USER")
 	token := os.Getenv("CONFLUENCE_TOKEN")
+	contextPath, setContextPath := os.LookupEnv("CONFLUENCE_CONTEXT_PATH")
 
 	if !config.Host.IsNull() {
 		host = config.Host.ValueString()
@@ -119,6 +144,10 @@ func (p *ConfluenceProvider) Configure(ctx context.Context, req provider.Configu
 	if !config.Token.IsNull() {
 		token = config.Token.ValueString()
 	}
+	if !config.ContextPath.IsNull() {
+		contextPath = config.ContextPath.ValueString()
+		setContextPath = true
+	}
 
 	if host == "" {
 		resp.Diagnostics.AddAttributeError(
@@ -129,15 +158,6 @@ func (p *ConfluenceProvider) Configure(ctx context.Context, req provider.Configu
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
-	if user == "" {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("user"),
-			"Missing Confluence User",
-			"The provider cannot create the Confluence API client as there is a missing or empty value for the Confluence API user. "+
-				"Set the host value in the configuration or use the CONFLUENCE_USER environment variable. "+
-				"If either is already set, ensure the value is not empty.",
-		)
-	}
 	if token == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("token"),
@@ -168,8 +188,24 @@ func (p *ConfluenceProvider) Configure(ctx context.Context, req provider.Configu
 		)
 		return
 	}
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	if setContextPath {
+		client.SetContextPath(contextPath)
+	}
+	// Confluence cloud only accepts basic auth with the user and its API
+	// token, Data Center accepts a personal access token on its own.
+	if user == "" && client.IsCloud() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("user"),
+			"Missing Confluence User",
+			"The provider cannot create the Confluence API client as there is a missing or empty value for the Confluence API user, confluence cloud requires it. "+
+				"Set the user value in the configuration or use the CONFLUENCE_USER environment variable. "+
+				"If either is already set, ensure the value is not empty.",
+		)
+		return
+	}
+	data := newProviderData(client)
+	resp.DataSourceData = data
+	resp.ResourceData = data
 	tflog.Info(ctx, "Configured Confluence Client", map[string]any{
 		"success": true,
 	})
@@ -179,11 +215,32 @@ func (p *ConfluenceProvider) Resources(ctx context.Context) []func() resource.Re
 	return []func() resource.Resource{
 		NewContentResource,
 		NewSpaceResource,
+		NewContentPropertyResource,
+		NewSpacePropertyResource,
+		NewBlogPostResource,
+		NewCommentResource,
+		NewTemplateResource,
+		NewPageTreeResource,
+		NewContentCopyResource,
+		NewContentWatchersResource,
+		NewSpaceWatchersResource,
+		NewContentRestoreResource,
+		NewSpaceExportResource,
+		NewGroupResource,
+		NewGroupMembershipResource,
 	}
 }
 
 func (p *ConfluenceProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewSpaceDataSource,
+		NewContentPropertyDataSource,
+		NewSpacesDataSource,
+		NewUserDataSource,
+		NewCurrentUserDataSource,
+		NewGroupDataSource,
+		NewBlogPostsDataSource,
+		NewTemplatesDataSource,
+		NewContentVersionsDataSource,
 	}
 }
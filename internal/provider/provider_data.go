@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluence"
+)
+
+// providerData is passed by Configure to resources and data sources, unit
+// tests build it with fake services instead of a *confluence.API.
+type providerData struct {
+	content     confluence.ContentService
+	hierarchy   confluence.PageHierarchyService
+	versions    confluence.VersionService
+	exports     confluence.SpaceExportService
+	attachments confluence.AttachmentService
+	blogPosts   confluence.BlogPostService
+	comments    confluence.CommentService
+	labels      confluence.LabelService
+	spaces      confluence.SpaceService
+	templates   confluence.TemplateService
+
+	contentProperties confluence.ContentPropertyService
+	spaceProperties   confluence.SpacePropertyService
+
+	users    confluence.UserService
+	groups   confluence.GroupService
+	watchers confluence.WatcherService
+
+	// cloud is set when the provider is configured against a confluence
+	// cloud site, resources that need Data Center report it on plan.
+	cloud bool
+}
+
+func newProviderData(api *confluence.API) *providerData {
+	return &providerData{
+		content:     api,
+		hierarchy:   api,
+		versions:    api,
+		exports:     api,
+		attachments: api,
+		blogPosts:   api,
+		comments:    api,
+		labels:      api,
+		spaces:      api,
+		templates:   api,
+
+		contentProperties: api,
+		spaceProperties:   api,
+
+		users:    api,
+		groups:   api,
+		watchers: api,
+
+		cloud: api.IsCloud(),
+	}
+}
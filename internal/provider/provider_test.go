@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluencefake"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -17,13 +18,27 @@ var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServe
 }
 
 func testAccPreCheck(t *testing.T) {
+	// Without a confluence host the acceptance tests run offline against the
+	// in-memory fake confluence server.
 	if v := os.Getenv("CONFLUENCE_HOST"); v == "" {
-		t.Fatal("CONFLUENCE_HOST must be set for acceptance tests")
-	}
-	if v := os.Getenv("CONFLUENCE_USER"); v == "" {
-		t.Fatal("CONFLUENCE_USER must be set for acceptance tests")
+		testAccFakeServer(t)
+		return
 	}
 	if v := os.Getenv("CONFLUENCE_TOKEN"); v == "" {
 		t.Fatal("CONFLUENCE_TOKEN must be set for acceptance tests")
 	}
 }
+
+// testAccFakeServer starts a fake confluence server with a DEVOPS space and a
+// confluence-users group, and points the provider environment variables to it.
+func testAccFakeServer(t *testing.T) *confluencefake.Server {
+	server := confluencefake.NewServer()
+	t.Cleanup(server.Close)
+	server.AddSpace("DEVOPS", "devops")
+	server.AddGroup("confluence-users", confluencefake.CurrentAccountId)
+
+	t.Setenv("CONFLUENCE_HOST", server.SiteURL())
+	t.Setenv("CONFLUENCE_USER", "user@example.com")
+	t.Setenv("CONFLUENCE_TOKEN", "token")
+	return server
+}
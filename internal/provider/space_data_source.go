@@ -20,7 +20,7 @@ func NewSpaceDataSource() datasource.DataSource {
 }
 
 type spaceDataSource struct {
-	client *confluence.API
+	spaces confluence.SpaceService
 }
 
 type SpaceDataSourceModel struct {
@@ -73,7 +73,7 @@ func (d *spaceDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	space, err := d.client.GetSpace(data.Key.ValueString())
+	space, err := d.spaces.GetSpace(ctx, data.Key.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Space Data Source Client Error", err.Error())
 		return
@@ -96,13 +96,13 @@ func (d *spaceDataSource) Configure(_ context.Context, req datasource.ConfigureR
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*confluence.API)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected DataSource Configure Type",
-			fmt.Sprintf("Expected *confluence.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
-	d.client = client
+	d.spaces = data.spaces
 }
@@ -0,0 +1,334 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluence"
+)
+
+// Space export formats, XML exports can be imported in another site, HTML
+// and PDF exports are meant to be read.
+const (
+	spaceExportFormatXML  = "xml"
+	spaceExportFormatHTML = "html"
+	spaceExportFormatPDF  = "pdf"
+)
+
+var (
+	_ resource.Resource               = &SpaceExportResource{}
+	_ resource.ResourceWithConfigure  = &SpaceExportResource{}
+	_ resource.ResourceWithModifyPlan = &SpaceExportResource{}
+)
+
+// spaceExportTypes maps the formats of the resource to the export types of
+// the remote API.
+var spaceExportTypes = map[string]string{
+	spaceExportFormatXML:  confluence.SpaceExportXML,
+	spaceExportFormatHTML: confluence.SpaceExportHTML,
+	spaceExportFormatPDF:  confluence.SpaceExportPDF,
+}
+
+func NewSpaceExportResource() resource.Resource {
+	return &SpaceExportResource{}
+}
+
+// SpaceExportResource exports a space once, when it is created, and keeps
+// the archive in a local file.
+type SpaceExportResource struct {
+	exports confluence.SpaceExportService
+	cloud   bool
+}
+
+type SpaceExportResourceModel struct {
+	Id       types.String `tfsdk:"id"`
+	SpaceKey types.String `tfsdk:"space_key"`
+	Format   types.String `tfsdk:"format"`
+	Path     types.String `tfsdk:"path"`
+	Triggers types.Map    `tfsdk:"triggers"`
+	Checksum types.String `tfsdk:"checksum"`
+	Size     types.Int64  `tfsdk:"size"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *SpaceExportResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_space_export"
+}
+
+func (r *SpaceExportResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The resource ```space_export``` exports a space to an archive and writes it to a local file, e.g: to keep " +
+			"a snapshot of a space for compliance. The export runs when the resource is created, changing `triggers` exports the " +
+			"space again. The export is created again when the file is removed or changed outside terraform, destroying the " +
+			"resource removes the file. Confluence has no REST API to export a space, only confluence Data Center with the " +
+			"JSON-RPC remote API enabled is supported: the space is exported with its `exportSpace` method. The remote API is " +
+			"disabled by default on recent Data Center versions and was removed in Confluence 9, where the export fails. The " +
+			"export is synchronous, confluence builds the whole archive within the create timeout. Confluence cloud has no API " +
+			"to export a space, the plan fails when the provider is configured with a cloud site.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Export identifier, the file name of the archive confluence built.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"space_key": schema.StringAttribute{
+				MarkdownDescription: "The key of the space to export.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"format": schema.StringAttribute{
+				MarkdownDescription: "The format of the export, `xml` (a zip archive that can be imported in another site), `html` " +
+					"(a zip archive of the pages) or `pdf`. Defaults to `xml`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(spaceExportFormatXML),
+				Validators: []validator.String{
+					stringvalidator.OneOf(spaceExportFormatXML, spaceExportFormatHTML, spaceExportFormatPDF),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "The local file the archive is written to, missing directories are created.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values that export the space again when they change, e.g: the quarter of the snapshot.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"checksum": schema.StringAttribute{
+				MarkdownDescription: "The hex encoded SHA-256 checksum of the archive.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"size": schema.Int64Attribute{
+				MarkdownDescription: "The size of the archive in bytes.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+			}),
+		},
+	}
+}
+
+func (r *SpaceExportResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.exports = data.exports
+	r.cloud = data.cloud
+}
+
+// ModifyPlan fails the plan on confluence cloud, where spaces can not be
+// exported through the API.
+func (r *SpaceExportResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(dataCenterOnly(r.cloud, req.Plan, "confluence_space_export",
+		"Confluence Cloud has no API to export a space, export it from the space settings.")...)
+}
+
+func (r *SpaceExportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SpaceExportResourceModel
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	key := data.SpaceKey.ValueString()
+	export, err := r.exports.ExportSpace(ctx, key, spaceExportTypes[data.Format.ValueString()])
+	if errors.Is(err, confluence.ErrSpaceExportUnsupported) {
+		resp.Diagnostics.AddError(
+			"Space Export Unsupported",
+			fmt.Sprintf("Unable to export space %s, the JSON-RPC remote API of the site is disabled or was removed, e.g: on "+
+				"Confluence 9. Enable the remote API or export the space from the space settings, got error: %s", key, err),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to export space %s, got error: %s", key, err))
+		return
+	}
+	checksum, size, err := r.writeArchive(ctx, export.DownloadLink, data.Path.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Space Export Error", fmt.Sprintf("Unable to write the export of space %s to %s, got error: %s", key, data.Path.ValueString(), err))
+		return
+	}
+	data.Id = types.StringValue(exportName(export.DownloadLink))
+	data.Checksum = types.StringValue(checksum)
+	data.Size = types.Int64Value(size)
+	tflog.Info(ctx, "exported a space", map[string]any{"space_key": key, "path": data.Path.ValueString(), "size": size})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read checks the local archive, the export is created again when the file
+// was removed or changed.
+func (r *SpaceExportResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SpaceExportResourceModel
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	checksum, err := fileChecksum(data.Path.ValueString())
+	if errors.Is(err, fs.ErrNotExist) || (err == nil && checksum != data.Checksum.ValueString()) {
+		tflog.Warn(ctx, "space export archive removed or changed, removing it from the state", map[string]any{
+			"space_key": data.SpaceKey.ValueString(),
+			"path":      data.Path.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Space Export Error", fmt.Sprintf("Unable to read the space export %s, got error: %s", data.Path.ValueString(), err))
+		return
+	}
+
+	// Set refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update only stores the timeouts, every other attribute exports again.
+func (r *SpaceExportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data SpaceExportResourceModel
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete removes the local archive, confluence removes the archives it
+// keeps for download on its own.
+func (r *SpaceExportResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SpaceExportResourceModel
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := os.Remove(data.Path.ValueString())
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		resp.Diagnostics.AddError("Space Export Error", fmt.Sprintf("Unable to remove the space export %s, got error: %s", data.Path.ValueString(), err))
+		return
+	}
+	tflog.Trace(ctx, "removed a space export")
+}
+
+// exportName returns the file name of the archive a download link points to,
+// e.g: Confluence-space-export-DOCS-123.xml.zip.
+func exportName(link string) string {
+	if u, err := url.Parse(link); err == nil {
+		link = u.Path
+	}
+	return path.Base(link)
+}
+
+// writeArchive downloads the archive to a temporary file next to path and
+// renames it, so path never holds a partial archive. It returns the checksum
+// and size of the archive.
+func (r *SpaceExportResource) writeArchive(ctx context.Context, link, path string) (string, int64, error) {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", 0, err
+	}
+	f, err := os.CreateTemp(dir, "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return "", 0, err
+	}
+	defer os.Remove(f.Name())
+	h := sha256.New()
+	size, err := r.exports.DownloadSpaceExport(ctx, link, io.MultiWriter(f, h))
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return "", 0, err
+	}
+	if err := os.Chmod(f.Name(), 0o644); err != nil {
+		return "", 0, err
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), size, nil
+}
+
+// fileChecksum returns the hex encoded SHA-256 checksum of a file.
+func fileChecksum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
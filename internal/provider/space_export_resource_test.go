@@ -0,0 +1,229 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluence"
+)
+
+func TestAccSpaceExportResourceBasic(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "exports", "devops.xml.zip")
+	var firstId string
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccSpaceExportResourceConfig(path, "2023-Q1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("confluence_space_export.test", "format", "xml"),
+					resource.TestCheckResourceAttrSet("confluence_space_export.test", "checksum"),
+					resource.TestCheckResourceAttrSet("confluence_space_export.test", "size"),
+					testAccCheckSpaceExportFile("confluence_space_export.test", &firstId),
+				),
+			},
+			// Changing the triggers exports again
+			{
+				Config: testAccSpaceExportResourceConfig(path, "2023-Q2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("confluence_space_export.test", "triggers.quarter", "2023-Q2"),
+					resource.TestCheckResourceAttrWith("confluence_space_export.test", "id", func(id string) error {
+						if id == firstId {
+							return fmt.Errorf("wants a new export, but got the export %s again", id)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckSpaceExportFile checks the checksum of the archive written and
+// stores the export id.
+func testAccCheckSpaceExportFile(name string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found", name)
+		}
+		checksum, err := fileChecksum(rs.Primary.Attributes["path"])
+		if err != nil {
+			return err
+		}
+		if checksum != rs.Primary.Attributes["checksum"] {
+			return fmt.Errorf("wants checksum %s, but the archive has %s", rs.Primary.Attributes["checksum"], checksum)
+		}
+		*id = rs.Primary.ID
+		return nil
+	}
+}
+
+func testAccSpaceExportResourceConfig(path, quarter string) string {
+	return fmt.Sprintf(`
+resource "confluence_space_export" "test" {
+  space_key = "DEVOPS"
+  path      = %q
+
+  triggers = {
+    quarter = %q
+  }
+}
+`, path, quarter)
+}
+
+func TestSpaceExportResourceCRUD(t *testing.T) {
+	ctx := context.Background()
+	m := newMockConfluence()
+	r := &SpaceExportResource{}
+	s := configuredResource(t, r, m)
+	path := filepath.Join(t.TempDir(), "exports", "devops.pdf")
+	data := SpaceExportResourceModel{
+		Id:       types.StringUnknown(),
+		SpaceKey: types.StringValue("DEVOPS"),
+		Format:   types.StringValue(spaceExportFormatPDF),
+		Path:     types.StringValue(path),
+		Triggers: types.MapNull(types.StringType),
+		Checksum: types.StringUnknown(),
+		Size:     types.Int64Unknown(),
+		Timeouts: nullTimeouts(s),
+	}
+
+	plan := tfsdk.Plan{Schema: s}
+	plan.Set(ctx, &data)
+	createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: s}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatal(createResp.Diagnostics)
+	}
+	assertCalls(t, m,
+		"ExportSpace DEVOPS TYPE_PDF",
+		"DownloadSpaceExport http://confluence.example.com/wiki/download/temp/DEVOPS-1001.pdf",
+	)
+	archive, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(archive)
+	var got SpaceExportResourceModel
+	createResp.State.Get(ctx, &got)
+	if string(archive) != "archive http://confluence.example.com/wiki/download/temp/DEVOPS-1001.pdf" {
+		t.Errorf("wants the downloaded archive, but got %q", archive)
+	}
+	if got.Checksum.ValueString() != hex.EncodeToString(sum[:]) || got.Size.ValueInt64() != int64(len(archive)) {
+		t.Errorf("wants checksum %x and size %d, but got %s and %d", sum, len(archive), got.Checksum, got.Size.ValueInt64())
+	}
+	if got.Id.ValueString() != "DEVOPS-1001.pdf" {
+		t.Errorf("wants the archive file name, but got %s", got.Id)
+	}
+
+	readResp := &fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatal(readResp.Diagnostics)
+	}
+	if readResp.State.Raw.IsNull() {
+		t.Fatal("wants the export kept while the archive is unchanged")
+	}
+
+	// An archive changed outside terraform is exported again.
+	if err := os.WriteFile(path, []byte("tampered"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	changedResp := &fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, changedResp)
+	if changedResp.Diagnostics.HasError() {
+		t.Fatal(changedResp.Diagnostics)
+	}
+	if !changedResp.State.Raw.IsNull() {
+		t.Error("wants the export removed from the state when the archive changed")
+	}
+
+	deleteResp := &fwresource.DeleteResponse{State: readResp.State}
+	r.Delete(ctx, fwresource.DeleteRequest{State: readResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatal(deleteResp.Diagnostics)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("wants the archive removed, but got %v", err)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 0 {
+		t.Errorf("wants no temporary files left, but got %v", entries)
+	}
+
+	m.errs["ExportSpace"] = fmt.Errorf("space not found")
+	createResp = &fwresource.CreateResponse{State: tfsdk.State{Schema: s}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, createResp)
+	if !createResp.Diagnostics.HasError() {
+		t.Error("wants an error when the export fails")
+	}
+}
+
+func TestSpaceExportResourceCreateUnsupported(t *testing.T) {
+	ctx := context.Background()
+	m := newMockConfluence()
+	r := &SpaceExportResource{}
+	s := configuredResource(t, r, m)
+	// The site runs Confluence 9, the remote API was removed.
+	m.errs["ExportSpace"] = fmt.Errorf("ExportSpace gets error: %w", confluence.ErrSpaceExportUnsupported)
+	data := SpaceExportResourceModel{
+		Id:       types.StringUnknown(),
+		SpaceKey: types.StringValue("DEVOPS"),
+		Format:   types.StringValue(spaceExportFormatXML),
+		Path:     types.StringValue(filepath.Join(t.TempDir(), "devops.xml.zip")),
+		Triggers: types.MapNull(types.StringType),
+		Checksum: types.StringUnknown(),
+		Size:     types.Int64Unknown(),
+		Timeouts: nullTimeouts(s),
+	}
+
+	plan := tfsdk.Plan{Schema: s}
+	plan.Set(ctx, &data)
+	createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: s}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, createResp)
+	if !createResp.Diagnostics.HasError() || createResp.Diagnostics[0].Summary() != "Space Export Unsupported" {
+		t.Fatalf("wants a space export unsupported error, but got %v", createResp.Diagnostics)
+	}
+	assertCalls(t, m, "ExportSpace DEVOPS TYPE_XML")
+}
+
+func TestSpaceExportResourceModifyPlan(t *testing.T) {
+	ctx := context.Background()
+	m := newMockConfluence()
+	r := &SpaceExportResource{}
+	s := configuredResource(t, r, m)
+	data := SpaceExportResourceModel{
+		Id:       types.StringUnknown(),
+		SpaceKey: types.StringValue("DEVOPS"),
+		Format:   types.StringValue(spaceExportFormatXML),
+		Path:     types.StringValue(filepath.Join(t.TempDir(), "devops.xml.zip")),
+		Triggers: types.MapNull(types.StringType),
+		Checksum: types.StringUnknown(),
+		Size:     types.Int64Unknown(),
+		Timeouts: nullTimeouts(s),
+	}
+	plan := tfsdk.Plan{Schema: s}
+	plan.Set(ctx, &data)
+
+	for _, cloud := range []bool{false, true} {
+		r.cloud = cloud
+		resp := &fwresource.ModifyPlanResponse{Plan: plan}
+		r.ModifyPlan(ctx, fwresource.ModifyPlanRequest{Plan: plan}, resp)
+		if resp.Diagnostics.HasError() != cloud {
+			t.Errorf("cloud %v, wants error %v, but got %v", cloud, cloud, resp.Diagnostics)
+		}
+	}
+}
@@ -0,0 +1,252 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluence"
+)
+
+var (
+	_ resource.Resource                = &SpacePropertyResource{}
+	_ resource.ResourceWithConfigure   = &SpacePropertyResource{}
+	_ resource.ResourceWithImportState = &SpacePropertyResource{}
+)
+
+func NewSpacePropertyResource() resource.Resource {
+	return &SpacePropertyResource{}
+}
+
+// SpacePropertyResource manages a property of a space.
+type SpacePropertyResource struct {
+	properties confluence.SpacePropertyService
+}
+
+type SpacePropertyResourceModel struct {
+	Id       types.String `tfsdk:"id"`
+	SpaceKey types.String `tfsdk:"space_key"`
+	Key      types.String `tfsdk:"key"`
+	Value    jsonValue    `tfsdk:"value"`
+	Version  types.Int64  `tfsdk:"version"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *SpacePropertyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_space_property"
+}
+
+func (r *SpacePropertyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The resource ```space_property``` stores a JSON value under a key on a space, e.g: the owning team or the cost centre of the space.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Property identifier, the space key and the property key separated by a slash.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"space_key": schema.StringAttribute{
+				MarkdownDescription: "The key of the space the property belongs to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key": schema.StringAttribute{
+				MarkdownDescription: "The key of the property.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"value": schema.StringAttribute{
+				MarkdownDescription: "The value of the property as a JSON document, e.g: `jsonencode({ team = \"devops\" })`. Whitespace and key order changes are not a diff.",
+				Required:            true,
+				CustomType:          jsonType{},
+			},
+			"version": schema.Int64Attribute{
+				MarkdownDescription: "The version of the property, it is incremented on every update.",
+				Computed:            true,
+			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *SpacePropertyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.properties = data.spaceProperties
+}
+
+func (r *SpacePropertyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SpacePropertyResourceModel
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	property := confluence.Property{
+		Key:   data.Key.ValueString(),
+		Value: json.RawMessage(data.Value.ValueString()),
+	}
+	err := r.properties.CreateSpaceProperty(ctx, data.SpaceKey.ValueString(), &property)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create space property, got error: %s", err))
+		return
+	}
+	data.Id = types.StringValue(spacePropertyId(data.SpaceKey.ValueString(), property.Key))
+	data.Version = propertyVersion(&property)
+	tflog.Trace(ctx, "created a space property")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SpacePropertyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SpacePropertyResourceModel
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	property, err := r.properties.GetSpaceProperty(ctx, data.SpaceKey.ValueString(), data.Key.ValueString())
+	if errors.Is(err, confluence.ErrNotFound) {
+		// The property or its space was deleted outside terraform, it is
+		// created again on the next apply.
+		tflog.Warn(ctx, "space property not found, removing it from the state", map[string]any{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read space property, got error: %s", err))
+		return
+	}
+	data.Id = types.StringValue(spacePropertyId(data.SpaceKey.ValueString(), property.Key))
+	data.Key = types.StringValue(property.Key)
+	data.Value = newJSONValue(string(property.Value))
+	data.Version = propertyVersion(property)
+
+	// Set refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SpacePropertyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data SpacePropertyResourceModel
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	property := confluence.Property{
+		Key:   data.Key.ValueString(),
+		Value: json.RawMessage(data.Value.ValueString()),
+	}
+	err := r.properties.UpdateSpaceProperty(ctx, data.SpaceKey.ValueString(), &property)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update space property, got error: %s", err))
+		return
+	}
+	data.Version = propertyVersion(&property)
+	tflog.Trace(ctx, "updated a space property")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SpacePropertyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SpacePropertyResourceModel
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.properties.DeleteSpaceProperty(ctx, data.SpaceKey.ValueString(), data.Key.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete space property, got error: %s", err))
+		return
+	}
+	tflog.Trace(ctx, "deleted a space property")
+}
+
+// ImportState accepts the space key and the property key separated by a slash.
+func (r *SpacePropertyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	spaceKey, key, ok := strings.Cut(req.ID, "/")
+	if !ok || spaceKey == "" || key == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import Identifier",
+			fmt.Sprintf("Expected an identifier with the format SPACEKEY/propertyKey, e.g: DEVOPS/team, got: %q", req.ID),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("space_key"), spaceKey)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("key"), key)...)
+}
+
+func spacePropertyId(spaceKey, key string) string {
+	return spaceKey + "/" + key
+}
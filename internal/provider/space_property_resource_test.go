@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluencefake"
+)
+
+func TestAccSpacePropertyResourceBasic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccSpacePropertyResourceConfigBasic("CC-100"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("confluence_space_property.test", "id", "DEVOPS/billing"),
+					resource.TestCheckResourceAttr("confluence_space_property.test", "value", `{"cost_centre":"CC-100","team":"devops"}`),
+					resource.TestCheckResourceAttr("confluence_space_property.test", "version", "1"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "confluence_space_property.test",
+				ImportState:       true,
+				ImportStateId:     "DEVOPS/billing",
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccSpacePropertyResourceConfigBasic("CC-200"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("confluence_space_property.test", "value", `{"cost_centre":"CC-200","team":"devops"}`),
+					resource.TestCheckResourceAttr("confluence_space_property.test", "version", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSpacePropertyResourceConfigBasic(costCentre string) string {
+	return fmt.Sprintf(`
+resource "confluence_space_property" "test" {
+  space_key = "DEVOPS"
+  key       = "billing"
+  value     = jsonencode({ team = "devops", cost_centre = %[1]q })
+}
+`, costCentre)
+}
+
+func TestSpacePropertyResourceDeletedOutsideTerraform(t *testing.T) {
+	ctx := context.Background()
+	server := confluencefake.NewServer()
+	t.Cleanup(server.Close)
+	server.AddSpace("DEVOPS", "devops")
+	r := &SpacePropertyResource{}
+	s, api := fakeConfiguredResource(t, r, server)
+	data := SpacePropertyResourceModel{
+		Id:       types.StringUnknown(),
+		SpaceKey: types.StringValue("DEVOPS"),
+		Key:      types.StringValue("billing"),
+		Value:    newJSONValue(`{"cost_centre":"CC-100"}`),
+		Version:  types.Int64Unknown(),
+		Timeouts: nullTimeouts(s),
+	}
+
+	plan := tfsdk.Plan{Schema: s}
+	plan.Set(ctx, &data)
+	createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: s}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatal(createResp.Diagnostics)
+	}
+
+	// The property is deleted in the UI, it is removed from the state.
+	err := api.DeleteSpaceProperty(ctx, "DEVOPS", "billing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	readResp := &fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatal(readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.IsNull() {
+		t.Error("wants the space property removed from the state")
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"context"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -25,7 +26,7 @@ func NewSpaceResource() resource.Resource {
 }
 
 type SpaceResource struct {
-	client *confluence.API
+	spaces confluence.SpaceService
 }
 
 type SpaceResourceModel struct {
@@ -33,13 +34,15 @@ type SpaceResourceModel struct {
 	Key         types.String `tfsdk:"key"`
 	Name        types.String `tfsdk:"name"`
 	Description types.String `tfsdk:"description"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *SpaceResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_space"
 }
 
-func (r *SpaceResource) Schema(_ context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *SpaceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: `Creates spaces, space is a container for organizing and grouping related pages of content.
 		Spaces can be used to separate content by project, team, department, or other criteria.
@@ -62,6 +65,12 @@ func (r *SpaceResource) Schema(_ context.Context, req resource.SchemaRequest, re
 				MarkdownDescription: "The description of the new/updated space.",
 				Optional:            true,
 			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
@@ -70,15 +79,15 @@ func (r *SpaceResource) Configure(_ context.Context, req resource.ConfigureReque
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*confluence.API)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *confluence.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
-	r.client = client
+	r.spaces = data.spaces
 }
 
 func (r *SpaceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -89,8 +98,17 @@ func (r *SpaceResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	// Calls GetSpace Confluece API Client method.
-	space, err := r.client.GetSpace(data.Key.ValueString())
+	space, err := r.spaces.GetSpace(ctx, data.Key.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read space, got error: %s", err))
 	}
@@ -121,6 +139,14 @@ func (r *SpaceResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	//Create Space confluence object
 
 	space := confluence.Space{
@@ -133,7 +159,7 @@ func (r *SpaceResource) Create(ctx context.Context, req resource.CreateRequest,
 		},
 	}
 
-	err := r.client.CreateSpace(&space)
+	err := r.spaces.CreateSpace(ctx, &space)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create space, got error: %s", err))
 	}
@@ -154,6 +180,14 @@ func (r *SpaceResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	//Create a space object
 	spacedescription := confluence.SpaceDescription{
 		Plain: confluence.Plain{
@@ -166,7 +200,7 @@ func (r *SpaceResource) Update(ctx context.Context, req resource.UpdateRequest,
 		Name:        data.Name.ValueString(),
 		Description: &spacedescription,
 	}
-	err := r.client.UpdateSpace(&space)
+	err := r.spaces.UpdateSpace(ctx, &space)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update space, got error %v", err))
 		return
@@ -196,8 +230,16 @@ func (r *SpaceResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// Delete space from API
-	err := r.client.DeleteSpace(data.Key.ValueString())
+	err := r.spaces.DeleteSpace(ctx, data.Key.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete space, got error %v", err))
 		return
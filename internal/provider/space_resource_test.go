@@ -1,9 +1,13 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
@@ -52,3 +56,56 @@ func testAccSpaceResourceConfigBasic(name, description string) string {
 	}
 	`, name, description)
 }
+
+func TestSpaceResourceCRUD(t *testing.T) {
+	ctx := context.Background()
+	m := newMockConfluence()
+	r := &SpaceResource{}
+	s := configuredResource(t, r, m)
+	data := SpaceResourceModel{
+		Id:          types.Int64Unknown(),
+		Key:         types.StringValue("TERRAFORM"),
+		Name:        types.StringValue("TERRAFORM"),
+		Description: types.StringValue("create"),
+		Timeouts:    nullTimeouts(s),
+	}
+
+	plan := tfsdk.Plan{Schema: s}
+	plan.Set(ctx, &data)
+	createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: s}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatal(createResp.Diagnostics)
+	}
+	assertCalls(t, m, "CreateSpace TERRAFORM")
+	createResp.State.Get(ctx, &data)
+	if data.Id.ValueInt64() != 1001 {
+		t.Errorf("wants id 1001, but got %v", data.Id)
+	}
+
+	readResp := &fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatal(readResp.Diagnostics)
+	}
+	assertCalls(t, m, "GetSpace TERRAFORM")
+
+	data.Name = types.StringValue("terraform update")
+	plan.Set(ctx, &data)
+	updateResp := &fwresource.UpdateResponse{State: readResp.State}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: readResp.State}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatal(updateResp.Diagnostics)
+	}
+	assertCalls(t, m, "UpdateSpace TERRAFORM terraform update")
+
+	deleteResp := &fwresource.DeleteResponse{State: updateResp.State}
+	r.Delete(ctx, fwresource.DeleteRequest{State: updateResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatal(deleteResp.Diagnostics)
+	}
+	assertCalls(t, m, "DeleteSpace TERRAFORM")
+	if len(m.spaces) != 0 {
+		t.Errorf("wants no spaces left, but got %v", m.spaces)
+	}
+}
@@ -0,0 +1,247 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluence"
+)
+
+var (
+	_ resource.Resource                = &SpaceWatchersResource{}
+	_ resource.ResourceWithConfigure   = &SpaceWatchersResource{}
+	_ resource.ResourceWithImportState = &SpaceWatchersResource{}
+)
+
+func NewSpaceWatchersResource() resource.Resource {
+	return &SpaceWatchersResource{}
+}
+
+// SpaceWatchersResource manages every user watching a space.
+type SpaceWatchersResource struct {
+	watchers confluence.WatcherService
+}
+
+type SpaceWatchersResourceModel struct {
+	Id         types.String `tfsdk:"id"`
+	SpaceKey   types.String `tfsdk:"space_key"`
+	AccountIds types.Set    `tfsdk:"account_ids"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *SpaceWatchersResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_space_watchers"
+}
+
+func (r *SpaceWatchersResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The resource ```space_watchers``` manages the users watching a space, they are notified when its pages and blog posts are created or edited. " +
+			"The watchers are authoritative, users watching the space outside terraform are removed on the next apply.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Watchers identifier, the space key.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"space_key": schema.StringAttribute{
+				MarkdownDescription: "The key of the watched space.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"account_ids": schema.SetAttribute{
+				MarkdownDescription: "The account ids of the users watching the space, e.g: from the `confluence_user` data source.",
+				ElementType:         types.StringType,
+				Required:            true,
+			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *SpaceWatchersResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.watchers = data.watchers
+}
+
+func (r *SpaceWatchersResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SpaceWatchersResourceModel
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(r.setWatchers(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = data.SpaceKey
+	tflog.Trace(ctx, "set the space watchers")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SpaceWatchersResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SpaceWatchersResourceModel
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	watchers, err := r.watchers.GetSpaceWatchers(ctx, data.SpaceKey.ValueString())
+	if errors.Is(err, confluence.ErrNotFound) {
+		// The space was deleted outside terraform, the watchers are
+		// set again on the next apply.
+		tflog.Warn(ctx, "space not found, removing its watchers from the state", map[string]any{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read space watchers, got error: %s", err))
+		return
+	}
+	data.AccountIds, diags = types.SetValueFrom(ctx, types.StringType, watcherAccountIds(watchers))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SpaceWatchersResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data SpaceWatchersResourceModel
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(r.setWatchers(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "updated the space watchers")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SpaceWatchersResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SpaceWatchersResourceModel
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	var accountIds []string
+	resp.Diagnostics.Append(data.AccountIds.ElementsAs(ctx, &accountIds, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for _, accountId := range accountIds {
+		err := r.watchers.UnwatchSpace(ctx, data.SpaceKey.ValueString(), accountId)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove space watcher %s, got error: %s", accountId, err))
+			return
+		}
+	}
+	tflog.Trace(ctx, "removed the space watchers")
+}
+
+// ImportState accepts the space key.
+func (r *SpaceWatchersResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("space_key"), req.ID)...)
+}
+
+// setWatchers adds and removes watchers until the space is watched by the
+// account ids of the model.
+func (r *SpaceWatchersResource) setWatchers(ctx context.Context, data *SpaceWatchersResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	key := data.SpaceKey.ValueString()
+	var want []string
+	diags.Append(data.AccountIds.ElementsAs(ctx, &want, false)...)
+	if diags.HasError() {
+		return diags
+	}
+	current, err := r.watchers.GetSpaceWatchers(ctx, key)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read space watchers, got error: %s", err))
+		return diags
+	}
+	err = reconcileWatchers(ctx, watcherAccountIds(current), want,
+		func(accountId string) error { return r.watchers.WatchSpace(ctx, key, accountId) },
+		func(accountId string) error { return r.watchers.UnwatchSpace(ctx, key, accountId) },
+	)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to set space watchers, got error: %s", err))
+	}
+	return diags
+}
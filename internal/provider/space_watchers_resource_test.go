@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluencefake"
+)
+
+func TestAccSpaceWatchersResourceBasic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccSpaceWatchersResourceConfig("[data.confluence_current_user.me.account_id]"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("confluence_space_watchers.test", "id", "DEVOPS"),
+					resource.TestCheckTypeSetElemAttr("confluence_space_watchers.test", "account_ids.*", confluencefake.CurrentAccountId),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "confluence_space_watchers.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccSpaceWatchersResourceConfig("[]"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("confluence_space_watchers.test", "account_ids.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSpaceWatchersResourceConfig(accountIds string) string {
+	return fmt.Sprintf(`
+data "confluence_current_user" "me" {}
+
+resource "confluence_space_watchers" "test" {
+  space_key   = "DEVOPS"
+  account_ids = %s
+}
+`, accountIds)
+}
+
+func TestSpaceWatchersResourceDeletedOutsideTerraform(t *testing.T) {
+	ctx := context.Background()
+	server := confluencefake.NewServer()
+	t.Cleanup(server.Close)
+	server.AddSpace("DEVOPS", "devops")
+	r := &SpaceWatchersResource{}
+	s, _ := fakeConfiguredResource(t, r, server)
+	// The space of the state was deleted in the UI.
+	accountIds, _ := types.SetValueFrom(ctx, types.StringType, []string{"alice"})
+	data := SpaceWatchersResourceModel{
+		Id:         types.StringValue("ARCHIVE"),
+		SpaceKey:   types.StringValue("ARCHIVE"),
+		AccountIds: accountIds,
+		Timeouts:   nullTimeouts(s),
+	}
+	state := tfsdk.State{Schema: s}
+	state.Set(ctx, &data)
+
+	readResp := &fwresource.ReadResponse{State: state}
+	r.Read(ctx, fwresource.ReadRequest{State: state}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatal(readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.IsNull() {
+		t.Error("wants the space watchers removed from the state")
+	}
+}
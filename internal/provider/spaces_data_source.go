@@ -0,0 +1,216 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluence"
+)
+
+var (
+	_ datasource.DataSource              = &spacesDataSource{}
+	_ datasource.DataSourceWithConfigure = &spacesDataSource{}
+)
+
+func NewSpacesDataSource() datasource.DataSource {
+	return &spacesDataSource{}
+}
+
+type spacesDataSource struct {
+	spaces confluence.SpaceService
+}
+
+type SpacesDataSourceModel struct {
+	Id        types.String                 `tfsdk:"id"`
+	Keys      []types.String               `tfsdk:"keys"`
+	Type      types.String                 `tfsdk:"type"`
+	Status    types.String                 `tfsdk:"status"`
+	Labels    []types.String               `tfsdk:"labels"`
+	Favourite types.Bool                   `tfsdk:"favourite"`
+	Spaces    []SpacesDataSourceSpaceModel `tfsdk:"spaces"`
+}
+
+type SpacesDataSourceSpaceModel struct {
+	Id         types.Int64  `tfsdk:"id"`
+	Key        types.String `tfsdk:"key"`
+	Name       types.String `tfsdk:"name"`
+	Type       types.String `tfsdk:"type"`
+	Status     types.String `tfsdk:"status"`
+	HomepageId types.String `tfsdk:"homepage_id"`
+}
+
+// Metadata returns the data source type name.
+func (d *spacesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_spaces"
+}
+
+// Schema defines the spaces data source schema.
+func (d *spacesDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Returns every space that matches the filters, e.g: to build an index page that links to every team space. Without filters every space visible to the user is returned.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the query, built from the filters.",
+				Computed:            true,
+			},
+			"keys": schema.ListAttribute{
+				MarkdownDescription: "Returns only the spaces with these keys.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Returns only the spaces of this type, global or personal.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("global", "personal"),
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Returns only the spaces with this status, current or archived.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("current", "archived"),
+				},
+			},
+			"labels": schema.ListAttribute{
+				MarkdownDescription: "Returns only the spaces with every one of these labels.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"favourite": schema.BoolAttribute{
+				MarkdownDescription: "Returns only the spaces the provider user marked as favourite.",
+				Optional:            true,
+			},
+			"spaces": schema.ListNestedAttribute{
+				MarkdownDescription: "The spaces that match the filters.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							MarkdownDescription: "Space identifier number.",
+							Computed:            true,
+						},
+						"key": schema.StringAttribute{
+							MarkdownDescription: "The key of the space.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the space.",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Type of the space, e.g: global, personal.",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							MarkdownDescription: "Current status for the space.",
+							Computed:            true,
+						},
+						"homepage_id": schema.StringAttribute{
+							MarkdownDescription: "Identifier of the space homepage.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *spacesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SpacesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query := confluence.SpaceQuery{
+		Keys:      stringValues(data.Keys),
+		Type:      data.Type.ValueString(),
+		Status:    data.Status.ValueString(),
+		Labels:    stringValues(data.Labels),
+		Favourite: data.Favourite.ValueBool(),
+	}
+	spaces, err := d.spaces.ListSpaces(ctx, query)
+	if err != nil {
+		resp.Diagnostics.AddError("Spaces Data Source Client Error", err.Error())
+		return
+	}
+
+	data.Id = types.StringValue(spacesQueryId(query))
+	data.Spaces = []SpacesDataSourceSpaceModel{}
+	for _, space := range spaces {
+		homepageId := ""
+		if space.Homepage != nil {
+			homepageId = space.Homepage.Id
+		}
+		data.Spaces = append(data.Spaces, SpacesDataSourceSpaceModel{
+			Id:         types.Int64Value(int64(space.Id)),
+			Key:        types.StringValue(space.Key),
+			Name:       types.StringValue(space.Name),
+			Type:       types.StringValue(space.Type),
+			Status:     types.StringValue(space.Status),
+			HomepageId: types.StringValue(homepageId),
+		})
+	}
+
+	// Set State
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *spacesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.spaces = data.spaces
+}
+
+// spacesQueryId returns a stable identifier for the filters of a query.
+func spacesQueryId(query confluence.SpaceQuery) string {
+	params := url.Values{}
+	for _, key := range query.Keys {
+		params.Add("key", key)
+	}
+	for _, label := range query.Labels {
+		params.Add("label", label)
+	}
+	if query.Type != "" {
+		params.Set("type", query.Type)
+	}
+	if query.Status != "" {
+		params.Set("status", query.Status)
+	}
+	if query.Favourite {
+		params.Set("favourite", "true")
+	}
+	if len(params) == 0 {
+		return "all"
+	}
+	return params.Encode()
+}
+
+// stringValues returns the known values of a list attribute.
+func stringValues(values []types.String) []string {
+	s := []string{}
+	for _, v := range values {
+		if !v.IsNull() && !v.IsUnknown() {
+			s = append(s, v.ValueString())
+		}
+	}
+	return s
+}
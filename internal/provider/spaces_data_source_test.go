@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccSpacesDataSourceBasic(t *testing.T) {
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck: func() {
+				testAccPreCheck(t)
+			},
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccSpacesDataSourceConfigBasic,
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.confluence_spaces.test", "spaces.#", "1"),
+						resource.TestCheckResourceAttr("data.confluence_spaces.test", "spaces.0.key", "DEVOPS"),
+						resource.TestCheckResourceAttr("data.confluence_spaces.test", "spaces.0.type", "global"),
+						resource.TestCheckResourceAttrSet("data.confluence_spaces.test", "spaces.0.homepage_id"),
+					),
+				},
+			},
+		},
+	)
+}
+
+const testAccSpacesDataSourceConfigBasic = `
+data "confluence_spaces" "test" {
+  keys   = ["DEVOPS"]
+  type   = "global"
+  status = "current"
+}
+`
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// storageEqual reports whether two bodies in the confluence storage format
+// are the same once normalised. Confluence rewrites the bodies it stores,
+// e.g: it orders attributes, closes empty elements and replaces entities, so
+// a body read back is compared with the configured one by value.
+func storageEqual(a, b string) bool {
+	if a == b {
+		return true
+	}
+	normalisedA, err := normaliseStorage(a)
+	if err != nil {
+		return false
+	}
+	normalisedB, err := normaliseStorage(b)
+	if err != nil {
+		return false
+	}
+	return normalisedA == normalisedB
+}
+
+// normaliseStorage writes the elements of a storage format body with sorted
+// attributes and explicit end tags, and its text with entities decoded.
+// Text made only of white space between elements is dropped. HTML void
+// elements, e.g: <br>, are closed where they start, the prefixed elements of
+// confluence, e.g: <ac:link>, are never void.
+func normaliseStorage(body string) (string, error) {
+	decoder := xml.NewDecoder(strings.NewReader("<storage>" + body + "</storage>"))
+	decoder.Strict = false
+	decoder.Entity = xml.HTMLEntity
+	var b strings.Builder
+	for {
+		token, err := decoder.RawToken()
+		if errors.Is(err, io.EOF) {
+			return b.String(), nil
+		}
+		if err != nil {
+			return "", err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			attrs := make([]string, 0, len(t.Attr))
+			for _, attr := range t.Attr {
+				attrs = append(attrs, storageName(attr.Name)+"="+strconv.Quote(attr.Value))
+			}
+			sort.Strings(attrs)
+			b.WriteString("<" + storageName(t.Name))
+			for _, attr := range attrs {
+				b.WriteString(" " + attr)
+			}
+			b.WriteString(">")
+			if isVoidElement(t.Name) {
+				b.WriteString("</" + storageName(t.Name) + ">")
+			}
+		case xml.EndElement:
+			if !isVoidElement(t.Name) {
+				b.WriteString("</" + storageName(t.Name) + ">")
+			}
+		case xml.CharData:
+			if strings.TrimSpace(string(t)) != "" {
+				b.WriteString(strconv.Quote(string(t)))
+			}
+		case xml.Comment:
+			b.WriteString("<!--" + string(t) + "-->")
+		}
+	}
+}
+
+func storageName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
+
+func isVoidElement(name xml.Name) bool {
+	if name.Space != "" {
+		return false
+	}
+	for _, void := range xml.HTMLAutoClose {
+		if strings.EqualFold(void, name.Local) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package provider
+
+import "testing"
+
+func TestStorageEqual(t *testing.T) {
+	testCases := []struct {
+		desc string
+		a    string
+		b    string
+		want bool
+	}{
+		{
+			desc: "Same body",
+			a:    "<p>runbook</p>",
+			b:    "<p>runbook</p>",
+			want: true,
+		},
+		{
+			desc: "Attribute order and quotes",
+			a:    `<ac:link ac:anchor="top" ac:card-appearance='inline'><ri:page ri:content-title="Oncall" /></ac:link>`,
+			b:    `<ac:link ac:card-appearance="inline" ac:anchor="top"><ri:page ri:content-title="Oncall"></ri:page></ac:link>`,
+			want: true,
+		},
+		{
+			desc: "Self closing and HTML void elements",
+			a:    "<p>first<br>second</p>",
+			b:    "<p>first<br />second</p>",
+			want: true,
+		},
+		{
+			desc: "Entities",
+			a:    "<p>a&nbsp;&amp;&nbsp;b &quot;c&quot;</p>",
+			b:    "<p>a &amp; b \"c\"</p>",
+			want: true,
+		},
+		{
+			desc: "White space between elements",
+			a:    "<h1>Oncall</h1>\n<p>page</p>\n",
+			b:    "<h1>Oncall</h1><p>page</p>",
+			want: true,
+		},
+		{
+			desc: "Different text",
+			a:    "<p>runbook</p>",
+			b:    "<p>run book</p>",
+		},
+		{
+			desc: "Different attribute value",
+			a:    `<ac:structured-macro ac:name="info" />`,
+			b:    `<ac:structured-macro ac:name="note" />`,
+		},
+		{
+			desc: "Different elements",
+			a:    "<p><strong>runbook</strong></p>",
+			b:    "<p><em>runbook</em></p>",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if got := storageEqual(tC.a, tC.b); got != tC.want {
+				t.Errorf("storageEqual(%q, %q), wants %v, but got %v", tC.a, tC.b, tC.want, got)
+			}
+		})
+	}
+}
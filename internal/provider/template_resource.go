@@ -0,0 +1,295 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluence"
+)
+
+var (
+	_ resource.Resource                = &TemplateResource{}
+	_ resource.ResourceWithConfigure   = &TemplateResource{}
+	_ resource.ResourceWithImportState = &TemplateResource{}
+)
+
+func NewTemplateResource() resource.Resource {
+	return &TemplateResource{}
+}
+
+// TemplateResource manages a page template of a space or a global template.
+type TemplateResource struct {
+	templates confluence.TemplateService
+}
+
+type TemplateResourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Body        types.String `tfsdk:"body"`
+	Labels      types.Set    `tfsdk:"labels"`
+	Space       types.String `tfsdk:"space"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *TemplateResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_template"
+}
+
+func (r *TemplateResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The resource ```template``` manages a page template of a space, or a global template available in every space, e.g: the team runbook template. " +
+			"Blueprint templates are provided by apps and can not be managed.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Template identifier.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the template, it must be unique in the space or among the global templates.",
+				Required:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "The description of the template. Defaults to an empty description.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"body": schema.StringAttribute{
+				MarkdownDescription: "The body of the template in storage format, variables are declared with `<at:var at:name=\"name\" />`.",
+				Required:            true,
+			},
+			"labels": schema.SetAttribute{
+				MarkdownDescription: "Global labels added to the pages created from the template.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"space": schema.StringAttribute{
+				MarkdownDescription: "The key of the space the template belongs to, a global template is created when it is not set. Changing it replaces the template.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *TemplateResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.templates = data.templates
+}
+
+func (r *TemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data TemplateResourceModel
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	template, diags := newTemplate(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := r.templates.CreateTemplate(ctx, template)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create template, got error: %s", err))
+		return
+	}
+	data.Id = types.StringValue(template.TemplateId)
+	resp.Diagnostics.Append(setTemplateModel(ctx, &data, template)...)
+	tflog.Trace(ctx, "created a template")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TemplateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data TemplateResourceModel
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	template, err := r.templates.GetTemplate(ctx, data.Id.ValueString())
+	if errors.Is(err, confluence.ErrNotFound) {
+		// The template was deleted outside terraform, it is created again on
+		// the next apply.
+		tflog.Warn(ctx, "template not found, removing it from the state", map[string]any{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read template, got error: %s", err))
+		return
+	}
+	if template.OriginalTemplate != nil && template.OriginalTemplate.ModuleKey != "" {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Template %s is a blueprint template, blueprints can not be managed", template.TemplateId))
+		return
+	}
+	resp.Diagnostics.Append(setTemplateModel(ctx, &data, template)...)
+
+	// Set refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data TemplateResourceModel
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	template, diags := newTemplate(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	template.TemplateId = data.Id.ValueString()
+	err := r.templates.UpdateTemplate(ctx, template)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update template, got error: %s", err))
+		return
+	}
+	resp.Diagnostics.Append(setTemplateModel(ctx, &data, template)...)
+	tflog.Trace(ctx, "updated a template")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TemplateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data TemplateResourceModel
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.templates.DeleteTemplate(ctx, data.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete template, got error: %s", err))
+		return
+	}
+	tflog.Trace(ctx, "deleted a template")
+}
+
+// ImportState accepts the id of the template.
+func (r *TemplateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// newTemplate returns the template of the model to create or update.
+func newTemplate(ctx context.Context, data *TemplateResourceModel) (*confluence.Template, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	template := &confluence.Template{
+		Name:        data.Name.ValueString(),
+		Description: data.Description.ValueString(),
+		Body: &confluence.Body{
+			Storage: confluence.Storage{
+				Value:          data.Body.ValueString(),
+				Representation: "storage",
+			},
+		},
+	}
+	if !data.Space.IsNull() {
+		template.Space = &confluence.Space{Key: data.Space.ValueString()}
+	}
+	if !data.Labels.IsUnknown() && !data.Labels.IsNull() {
+		var names []string
+		diags.Append(data.Labels.ElementsAs(ctx, &names, false)...)
+		for _, name := range names {
+			template.Labels = append(template.Labels, confluence.Label{Prefix: "global", Name: name})
+		}
+	}
+	return template, diags
+}
+
+// setTemplateModel writes the template returned by confluence into the model,
+// the body is kept when confluence does not return it.
+func setTemplateModel(ctx context.Context, data *TemplateResourceModel, template *confluence.Template) diag.Diagnostics {
+	data.Name = types.StringValue(template.Name)
+	data.Description = types.StringValue(template.Description)
+	if template.Body != nil {
+		data.Body = types.StringValue(template.Body.Storage.Value)
+	}
+	data.Space = types.StringNull()
+	if template.Space != nil && template.Space.Key != "" {
+		data.Space = types.StringValue(template.Space.Key)
+	}
+	var diags diag.Diagnostics
+	data.Labels, diags = types.SetValueFrom(ctx, types.StringType, labelNames(template.Labels))
+	return diags
+}
@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccTemplateResourceBasic(t *testing.T) {
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck: func() {
+				testAccPreCheck(t)
+			},
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				// Create and Read testing
+				{
+					Config: testAccTemplateResourceConfig("<h2>Alerts</h2>"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttrSet("confluence_template.test", "id"),
+						resource.TestCheckResourceAttr("confluence_template.test", "space", "DEVOPS"),
+						resource.TestCheckResourceAttr("confluence_template.test", "labels.#", "1"),
+						resource.TestCheckResourceAttr("confluence_template.global", "description", ""),
+						resource.TestCheckNoResourceAttr("confluence_template.global", "space"),
+						resource.TestCheckResourceAttr("data.confluence_templates.test", "templates.#", "1"),
+						resource.TestCheckResourceAttrPair("data.confluence_templates.test", "templates.0.id", "confluence_template.test", "id"),
+					),
+				},
+				// ImportState testing
+				{
+					ResourceName:            "confluence_template.test",
+					ImportState:             true,
+					ImportStateVerify:       true,
+					ImportStateVerifyIgnore: []string{"timeouts"},
+				},
+				// Update and Read testing
+				{
+					Config: testAccTemplateResourceConfig("<h2>Alerts</h2><h2>Dashboards</h2>"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("confluence_template.test", "body", "<h2>Alerts</h2><h2>Dashboards</h2>"),
+						resource.TestCheckResourceAttr("data.confluence_templates.test", "templates.0.body", "<h2>Alerts</h2><h2>Dashboards</h2>"),
+					),
+				},
+				// Delete testing automatically occurs in TestCase
+			},
+		},
+	)
+}
+
+func testAccTemplateResourceConfig(body string) string {
+	return fmt.Sprintf(`
+resource "confluence_template" "test" {
+  name        = "Runbook"
+  description = "Runbook of a service"
+  body        = %[1]q
+  labels      = ["runbook"]
+  space       = "DEVOPS"
+}
+
+resource "confluence_template" "global" {
+  name = "Terraform Acc ADR"
+  body = "<h2>Decision</h2>"
+}
+
+data "confluence_templates" "test" {
+  space = confluence_template.test.space
+  name  = confluence_template.test.name
+}
+`, body)
+}
+
+func TestAccTemplatesDataSourceBlueprints(t *testing.T) {
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck: func() {
+				testAccPreCheck(t)
+			},
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccTemplatesDataSourceConfigBlueprints,
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.confluence_templates.test", "templates.#", "1"),
+						resource.TestCheckResourceAttr("data.confluence_templates.test", "templates.0.module_key", "meeting-notes-page"),
+					),
+				},
+			},
+		},
+	)
+}
+
+const testAccTemplatesDataSourceConfigBlueprints = `
+data "confluence_templates" "test" {
+  space = "DEVOPS"
+  name  = "Meeting notes"
+}
+`
+
+func TestTemplateResourceDeletedOutsideTerraform(t *testing.T) {
+	ctx := context.Background()
+	m := newMockConfluence()
+	r := &TemplateResource{}
+	s := configuredResource(t, r, m)
+	// The template of the state was deleted in the UI.
+	data := TemplateResourceModel{
+		Id:          types.StringValue("2001"),
+		Name:        types.StringValue("Incident"),
+		Description: types.StringValue(""),
+		Body:        types.StringValue("<h2>Alerts</h2>"),
+		Labels:      types.SetNull(types.StringType),
+		Space:       types.StringValue("DEVOPS"),
+		Timeouts:    nullTimeouts(s),
+	}
+	state := tfsdk.State{Schema: s}
+	state.Set(ctx, &data)
+
+	readResp := &fwresource.ReadResponse{State: state}
+	r.Read(ctx, fwresource.ReadRequest{State: state}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatal(readResp.Diagnostics)
+	}
+	assertCalls(t, m, "GetTemplate 2001")
+	if !readResp.State.Raw.IsNull() {
+		t.Error("wants the template removed from the state")
+	}
+}
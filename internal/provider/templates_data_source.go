@@ -0,0 +1,167 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluence"
+)
+
+var (
+	_ datasource.DataSource              = &templatesDataSource{}
+	_ datasource.DataSourceWithConfigure = &templatesDataSource{}
+)
+
+func NewTemplatesDataSource() datasource.DataSource {
+	return &templatesDataSource{}
+}
+
+type templatesDataSource struct {
+	templates confluence.TemplateService
+}
+
+type TemplatesDataSourceModel struct {
+	Id        types.String                       `tfsdk:"id"`
+	Space     types.String                       `tfsdk:"space"`
+	Name      types.String                       `tfsdk:"name"`
+	Templates []TemplatesDataSourceTemplateModel `tfsdk:"templates"`
+}
+
+type TemplatesDataSourceTemplateModel struct {
+	Id          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Body        types.String `tfsdk:"body"`
+	Labels      types.Set    `tfsdk:"labels"`
+	ModuleKey   types.String `tfsdk:"module_key"`
+}
+
+// Metadata returns the data source type name.
+func (d *templatesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_templates"
+}
+
+// Schema defines the templates data source schema.
+func (d *templatesDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Returns the page and blueprint templates of a space or the global ones, e.g: to create pages from a template id.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the query, the space key and the name separated by a slash.",
+				Computed:            true,
+			},
+			"space": schema.StringAttribute{
+				MarkdownDescription: "The key of the space, the global templates are returned when it is not set.",
+				Optional:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Returns only the templates with this name.",
+				Optional:            true,
+			},
+			"templates": schema.ListNestedAttribute{
+				MarkdownDescription: "The page templates followed by the blueprint templates.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Template identifier.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the template.",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "The description of the template.",
+							Computed:            true,
+						},
+						"body": schema.StringAttribute{
+							MarkdownDescription: "The body of a page template in storage format, it is not returned for blueprint templates.",
+							Computed:            true,
+						},
+						"labels": schema.SetAttribute{
+							MarkdownDescription: "Global labels added to the pages created from the template.",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"module_key": schema.StringAttribute{
+							MarkdownDescription: "The module key of a blueprint template, e.g: `meeting-notes-page`, it is not set for page templates.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *templatesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data TemplatesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	templates := []confluence.Template{}
+	for _, blueprints := range []bool{false, true} {
+		results, err := d.templates.ListTemplates(ctx, confluence.TemplateQuery{
+			SpaceKey:   data.Space.ValueString(),
+			Blueprints: blueprints,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Templates Data Source Client Error", err.Error())
+			return
+		}
+		templates = append(templates, results...)
+	}
+
+	data.Id = types.StringValue(data.Space.ValueString() + "/" + data.Name.ValueString())
+	data.Templates = []TemplatesDataSourceTemplateModel{}
+	for _, template := range templates {
+		if !data.Name.IsNull() && template.Name != data.Name.ValueString() {
+			continue
+		}
+		labels, diags := types.SetValueFrom(ctx, types.StringType, labelNames(template.Labels))
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		model := TemplatesDataSourceTemplateModel{
+			Id:          types.StringValue(template.TemplateId),
+			Name:        types.StringValue(template.Name),
+			Description: types.StringValue(template.Description),
+			Body:        types.StringNull(),
+			Labels:      labels,
+			ModuleKey:   types.StringNull(),
+		}
+		if template.Body != nil {
+			model.Body = types.StringValue(template.Body.Storage.Value)
+		}
+		if template.OriginalTemplate != nil && template.OriginalTemplate.ModuleKey != "" {
+			model.ModuleKey = types.StringValue(template.OriginalTemplate.ModuleKey)
+		}
+		data.Templates = append(data.Templates, model)
+	}
+
+	// Set State
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *templatesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.templates = data.templates
+}
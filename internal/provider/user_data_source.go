@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluence"
+)
+
+var (
+	_ datasource.DataSource                     = &userDataSource{}
+	_ datasource.DataSourceWithConfigure        = &userDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &userDataSource{}
+)
+
+func NewUserDataSource() datasource.DataSource {
+	return &userDataSource{}
+}
+
+type userDataSource struct {
+	users confluence.UserService
+}
+
+type UserDataSourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	AccountId   types.String `tfsdk:"account_id"`
+	Email       types.String `tfsdk:"email"`
+	DisplayName types.String `tfsdk:"display_name"`
+	PublicName  types.String `tfsdk:"public_name"`
+	AccountType types.String `tfsdk:"account_type"`
+}
+
+// Metadata returns the data source type name.
+func (d *userDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user"
+}
+
+// Schema defines the user data source schema.
+func (d *userDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up a user by account id or display name, e.g: to get the account id used by restrictions and mentions. Exactly one of them must be set. " +
+			"Confluence can not search users by email, and cloud hides the email of most users.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The account id of the user.",
+				Computed:            true,
+			},
+			"account_id": schema.StringAttribute{
+				MarkdownDescription: "The Atlassian account id of the user.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"email": schema.StringAttribute{
+				MarkdownDescription: "The email of the user, it is only returned when the user privacy settings allow it.",
+				Computed:            true,
+			},
+			"display_name": schema.StringAttribute{
+				MarkdownDescription: "The display name of the user, the lookup fails when more than one user has it.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"public_name": schema.StringAttribute{
+				MarkdownDescription: "The public name of the user.",
+				Computed:            true,
+			},
+			"account_type": schema.StringAttribute{
+				MarkdownDescription: "Type of the account, e.g: atlassian, app.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *userDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("account_id"),
+			path.MatchRoot("display_name"),
+		),
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *userDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data UserDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, err := findUser(ctx, d.users, data.AccountId.ValueString(), data.DisplayName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("User Data Source Client Error", err.Error())
+		return
+	}
+	data.Id = types.StringValue(user.AccountId)
+	setUserModel(&data, user)
+
+	// Set State
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *userDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.users = data.users
+}
+
+// findUser returns the user with the account id, or the only user with the
+// display name. The user search matches part of the name, so the results are
+// filtered by the exact value.
+func findUser(ctx context.Context, users confluence.UserService, accountId, displayName string) (*confluence.User, error) {
+	if accountId != "" {
+		return users.GetUser(ctx, accountId)
+	}
+	results, err := users.SearchUsers(ctx, "user.fullname~"+cqlString(displayName))
+	if err != nil {
+		return nil, err
+	}
+	matches := []confluence.User{}
+	for _, user := range results {
+		if strings.EqualFold(user.DisplayName, displayName) {
+			matches = append(matches, user)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no user found with display name %q", displayName)
+	case 1:
+		return &matches[0], nil
+	default:
+		return nil, fmt.Errorf("%d users found with display name %q, use account_id instead", len(matches), displayName)
+	}
+}
+
+// setUserModel copies the user fields the user and current user data
+// sources have in common.
+func setUserModel(data *UserDataSourceModel, user *confluence.User) {
+	data.AccountId = types.StringValue(user.AccountId)
+	data.Email = types.StringValue(user.Email)
+	data.DisplayName = types.StringValue(user.DisplayName)
+	data.PublicName = types.StringValue(user.PublicName)
+	data.AccountType = types.StringValue(user.AccountType)
+}
+
+// cqlString quotes a value for a CQL query.
+func cqlString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluence"
+	"github.com/renemontilva/terraform-provider-confluence/internal/confluencefake"
+)
+
+func TestAccUserDataSourceBasic(t *testing.T) {
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck: func() {
+				testAccPreCheck(t)
+			},
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccUserDataSourceConfigBasic,
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttrSet("data.confluence_current_user.test", "account_id"),
+						resource.TestCheckResourceAttrPair("data.confluence_user.by_account_id", "display_name", "data.confluence_current_user.test", "display_name"),
+						resource.TestCheckResourceAttrPair("data.confluence_user.by_display_name", "account_id", "data.confluence_current_user.test", "account_id"),
+					),
+				},
+			},
+		},
+	)
+}
+
+const testAccUserDataSourceConfigBasic = `
+data "confluence_current_user" "test" {}
+
+data "confluence_user" "by_account_id" {
+  account_id = data.confluence_current_user.test.account_id
+}
+
+data "confluence_user" "by_display_name" {
+  display_name = data.confluence_current_user.test.display_name
+}
+`
+
+func TestFindUser(t *testing.T) {
+	server := confluencefake.NewServer()
+	t.Cleanup(server.Close)
+	server.AddUser("1", "Jane Doe", "jane@example.com")
+	server.AddUser("2", "Jane Doe", "jane.doe@example.com")
+	server.AddUser("3", "John Doe", "john@example.com")
+	api, err := confluence.NewAPI("user@example.com", "token", server.SiteURL())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		desc        string
+		accountId   string
+		displayName string
+		want        string
+		wantErr     bool
+	}{
+		{
+			desc:      "account id",
+			accountId: "3",
+			want:      "3",
+		},
+		{
+			desc:        "display name",
+			displayName: "john doe",
+			want:        "3",
+		},
+		{
+			desc:        "display name of several users",
+			displayName: "Jane Doe",
+			wantErr:     true,
+		},
+		{
+			desc:        "display name partial match",
+			displayName: "Doe",
+			wantErr:     true,
+		},
+		{
+			desc:        "email is not a display name",
+			displayName: "john@example.com",
+			wantErr:     true,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			user, err := findUser(context.Background(), api, tC.accountId, tC.displayName)
+			if tC.wantErr {
+				if err == nil {
+					t.Errorf("wants an error, but got user %s", user.AccountId)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if user.AccountId != tC.want {
+				t.Errorf("wants user %s, but got %s", tC.want, user.AccountId)
+			}
+		})
+	}
+}
+
+func TestCQLString(t *testing.T) {
+	got := cqlString(`Jane "JD" \ Doe`)
+	want := `"Jane \"JD\" \\ Doe"`
+	if got != want {
+		t.Errorf("wants %s, but got %s", want, got)
+	}
+}